@@ -5,11 +5,16 @@ import (
 	"log"
 	"math"
 	"sync"
+	"time"
 
 	"gmcc/internal/commands"
+	"gmcc/internal/dialog"
+	"gmcc/internal/item"
 	"gmcc/internal/mcclient"
 	"gmcc/internal/mcclient/packet"
 	"gmcc/internal/mcclient/protocol"
+	"gmcc/internal/player"
+	"gmcc/internal/world"
 )
 
 type ClientAdapter struct {
@@ -211,3 +216,163 @@ func (c *ClientAdapter) InteractEntity(entityID int32) error {
 	// 发送交互包 (右键点击实体)
 	return client.SendInteract(entityID, protocol.InteractActionInteract, protocol.HandMainHand, false)
 }
+
+func (c *ClientAdapter) GetVehicle() (int32, bool) {
+	if c.client == nil {
+		return 0, false
+	}
+	return c.client.Vehicle()
+}
+
+func (c *ClientAdapter) Attack(entityID int32) error {
+	if c.client == nil {
+		return fmt.Errorf("client not initialized")
+	}
+	return c.client.Attack(entityID)
+}
+
+func (c *ClientAdapter) SetAutoAttack(enabled bool) {
+	if c.client != nil {
+		c.client.SetAutoAttack(enabled)
+	}
+}
+
+func (c *ClientAdapter) AutoAttackEnabled() bool {
+	return c.client != nil && c.client.AutoAttackEnabled()
+}
+
+func (c *ClientAdapter) GetHeldItem() *item.ItemStack {
+	if c.client == nil {
+		return nil
+	}
+	return c.client.Player.GetHeldItem()
+}
+
+func (c *ClientAdapter) GetInventory() map[int8]*item.ItemStack {
+	if c.client == nil {
+		return nil
+	}
+	return c.client.Player.Inventory.GetAll()
+}
+
+func (c *ClientAdapter) Craft(itemName string, count int) (int, error) {
+	if c.client == nil {
+		return 0, fmt.Errorf("client not initialized")
+	}
+	return c.client.Craft(itemName, count)
+}
+
+func (c *ClientAdapter) GetWorldTime() world.Time {
+	if c.client == nil {
+		return world.Time{}
+	}
+	return c.client.World.Time()
+}
+
+func (c *ClientAdapter) GetWeather() world.Weather {
+	if c.client == nil {
+		return world.Weather{}
+	}
+	return c.client.World.Weather()
+}
+
+func (c *ClientAdapter) GetWorldBorder() world.Border {
+	if c.client == nil {
+		return world.Border{}
+	}
+	return c.client.World.Border()
+}
+
+func (c *ClientAdapter) GetTPS() (world.TPS, bool) {
+	if c.client == nil {
+		return world.TPS{}, false
+	}
+	return c.client.TPS()
+}
+
+func (c *ClientAdapter) GetLatency() (time.Duration, bool) {
+	if c.client == nil {
+		return 0, false
+	}
+	return c.client.Latency()
+}
+
+func (c *ClientAdapter) GetSign(pos world.BlockPos) (world.Sign, bool) {
+	if c.client == nil {
+		return world.Sign{}, false
+	}
+	return c.client.World.Sign(pos)
+}
+
+func (c *ClientAdapter) GetSignEditor() (world.BlockPos, bool, bool) {
+	if c.client == nil {
+		return world.BlockPos{}, false, false
+	}
+	e, ok := c.client.SignEditor()
+	return e.Pos, e.Front, ok
+}
+
+func (c *ClientAdapter) UpdateSign(pos world.BlockPos, front bool, lines [4]string) error {
+	if c.client == nil {
+		return fmt.Errorf("client not initialized")
+	}
+	return c.client.UpdateSign(pos, front, lines)
+}
+
+func (c *ClientAdapter) GetDialog() (*dialog.Dialog, bool) {
+	if c.client == nil {
+		return nil, false
+	}
+	return c.client.CurrentDialog()
+}
+
+func (c *ClientAdapter) ClickDialog(button string, inputs map[string]string) error {
+	if c.client == nil {
+		return fmt.Errorf("client not initialized")
+	}
+	return c.client.ClickDialog(button, inputs)
+}
+
+func (c *ClientAdapter) CloseDialog() error {
+	if c.client == nil {
+		return fmt.Errorf("client not initialized")
+	}
+	return c.client.CloseDialog()
+}
+
+func (c *ClientAdapter) GetNearbyMerchants() []int32 {
+	if c.client == nil {
+		return nil
+	}
+	merchants := c.client.NearbyMerchants()
+	ids := make([]int32, 0, len(merchants))
+	for _, e := range merchants {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func (c *ClientAdapter) OpenMerchant(entityID int32) (*player.Merchant, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+	return c.client.OpenMerchant(entityID)
+}
+
+func (c *ClientAdapter) Trade(index, times int) (int, error) {
+	if c.client == nil {
+		return 0, fmt.Errorf("client not initialized")
+	}
+	return c.client.Trade(index, times)
+}
+
+func (c *ClientAdapter) CloseMerchant() error {
+	if c.client == nil {
+		return fmt.Errorf("client not initialized")
+	}
+	m, ok := c.client.Merchant()
+	if !ok {
+		return nil
+	}
+	return c.client.SendContainerClose(m.WindowID)
+}
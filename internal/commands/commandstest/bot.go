@@ -0,0 +1,56 @@
+// Package commandstest 提供指令模块测试用的 BotAdapter 桩实现
+package commandstest
+
+import (
+	"time"
+
+	"gmcc/internal/commands"
+	"gmcc/internal/dialog"
+	"gmcc/internal/item"
+	"gmcc/internal/player"
+	"gmcc/internal/world"
+)
+
+var _ commands.BotAdapter = (*Bot)(nil)
+
+// Bot 所有方法都返回零值的在线机器人。测试中嵌入后只需覆盖关心的方法，
+// BotAdapter 新增方法时只需修改这里
+type Bot struct{}
+
+func (*Bot) GetPlayerID() string                                { return "MockBot" }
+func (*Bot) GetUUID() string                                    { return "mock-uuid" }
+func (*Bot) GetPosition() (x, y, z float64)                     { return 0, 0, 0 }
+func (*Bot) GetRotation() (yaw, pitch float32)                  { return 0, 0 }
+func (*Bot) SendChat(string) error                              { return nil }
+func (*Bot) SendCommand(string) error                           { return nil }
+func (*Bot) SendPrivateMessage(string, string) error            { return nil }
+func (*Bot) SetYawPitch(float32, float32) error                 { return nil }
+func (*Bot) LookAt(float64, float64, float64) error             { return nil }
+func (*Bot) GetNearbyPlayers() []commands.PlayerInfo            { return nil }
+func (*Bot) GetPlayerByName(string) (commands.PlayerInfo, bool) { return commands.PlayerInfo{}, false }
+func (*Bot) DistanceTo(float64, float64, float64) float64       { return 0 }
+func (*Bot) IsOnline() bool                                     { return true }
+func (*Bot) SetHeldSlot(int16) error                            { return nil }
+func (*Bot) InteractEntity(int32) error                         { return nil }
+func (*Bot) GetVehicle() (int32, bool)                          { return 0, false }
+func (*Bot) Attack(int32) error                                 { return nil }
+func (*Bot) SetAutoAttack(bool)                                 {}
+func (*Bot) AutoAttackEnabled() bool                            { return false }
+func (*Bot) GetHeldItem() *item.ItemStack                       { return nil }
+func (*Bot) GetInventory() map[int8]*item.ItemStack             { return nil }
+func (*Bot) Craft(string, int) (int, error)                     { return 0, nil }
+func (*Bot) GetWorldTime() world.Time                           { return world.Time{} }
+func (*Bot) GetWeather() world.Weather                          { return world.Weather{} }
+func (*Bot) GetWorldBorder() world.Border                       { return world.Border{} }
+func (*Bot) GetTPS() (world.TPS, bool)                          { return world.TPS{}, false }
+func (*Bot) GetLatency() (time.Duration, bool)                  { return 0, false }
+func (*Bot) GetSign(world.BlockPos) (world.Sign, bool)          { return world.Sign{}, false }
+func (*Bot) GetSignEditor() (world.BlockPos, bool, bool)        { return world.BlockPos{}, false, false }
+func (*Bot) UpdateSign(world.BlockPos, bool, [4]string) error   { return nil }
+func (*Bot) GetDialog() (*dialog.Dialog, bool)                  { return nil, false }
+func (*Bot) ClickDialog(string, map[string]string) error        { return nil }
+func (*Bot) CloseDialog() error                                 { return nil }
+func (*Bot) GetNearbyMerchants() []int32                        { return nil }
+func (*Bot) OpenMerchant(int32) (*player.Merchant, error)       { return nil, nil }
+func (*Bot) Trade(int, int) (int, error)                        { return 0, nil }
+func (*Bot) CloseMerchant() error                               { return nil }
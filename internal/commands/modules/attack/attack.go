@@ -0,0 +1,54 @@
+package attack
+
+import (
+	"strings"
+
+	"gmcc/internal/commands"
+)
+
+// AttackCommand 开关自动攻击 (初始状态来自配置 combat.auto_attack)
+type AttackCommand struct {
+	bot commands.BotAdapter
+}
+
+func NewAttackCommand() *AttackCommand {
+	return &AttackCommand{}
+}
+
+func (a *AttackCommand) Name() string        { return "attack" }
+func (a *AttackCommand) Description() string { return "开关自动攻击附近的敌对生物" }
+func (a *AttackCommand) Usage() string       { return "attack [on|off]" }
+
+func (a *AttackCommand) Init(bot commands.BotAdapter, _ *commands.ModuleConfig) error {
+	a.bot = bot
+	return nil
+}
+
+func (a *AttackCommand) Execute(ctx *commands.ChatContext) *commands.CommandResult {
+	if len(ctx.Args) == 0 {
+		return &commands.CommandResult{Success: true, Message: "自动攻击: " + statusText(a.bot.AutoAttackEnabled())}
+	}
+
+	switch strings.ToLower(ctx.Args[0]) {
+	case "on":
+		a.bot.SetAutoAttack(true)
+	case "off":
+		a.bot.SetAutoAttack(false)
+	default:
+		return &commands.CommandResult{Success: false, Message: "用法: " + a.Usage()}
+	}
+	return &commands.CommandResult{Success: true, Message: "自动攻击已" + statusText(a.bot.AutoAttackEnabled())}
+}
+
+func statusText(enabled bool) string {
+	if enabled {
+		return "开启"
+	}
+	return "关闭"
+}
+
+func (a *AttackCommand) Tick(_ *commands.ChatContext) *commands.CommandResult { return nil }
+func (a *AttackCommand) Cleanup()                                             {}
+func (a *AttackCommand) Stop()                                                {}
+func (a *AttackCommand) State() commands.StateType                            { return commands.StateIdle }
+func (a *AttackCommand) Target() string                                       { return "" }
@@ -0,0 +1,35 @@
+package attack
+
+import (
+	"testing"
+
+	"gmcc/internal/commands"
+	"gmcc/internal/commands/commandstest"
+)
+
+func TestAttackCommand_Execute(t *testing.T) {
+	bot := &mockBot{}
+	cmd := NewAttackCommand()
+	cmd.Init(bot, nil)
+
+	if result := cmd.Execute(&commands.ChatContext{Bot: bot, Args: []string{"ON"}}); !result.Success || !bot.auto {
+		t.Errorf("attack on = %+v, auto=%v", result, bot.auto)
+	}
+	if result := cmd.Execute(&commands.ChatContext{Bot: bot}); result.Message != "自动攻击: 开启" {
+		t.Errorf("attack = %q", result.Message)
+	}
+	if result := cmd.Execute(&commands.ChatContext{Bot: bot, Args: []string{"off"}}); !result.Success || bot.auto {
+		t.Errorf("attack off = %+v, auto=%v", result, bot.auto)
+	}
+	if result := cmd.Execute(&commands.ChatContext{Bot: bot, Args: []string{"maybe"}}); result.Success {
+		t.Error("无效参数应失败")
+	}
+}
+
+type mockBot struct {
+	commandstest.Bot
+	auto bool
+}
+
+func (m *mockBot) SetAutoAttack(enabled bool) { m.auto = enabled }
+func (m *mockBot) AutoAttackEnabled() bool    { return m.auto }
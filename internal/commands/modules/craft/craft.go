@@ -0,0 +1,130 @@
+package craft
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"gmcc/internal/commands"
+)
+
+// CraftCommand 通过配方书合成物品。合成需要等待服务端同步，
+// 因此在后台执行，完成后由 Tick 私聊结果。
+type CraftCommand struct {
+	mu  sync.Mutex
+	bot commands.BotAdapter
+
+	state  commands.StateType
+	target string
+	done   chan *commands.CommandResult
+}
+
+func NewCraftCommand() *CraftCommand {
+	return &CraftCommand{state: commands.StateIdle}
+}
+
+func (c *CraftCommand) Name() string { return "craft" }
+func (c *CraftCommand) Description() string {
+	return "使用配方书合成物品 (背包或已打开的工作台)"
+}
+func (c *CraftCommand) Usage() string { return "craft <物品> [数量]" }
+
+func (c *CraftCommand) Init(bot commands.BotAdapter, _ *commands.ModuleConfig) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bot = bot
+	return nil
+}
+
+func (c *CraftCommand) Execute(ctx *commands.ChatContext) *commands.CommandResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state != commands.StateIdle {
+		return &commands.CommandResult{
+			Success:   false,
+			Message:   "正在合成，请稍候",
+			NextState: c.state,
+		}
+	}
+	if len(ctx.Args) == 0 {
+		return &commands.CommandResult{Success: false, Message: "用法: " + c.Usage()}
+	}
+
+	name := ctx.Args[0]
+	count := 0
+	if len(ctx.Args) > 1 {
+		n, err := strconv.Atoi(ctx.Args[1])
+		if err != nil || n <= 0 {
+			return &commands.CommandResult{Success: false, Message: fmt.Sprintf("数量无效: %s", ctx.Args[1])}
+		}
+		count = n
+	}
+
+	c.state = commands.StateExecuting
+	c.target = ctx.Sender
+	done := make(chan *commands.CommandResult, 1)
+	c.done = done
+	bot := c.bot
+	go func() {
+		n, err := bot.Craft(name, count)
+		if err != nil {
+			done <- &commands.CommandResult{
+				Success: false,
+				Message: fmt.Sprintf("合成 %s 失败 (已得到 %d 个): %v", name, n, err),
+				Error:   err,
+			}
+			return
+		}
+		done <- &commands.CommandResult{Success: true, Message: fmt.Sprintf("已合成 %d 个 %s", n, name)}
+	}()
+
+	msg := fmt.Sprintf("开始合成 %s", name)
+	if count > 0 {
+		msg = fmt.Sprintf("开始合成 %d 个 %s", count, name)
+	}
+	return &commands.CommandResult{
+		Success:   true,
+		Message:   msg,
+		NextState: commands.StateExecuting,
+	}
+}
+
+func (c *CraftCommand) Tick(_ *commands.ChatContext) *commands.CommandResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state != commands.StateExecuting {
+		return nil
+	}
+	select {
+	case result := <-c.done:
+		c.state = commands.StateIdle
+		c.done = nil
+		return result
+	default:
+		return nil
+	}
+}
+
+func (c *CraftCommand) Cleanup() {}
+
+// Stop 不会中断进行中的合成，只是不再汇报结果
+func (c *CraftCommand) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state = commands.StateIdle
+	c.done = nil
+}
+
+func (c *CraftCommand) State() commands.StateType {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
+
+func (c *CraftCommand) Target() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.target
+}
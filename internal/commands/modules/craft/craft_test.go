@@ -0,0 +1,82 @@
+package craft
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gmcc/internal/commands"
+	"gmcc/internal/commands/commandstest"
+)
+
+func waitResult(t *testing.T, cmd *CraftCommand) *commands.CommandResult {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if result := cmd.Tick(nil); result != nil {
+			return result
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("等待合成结果超时")
+	return nil
+}
+
+func TestCraftCommand_Execute(t *testing.T) {
+	bot := &mockBot{crafted: 9}
+	cmd := NewCraftCommand()
+	cmd.Init(bot, nil)
+
+	result := cmd.Execute(&commands.ChatContext{Bot: bot, Sender: "Steve", Args: []string{"iron_block", "9"}})
+	if !result.Success || result.NextState != commands.StateExecuting {
+		t.Fatalf("Execute() = %+v", result)
+	}
+	if cmd.Target() != "Steve" {
+		t.Errorf("Target() = %q, want Steve", cmd.Target())
+	}
+
+	done := waitResult(t, cmd)
+	if !done.Success {
+		t.Errorf("合成结果失败: %s", done.Message)
+	}
+	if bot.name != "iron_block" || bot.count != 9 {
+		t.Errorf("Craft(%q, %d), want (iron_block, 9)", bot.name, bot.count)
+	}
+	if cmd.State() != commands.StateIdle {
+		t.Errorf("State() = %v, want idle", cmd.State())
+	}
+}
+
+func TestCraftCommand_Errors(t *testing.T) {
+	bot := &mockBot{err: errors.New("材料不足")}
+	cmd := NewCraftCommand()
+	cmd.Init(bot, nil)
+
+	if result := cmd.Execute(&commands.ChatContext{Bot: bot}); result.Success {
+		t.Error("缺少参数应失败")
+	}
+	if result := cmd.Execute(&commands.ChatContext{Bot: bot, Args: []string{"iron_block", "x"}}); result.Success {
+		t.Error("数量无效应失败")
+	}
+
+	cmd.Execute(&commands.ChatContext{Bot: bot, Args: []string{"iron_block"}})
+	if done := waitResult(t, cmd); done.Success || done.Error == nil {
+		t.Errorf("合成失败应返回错误: %+v", done)
+	}
+	if bot.count != 0 {
+		t.Errorf("未指定数量时 count = %d, want 0", bot.count)
+	}
+}
+
+type mockBot struct {
+	commandstest.Bot
+	crafted int
+	err     error
+	name    string
+	count   int
+}
+
+func (m *mockBot) Craft(itemName string, count int) (int, error) {
+	m.name, m.count = itemName, count
+	return m.crafted, m.err
+}
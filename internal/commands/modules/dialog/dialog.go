@@ -0,0 +1,60 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	"gmcc/internal/commands"
+	mcdialog "gmcc/internal/dialog"
+)
+
+// DialogCommand 查看并回应服务端显示的对话框
+type DialogCommand struct {
+	bot commands.BotAdapter
+}
+
+func NewDialogCommand() *DialogCommand {
+	return &DialogCommand{}
+}
+
+func (d *DialogCommand) Name() string        { return "dialog" }
+func (d *DialogCommand) Description() string { return "查看、点击或关闭服务端对话框" }
+func (d *DialogCommand) Usage() string       { return "dialog [close | <按钮> [key=value ...]]" }
+
+func (d *DialogCommand) Init(bot commands.BotAdapter, _ *commands.ModuleConfig) error {
+	d.bot = bot
+	return nil
+}
+
+func (d *DialogCommand) Execute(ctx *commands.ChatContext) *commands.CommandResult {
+	current, ok := d.bot.GetDialog()
+	if !ok {
+		return &commands.CommandResult{Success: false, Message: "当前没有对话框"}
+	}
+	if len(ctx.Args) == 0 {
+		lines := current.Lines()
+		for i := range lines {
+			lines[i] = strings.TrimSpace(lines[i])
+		}
+		return &commands.CommandResult{Success: true, Message: strings.Join(lines, " | ")}
+	}
+
+	if len(ctx.Args) == 1 && ctx.Args[0] == "close" {
+		if err := d.bot.CloseDialog(); err != nil {
+			return &commands.CommandResult{Success: false, Message: fmt.Sprintf("关闭对话框失败: %v", err), Error: err}
+		}
+		return &commands.CommandResult{Success: true, Message: fmt.Sprintf("已关闭对话框 %s", current.Title.Plain)}
+	}
+
+	button, inputs := mcdialog.ParseArgs(strings.Join(ctx.Args, " "))
+	if err := d.bot.ClickDialog(button, inputs); err != nil {
+		return &commands.CommandResult{Success: false, Message: fmt.Sprintf("点击按钮失败: %v", err), Error: err}
+	}
+	return &commands.CommandResult{Success: true, Message: fmt.Sprintf("已点击 %s 的按钮 %s", current.Title.Plain, button)}
+}
+
+func (d *DialogCommand) Tick(_ *commands.ChatContext) *commands.CommandResult { return nil }
+func (d *DialogCommand) Cleanup()                                             {}
+func (d *DialogCommand) Stop()                                                {}
+func (d *DialogCommand) State() commands.StateType                            { return commands.StateIdle }
+func (d *DialogCommand) Target() string                                       { return "" }
@@ -0,0 +1,58 @@
+package dialog
+
+import (
+	"strings"
+	"testing"
+
+	"gmcc/internal/commands"
+	"gmcc/internal/commands/commandstest"
+	mcdialog "gmcc/internal/dialog"
+)
+
+func TestDialogCommand_Execute(t *testing.T) {
+	bot := &mockBot{}
+	cmd := NewDialogCommand()
+	cmd.Init(bot, nil)
+
+	if result := cmd.Execute(&commands.ChatContext{Bot: bot}); result.Success {
+		t.Errorf("没有对话框时 Execute() = %+v", result)
+	}
+
+	bot.dialog = &mcdialog.Dialog{
+		Type:               mcdialog.TypeConfirmation,
+		Title:              mcdialog.Text{Plain: "服务器规则"},
+		Buttons:            []mcdialog.Button{{Label: mcdialog.Text{Plain: "同意"}}, {Label: mcdialog.Text{Plain: "拒绝"}}},
+		CanCloseWithEscape: true,
+	}
+	result := cmd.Execute(&commands.ChatContext{Bot: bot})
+	if !result.Success || !strings.Contains(result.Message, "<1> 同意") {
+		t.Errorf("Execute() = %+v", result)
+	}
+
+	result = cmd.Execute(&commands.ChatContext{Bot: bot, Args: []string{"同意", `name="Steve Alex"`, "age=3"}})
+	if !result.Success || bot.button != "同意" || bot.inputs["name"] != "Steve Alex" || bot.inputs["age"] != "3" {
+		t.Errorf("Execute() = %+v, button = %q, inputs = %v", result, bot.button, bot.inputs)
+	}
+
+	if result := cmd.Execute(&commands.ChatContext{Bot: bot, Args: []string{"close"}}); !result.Success || !bot.closed {
+		t.Errorf("Execute(close) = %+v", result)
+	}
+}
+
+type mockBot struct {
+	commandstest.Bot
+	dialog *mcdialog.Dialog
+	button string
+	inputs map[string]string
+	closed bool
+}
+
+func (m *mockBot) GetDialog() (*mcdialog.Dialog, bool) { return m.dialog, m.dialog != nil }
+func (m *mockBot) ClickDialog(button string, inputs map[string]string) error {
+	m.button, m.inputs = button, inputs
+	return nil
+}
+func (m *mockBot) CloseDialog() error {
+	m.closed = true
+	return nil
+}
@@ -2,8 +2,12 @@ package modules
 
 import (
 	"gmcc/internal/commands"
+	"gmcc/internal/commands/modules/attack"
+	"gmcc/internal/commands/modules/craft"
+	"gmcc/internal/commands/modules/dialog"
 	"gmcc/internal/commands/modules/pos"
 	"gmcc/internal/commands/modules/ride"
+	"gmcc/internal/commands/modules/trade"
 )
 
 type ModuleConfig struct {
@@ -18,3 +22,19 @@ func NewRideCommand(cfg *ride.Config) *ride.RideCommand {
 func NewPosCommand() *pos.PosCommand {
 	return pos.NewPosCommand()
 }
+
+func NewCraftCommand() *craft.CraftCommand {
+	return craft.NewCraftCommand()
+}
+
+func NewAttackCommand() *attack.AttackCommand {
+	return attack.NewAttackCommand()
+}
+
+func NewDialogCommand() *dialog.DialogCommand {
+	return dialog.NewDialogCommand()
+}
+
+func NewTradeCommand() *trade.TradeCommand {
+	return trade.NewTradeCommand()
+}
@@ -17,6 +17,10 @@ const (
 	// 5 秒冷却防止命令滥用和刷屏
 	DefaultCooldown = 5 * time.Second
 
+	// DefaultMountTimeout 默认等待服务端确认骑乘的时间
+	// 服务端会在骑乘成功后立即发送乘客列表，3 秒足以覆盖网络延迟
+	DefaultMountTimeout = 3 * time.Second
+
 	// DefaultLookSmoothing 默认视角平滑系数
 	// 范围 0-1，值越小越平滑，0.3 提供自然的视角过渡
 	DefaultLookSmoothing = 0.3
@@ -35,6 +39,10 @@ type Config struct {
 	// 骑乘成功后的冷却时间，防止命令滥用
 	Cooldown time.Duration
 
+	// MountTimeout 骑乘确认超时
+	// 发送交互后在此时间内未成为目标的乘客则视为失败
+	MountTimeout time.Duration
+
 	// LookSmoothing 视角平滑系数 (0-1)
 	// 控制视角追踪的平滑程度，越大越快
 	LookSmoothing float32
@@ -45,6 +53,7 @@ func DefaultConfig() *Config {
 		RangeLimit:    DefaultRangeLimit,
 		Timeout:       DefaultTimeout,
 		Cooldown:      DefaultCooldown,
+		MountTimeout:  DefaultMountTimeout,
 		LookSmoothing: DefaultLookSmoothing,
 	}
 }
@@ -17,6 +17,10 @@ type RideCommand struct {
 	target    string
 	startTime time.Time
 
+	// 已发送骑乘请求，等待乘客数据确认
+	mounting bool
+	targetID int32
+
 	currentYaw   float32
 	currentPitch float32
 }
@@ -113,6 +117,7 @@ func (r *RideCommand) Tick(ctx *commands.ChatContext) *commands.CommandResult {
 	case commands.StateFailed:
 		r.state = commands.StateIdle
 		r.target = ""
+		r.mounting = false
 		return nil
 	}
 
@@ -147,6 +152,10 @@ func (r *RideCommand) tickPreparing(ctx *commands.ChatContext) *commands.Command
 }
 
 func (r *RideCommand) tickExecuting(ctx *commands.ChatContext) *commands.CommandResult {
+	if r.mounting {
+		return r.tickMounting()
+	}
+
 	player, ok := r.bot.GetPlayerByName(r.target)
 	if !ok {
 		r.state = commands.StateFailed
@@ -176,6 +185,35 @@ func (r *RideCommand) tickExecuting(ctx *commands.ChatContext) *commands.Command
 	return nil
 }
 
+// tickMounting 通过载具的乘客数据确认骑乘是否成功
+func (r *RideCommand) tickMounting() *commands.CommandResult {
+	if vehicle, ok := r.bot.GetVehicle(); ok && vehicle == r.targetID {
+		r.mounting = false
+		r.state = commands.StateCooldown
+		r.startTime = time.Now()
+		return &commands.CommandResult{
+			Success:   true,
+			Message:   fmt.Sprintf("已骑乘 %s", r.target),
+			NextState: commands.StateCooldown,
+		}
+	}
+
+	timeout := r.config.MountTimeout
+	if timeout <= 0 {
+		timeout = DefaultMountTimeout
+	}
+	if time.Since(r.startTime) > timeout {
+		r.mounting = false
+		r.state = commands.StateFailed
+		return &commands.CommandResult{
+			Success:   false,
+			Message:   fmt.Sprintf("骑乘 %s 失败，服务端未确认", r.target),
+			NextState: commands.StateFailed,
+		}
+	}
+	return nil
+}
+
 func (r *RideCommand) tickCooldown() *commands.CommandResult {
 	if time.Since(r.startTime) > r.config.Cooldown {
 		r.state = commands.StateIdle
@@ -216,13 +254,15 @@ func (r *RideCommand) executeRide(ctx *commands.ChatContext) *commands.CommandRe
 		}
 	}
 
-	r.state = commands.StateCooldown
+	r.state = commands.StateExecuting
 	r.startTime = time.Now()
+	r.mounting = true
+	r.targetID = player.EntityID
 
 	return &commands.CommandResult{
 		Success:   true,
-		Message:   fmt.Sprintf("已向 %s 发送骑乘请求", r.target),
-		NextState: commands.StateCooldown,
+		Message:   fmt.Sprintf("已向 %s 发送骑乘请求，等待确认...", r.target),
+		NextState: commands.StateExecuting,
 	}
 }
 
@@ -250,6 +290,7 @@ func (r *RideCommand) Cleanup() {
 	defer r.mu.Unlock()
 	r.state = commands.StateIdle
 	r.target = ""
+	r.mounting = false
 }
 
 func (r *RideCommand) Stop() {
@@ -257,6 +298,7 @@ func (r *RideCommand) Stop() {
 	defer r.mu.Unlock()
 	r.state = commands.StateIdle
 	r.target = ""
+	r.mounting = false
 	r.startTime = time.Time{}
 }
 
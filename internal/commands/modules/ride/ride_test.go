@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gmcc/internal/commands"
+	"gmcc/internal/commands/commandstest"
 )
 
 func TestRideCommand_Name(t *testing.T) {
@@ -118,11 +119,50 @@ func TestRideCommand_Execute_TargetInRange(t *testing.T) {
 	if !result.Success {
 		t.Errorf("Execute() failed: %s", result.Message)
 	}
+	if cmd.State() != commands.StateExecuting {
+		t.Errorf("State() = %v, want %v", cmd.State(), commands.StateExecuting)
+	}
+	if bot.interacted != 100 {
+		t.Errorf("InteractEntity(%d), want 100", bot.interacted)
+	}
+
+	// 未收到乘客数据前保持等待
+	if result := cmd.Tick(ctx); result != nil {
+		t.Errorf("Tick() before mount = %+v, want nil", result)
+	}
+
+	bot.vehicle = 100
+	result = cmd.Tick(ctx)
+	if result == nil || !result.Success {
+		t.Fatalf("Tick() after mount = %+v, want success", result)
+	}
 	if cmd.State() != commands.StateCooldown {
 		t.Errorf("State() = %v, want %v", cmd.State(), commands.StateCooldown)
 	}
 }
 
+func TestRideCommand_Tick_MountTimeout(t *testing.T) {
+	cmd := &RideCommand{
+		config:    &Config{MountTimeout: time.Second},
+		state:     commands.StateExecuting,
+		target:    "NearbyPlayer",
+		mounting:  true,
+		targetID:  100,
+		startTime: time.Now().Add(-time.Minute),
+	}
+	// 骑乘在其他载具上不算成功
+	bot := &mockBot{online: true, vehicle: 42}
+	cmd.Init(bot, nil)
+
+	result := cmd.Tick(&commands.ChatContext{Bot: bot})
+	if result == nil || result.Success {
+		t.Fatalf("Tick() = %+v, want failure", result)
+	}
+	if cmd.State() != commands.StateFailed {
+		t.Errorf("State() = %v, want %v", cmd.State(), commands.StateFailed)
+	}
+}
+
 func TestRideCommand_Execute_TargetOutOfRange(t *testing.T) {
 	cmd := &RideCommand{
 		config: &Config{RangeLimit: 3.0},
@@ -299,6 +339,7 @@ func abs32(x float32) float32 {
 }
 
 type mockBot struct {
+	commandstest.Bot
 	online      bool
 	position    [3]float64
 	rotation    [2]float32
@@ -306,6 +347,8 @@ type mockBot struct {
 	commands    []string
 	privateMsgs []struct{ target, msg string }
 	players     []mockPlayer
+	interacted  int32
+	vehicle     int32
 }
 
 type mockPlayer struct {
@@ -314,8 +357,6 @@ type mockPlayer struct {
 	position [3]float64
 }
 
-func (m *mockBot) GetPlayerID() string               { return "MockBot" }
-func (m *mockBot) GetUUID() string                   { return "mock-uuid" }
 func (m *mockBot) GetPosition() (x, y, z float64)    { return m.position[0], m.position[1], m.position[2] }
 func (m *mockBot) GetRotation() (yaw, pitch float32) { return m.rotation[0], m.rotation[1] }
 func (m *mockBot) SendChat(msg string) error         { m.messages = append(m.messages, msg); return nil }
@@ -328,9 +369,7 @@ func (m *mockBot) SetYawPitch(yaw, pitch float32) error {
 	m.rotation = [2]float32{yaw, pitch}
 	return nil
 }
-func (m *mockBot) LookAt(x, y, z float64) error            { return nil }
-func (m *mockBot) IsOnline() bool                          { return m.online }
-func (m *mockBot) GetNearbyPlayers() []commands.PlayerInfo { return nil }
+func (m *mockBot) IsOnline() bool { return m.online }
 func (m *mockBot) DistanceTo(x, y, z float64) float64 {
 	dx := m.position[0] - x
 	dy := m.position[1] - y
@@ -349,5 +388,5 @@ func (m *mockBot) GetPlayerByName(name string) (commands.PlayerInfo, bool) {
 	}
 	return commands.PlayerInfo{}, false
 }
-func (m *mockBot) SetHeldSlot(slot int16) error        { return nil }
-func (m *mockBot) InteractEntity(entityID int32) error { return nil }
+func (m *mockBot) InteractEntity(entityID int32) error { m.interacted = entityID; return nil }
+func (m *mockBot) GetVehicle() (int32, bool)           { return m.vehicle, m.vehicle != 0 }
@@ -0,0 +1,212 @@
+package trade
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gmcc/internal/commands"
+	"gmcc/internal/player"
+)
+
+// defaultItem 不指定物品时收购绿宝石
+const defaultItem = "emerald"
+
+// TradeCommand 与附近的村民交易。交易需要逐个打开商人并等待服务端同步，
+// 因此在后台执行，完成后由 Tick 私聊结果。
+type TradeCommand struct {
+	mu  sync.Mutex
+	bot commands.BotAdapter
+
+	state  commands.StateType
+	target string
+	done   chan *commands.CommandResult
+}
+
+func NewTradeCommand() *TradeCommand {
+	return &TradeCommand{state: commands.StateIdle}
+}
+
+func (t *TradeCommand) Name() string { return "trade" }
+func (t *TradeCommand) Description() string {
+	return "与附近的村民交易换取物品 (默认绿宝石)"
+}
+func (t *TradeCommand) Usage() string { return "trade list | trade [物品] [数量]" }
+
+func (t *TradeCommand) Init(bot commands.BotAdapter, _ *commands.ModuleConfig) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bot = bot
+	return nil
+}
+
+func (t *TradeCommand) Execute(ctx *commands.ChatContext) *commands.CommandResult {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != commands.StateIdle {
+		return &commands.CommandResult{
+			Success:   false,
+			Message:   "正在交易，请稍候",
+			NextState: t.state,
+		}
+	}
+
+	merchants := t.bot.GetNearbyMerchants()
+	if len(merchants) == 0 {
+		return &commands.CommandResult{Success: false, Message: "附近没有可交易的村民"}
+	}
+	if len(ctx.Args) > 0 && strings.EqualFold(ctx.Args[0], "list") {
+		id := merchants[0]
+		t.start(ctx.Sender, func(bot commands.BotAdapter) *commands.CommandResult { return list(bot, id) })
+		return &commands.CommandResult{Success: true, Message: "正在查看最近商人的交易", NextState: commands.StateExecuting}
+	}
+
+	name := defaultItem
+	args := ctx.Args
+	if len(args) > 0 {
+		if _, err := strconv.Atoi(args[0]); err != nil {
+			name, args = args[0], args[1:]
+		}
+	}
+	name = strings.TrimPrefix(strings.ToLower(name), "minecraft:")
+	want := 0
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			return &commands.CommandResult{Success: false, Message: fmt.Sprintf("数量无效: %s", args[0])}
+		}
+		want = n
+	}
+
+	t.start(ctx.Sender, func(bot commands.BotAdapter) *commands.CommandResult {
+		return buy(bot, merchants, name, want)
+	})
+	return &commands.CommandResult{
+		Success:   true,
+		Message:   fmt.Sprintf("开始与 %d 个商人交易 %s", len(merchants), name),
+		NextState: commands.StateExecuting,
+	}
+}
+
+// start 在后台执行 run，结果由 Tick 取回。调用方须持有 t.mu
+func (t *TradeCommand) start(sender string, run func(commands.BotAdapter) *commands.CommandResult) {
+	t.state = commands.StateExecuting
+	t.target = sender
+	done := make(chan *commands.CommandResult, 1)
+	t.done = done
+	bot := t.bot
+	go func() {
+		done <- run(bot)
+	}()
+}
+
+// list 打开商人并列出交易
+func list(bot commands.BotAdapter, entityID int32) *commands.CommandResult {
+	m, err := bot.OpenMerchant(entityID)
+	if err != nil {
+		return &commands.CommandResult{Success: false, Message: fmt.Sprintf("打开交易界面失败: %v", err), Error: err}
+	}
+	defer bot.CloseMerchant()
+
+	if len(m.Offers) == 0 {
+		return &commands.CommandResult{Success: true, Message: "该商人没有交易"}
+	}
+	parts := make([]string, 0, len(m.Offers))
+	for i := range m.Offers {
+		parts = append(parts, fmt.Sprintf("%d. %s", i+1, &m.Offers[i]))
+	}
+	return &commands.CommandResult{Success: true, Message: strings.Join(parts, " | ")}
+}
+
+// buy 依次打开商人，执行结果为 name 的交易，直到得到 want 个 (want<=0 表示尽可能多)
+func buy(bot commands.BotAdapter, merchants []int32, name string, want int) *commands.CommandResult {
+	got, trades := 0, 0
+	var lastErr error
+	for _, id := range merchants {
+		if want > 0 && got >= want {
+			break
+		}
+		m, err := bot.OpenMerchant(id)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		for i := range m.Offers {
+			if want > 0 && got >= want {
+				break
+			}
+			o := &m.Offers[i]
+			if !matches(o, name) || o.OutOfStock() {
+				continue
+			}
+			per := int(max(o.Result.Count, 1))
+			times := 0
+			if want > 0 {
+				times = (want - got + per - 1) / per
+			}
+			n, err := bot.Trade(i, times)
+			if err != nil {
+				lastErr = err
+			}
+			got += n * per
+			trades += n
+		}
+		if err := bot.CloseMerchant(); err != nil {
+			lastErr = err
+		}
+	}
+
+	if trades == 0 {
+		msg := fmt.Sprintf("没有完成任何 %s 交易", name)
+		if lastErr != nil {
+			msg += fmt.Sprintf(": %v", lastErr)
+		}
+		return &commands.CommandResult{Success: false, Message: msg, Error: lastErr}
+	}
+	return &commands.CommandResult{Success: true, Message: fmt.Sprintf("交易 %d 次，得到 %d 个 %s", trades, got, name)}
+}
+
+func matches(o *player.MerchantOffer, name string) bool {
+	return !o.Result.IsEmpty() && o.Result.Name() == "minecraft:"+name
+}
+
+func (t *TradeCommand) Tick(_ *commands.ChatContext) *commands.CommandResult {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != commands.StateExecuting {
+		return nil
+	}
+	select {
+	case result := <-t.done:
+		t.state = commands.StateIdle
+		t.done = nil
+		return result
+	default:
+		return nil
+	}
+}
+
+func (t *TradeCommand) Cleanup() {}
+
+// Stop 不会中断进行中的交易，只是不再汇报结果
+func (t *TradeCommand) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.state = commands.StateIdle
+	t.done = nil
+}
+
+func (t *TradeCommand) State() commands.StateType {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state
+}
+
+func (t *TradeCommand) Target() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.target
+}
@@ -0,0 +1,151 @@
+package trade
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gmcc/internal/commands"
+	"gmcc/internal/commands/commandstest"
+	"gmcc/internal/item"
+	"gmcc/internal/player"
+	"gmcc/internal/registry"
+)
+
+func stack(t *testing.T, name string, count int32) *item.ItemStack {
+	t.Helper()
+	id := registry.GetItemRegistry().NameToID(name)
+	if id < 0 {
+		t.Fatalf("未知物品: %s", name)
+	}
+	return &item.ItemStack{ID: id, Count: count}
+}
+
+func waitResult(t *testing.T, cmd *TradeCommand) *commands.CommandResult {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if result := cmd.Tick(nil); result != nil {
+			return result
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("等待交易结果超时")
+	return nil
+}
+
+func newBot(t *testing.T) *mockBot {
+	return &mockBot{merchants: map[int32]*player.Merchant{
+		// 农民: 小麦换绿宝石，剩余 2 次
+		10: {WindowID: 1, Offers: []player.MerchantOffer{
+			{CostA: stack(t, "wheat", 20), Result: stack(t, "emerald", 1), Uses: 14, MaxUses: 16},
+			{CostA: stack(t, "emerald", 1), Result: stack(t, "bread", 6), MaxUses: 16},
+		}},
+		// 图书管理员: 纸换绿宝石，已缺货的交易会被跳过
+		11: {WindowID: 2, Offers: []player.MerchantOffer{
+			{CostA: stack(t, "paper", 24), Result: stack(t, "emerald", 1), Uses: 16, MaxUses: 16},
+			{CostA: stack(t, "book", 4), Result: stack(t, "emerald", 1), MaxUses: 12},
+		}},
+	}, order: []int32{10, 11}}
+}
+
+func TestTradeCommand_Buy(t *testing.T) {
+	bot := newBot(t)
+	cmd := NewTradeCommand()
+	cmd.Init(bot, nil)
+
+	result := cmd.Execute(&commands.ChatContext{Bot: bot, Sender: "Steve", Args: []string{"5"}})
+	if !result.Success || result.NextState != commands.StateExecuting || cmd.Target() != "Steve" {
+		t.Fatalf("Execute() = %+v", result)
+	}
+	done := waitResult(t, cmd)
+	if !done.Success || !strings.Contains(done.Message, "得到 5 个 emerald") {
+		t.Errorf("结果 = %+v", done)
+	}
+	want := []string{"10:0x2", "11:1x3"}
+	if strings.Join(bot.trades, ",") != strings.Join(want, ",") {
+		t.Errorf("trades = %v, want %v", bot.trades, want)
+	}
+	if bot.opened != nil {
+		t.Error("交易后应关闭交易界面")
+	}
+	if cmd.State() != commands.StateIdle {
+		t.Errorf("State() = %v, want idle", cmd.State())
+	}
+}
+
+func TestTradeCommand_List(t *testing.T) {
+	bot := newBot(t)
+	cmd := NewTradeCommand()
+	cmd.Init(bot, nil)
+
+	// 打开商人需要等待服务端，与交易一样在后台执行
+	started := cmd.Execute(&commands.ChatContext{Bot: bot, Args: []string{"list"}})
+	if !started.Success || started.NextState != commands.StateExecuting {
+		t.Fatalf("Execute(list) = %+v", started)
+	}
+	result := waitResult(t, cmd)
+	if !result.Success || !strings.HasPrefix(result.Message, "1. 20 ") || !strings.Contains(result.Message, "| 2. 1 ") {
+		t.Errorf("Execute(list) = %+v", result)
+	}
+	if bot.opened != nil {
+		t.Error("列出交易后应关闭交易界面")
+	}
+}
+
+func TestTradeCommand_Errors(t *testing.T) {
+	bot := newBot(t)
+	cmd := NewTradeCommand()
+	cmd.Init(bot, nil)
+
+	if result := cmd.Execute(&commands.ChatContext{Bot: bot, Args: []string{"emerald", "0"}}); result.Success {
+		t.Error("无效数量应失败")
+	}
+
+	bot.err = errors.New("材料不足")
+	cmd.Execute(&commands.ChatContext{Bot: bot})
+	if done := waitResult(t, cmd); done.Success || !strings.Contains(done.Message, "材料不足") {
+		t.Errorf("结果 = %+v", done)
+	}
+
+	empty := &mockBot{}
+	cmd.Init(empty, nil)
+	if result := cmd.Execute(&commands.ChatContext{Bot: empty}); result.Success {
+		t.Error("附近没有村民时应失败")
+	}
+}
+
+type mockBot struct {
+	commandstest.Bot
+	merchants map[int32]*player.Merchant
+	order     []int32
+	opened    *player.Merchant
+	openedID  int32
+	trades    []string
+	err       error
+}
+
+func (m *mockBot) GetNearbyMerchants() []int32 { return m.order }
+func (m *mockBot) OpenMerchant(id int32) (*player.Merchant, error) {
+	m.opened, m.openedID = m.merchants[id], id
+	return m.opened, nil
+}
+func (m *mockBot) Trade(index, times int) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	o := &m.opened.Offers[index]
+	n := int(o.Remaining())
+	if times > 0 {
+		n = min(n, times)
+	}
+	o.Uses += int32(n)
+	m.trades = append(m.trades, fmt.Sprintf("%d:%dx%d", m.openedID, index, n))
+	return n, nil
+}
+func (m *mockBot) CloseMerchant() error {
+	m.opened = nil
+	return nil
+}
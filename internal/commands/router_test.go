@@ -6,7 +6,9 @@ import (
 	"testing"
 )
 
+// mockBotAdapter 只实现路由会用到的方法，其余方法由嵌入的 nil 接口兜底 (调用即 panic)
 type mockBotAdapter struct {
+	BotAdapter
 	online      bool
 	playerName  string
 	messages    []string
@@ -16,11 +18,8 @@ type mockBotAdapter struct {
 	}
 }
 
-func (m *mockBotAdapter) GetPlayerID() string               { return m.playerName }
-func (m *mockBotAdapter) GetUUID() string                   { return "test-uuid" }
-func (m *mockBotAdapter) GetPosition() (x, y, z float64)    { return 0, 64, 0 }
-func (m *mockBotAdapter) GetRotation() (yaw, pitch float32) { return 0, 0 }
-func (m *mockBotAdapter) SendChat(msg string) error         { m.messages = append(m.messages, msg); return nil }
+func (m *mockBotAdapter) GetPlayerID() string       { return m.playerName }
+func (m *mockBotAdapter) SendChat(msg string) error { m.messages = append(m.messages, msg); return nil }
 func (m *mockBotAdapter) SendCommand(cmd string) error {
 	m.commands = append(m.commands, cmd)
 	return nil
@@ -29,14 +28,7 @@ func (m *mockBotAdapter) SendPrivateMessage(target, msg string) error {
 	m.privateMsgs = append(m.privateMsgs, struct{ target, msg string }{target, msg})
 	return nil
 }
-func (m *mockBotAdapter) IsOnline() bool                                 { return m.online }
-func (m *mockBotAdapter) GetNearbyPlayers() []PlayerInfo                 { return nil }
-func (m *mockBotAdapter) GetPlayerByName(name string) (PlayerInfo, bool) { return PlayerInfo{}, false }
-func (m *mockBotAdapter) DistanceTo(x, y, z float64) float64             { return 0 }
-func (m *mockBotAdapter) SetYawPitch(yaw, pitch float32) error           { return nil }
-func (m *mockBotAdapter) LookAt(x, y, z float64) error                   { return nil }
-func (m *mockBotAdapter) SetHeldSlot(slot int16) error                   { return nil }
-func (m *mockBotAdapter) InteractEntity(entityID int32) error            { return nil }
+func (m *mockBotAdapter) IsOnline() bool { return m.online }
 
 type mockCommand struct {
 	name          string
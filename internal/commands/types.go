@@ -2,6 +2,11 @@ package commands
 
 import (
 	"time"
+
+	"gmcc/internal/dialog"
+	"gmcc/internal/item"
+	"gmcc/internal/player"
+	"gmcc/internal/world"
 )
 
 type BotAdapter interface {
@@ -21,6 +26,34 @@ type BotAdapter interface {
 	// 实体交互
 	SetHeldSlot(slot int16) error        // 切换快捷栏槽位 (0-8)
 	InteractEntity(entityID int32) error // 右键点击实体
+	GetVehicle() (int32, bool)           // 当前骑乘的载具实体ID
+	// 战斗
+	Attack(entityID int32) error // 转向并攻击实体
+	SetAutoAttack(enabled bool)  // 开关自动攻击附近的敌对生物
+	AutoAttackEnabled() bool
+	// 背包
+	GetHeldItem() *item.ItemStack                  // 主手物品，空手返回 nil
+	GetInventory() map[int8]*item.ItemStack        // 按玩家背包窗口槽位编号
+	Craft(itemName string, count int) (int, error) // 通过配方书合成，返回实际得到的数量
+	// 世界与服务器状态
+	GetWorldTime() world.Time
+	GetWeather() world.Weather
+	GetWorldBorder() world.Border
+	GetTPS() (world.TPS, bool)         // 根据世界时间估算，刚连接时没有数据
+	GetLatency() (time.Duration, bool) // 服务器测得的 keep_alive 往返延迟
+	// 告示牌
+	GetSign(pos world.BlockPos) (world.Sign, bool)
+	GetSignEditor() (pos world.BlockPos, front bool, ok bool)         // 服务端打开且尚未提交的告示牌编辑界面
+	UpdateSign(pos world.BlockPos, front bool, lines [4]string) error // 提交告示牌内容
+	// 对话框
+	GetDialog() (*dialog.Dialog, bool)                         // 服务端当前显示的对话框
+	ClickDialog(button string, inputs map[string]string) error // 按文字或序号点击按钮，inputs 按输入项 key 给值
+	CloseDialog() error                                        // 按 Esc 关闭
+	// 交易
+	GetNearbyMerchants() []int32                           // 交互距离内的村民和流浪商人实体ID，由近到远
+	OpenMerchant(entityID int32) (*player.Merchant, error) // 右键商人并等待交易列表
+	Trade(index, times int) (int, error)                   // 执行已打开界面的第 index 项交易，返回实际次数
+	CloseMerchant() error
 }
 
 type Message struct {
@@ -25,6 +25,39 @@ type CommandsConfig struct {
 	Whitelist []string `yaml:"whitelist"`
 }
 
+type CombatConfig struct {
+	AutoAttack bool     `yaml:"auto_attack"`
+	Range      float64  `yaml:"range"`
+	Targets    []string `yaml:"targets"` // 实体类型，如 zombie；为空时攻击所有敌对生物
+}
+
+type AutoEatConfig struct {
+	Enabled   bool     `yaml:"enabled"`
+	Threshold int      `yaml:"threshold"` // 饱食度低于该值时进食 (0-20)
+	Blacklist []string `yaml:"blacklist"` // 不吃的物品，如 golden_apple
+}
+
+type RespawnConfig struct {
+	Manual           bool `yaml:"manual"`             // 不自动重生，停留在死亡界面
+	DelayMs          int  `yaml:"delay_ms"`           // 死亡后等待多久再重生
+	RerunJoinActions bool `yaml:"rerun_join_actions"` // 重生后重新执行入服命令，如 /home
+}
+
+type MapsConfig struct {
+	AutoSave bool   `yaml:"auto_save"` // 自动把收到的地图保存为 PNG
+	SaveDir  string `yaml:"save_dir"`  // 地图 PNG 保存目录
+}
+
+type DialogsConfig struct {
+	Rules []DialogRule `yaml:"rules"` // 自动回应对话框，按顺序匹配第一条
+}
+
+type DialogRule struct {
+	Title  string            `yaml:"title"`  // 对话框标题包含该文字时匹配 (纯文本)
+	Button string            `yaml:"button"` // 点击的按钮文字或序号 (从 1 开始)，为空时按 Esc 关闭
+	Inputs map[string]string `yaml:"inputs"` // 按输入项 key 填写的值，未填写的使用初始值
+}
+
 type LogConfig struct {
 	LogDir     string `yaml:"log_dir"`
 	MaxSize    int64  `yaml:"max_size"`
@@ -45,6 +78,11 @@ type Config struct {
 	Server   ServerConfig   `yaml:"server"`
 	Actions  ActionsConfig  `yaml:"actions"`
 	Commands CommandsConfig `yaml:"commands"`
+	Combat   CombatConfig   `yaml:"combat"`
+	AutoEat  AutoEatConfig  `yaml:"auto_eat"`
+	Respawn  RespawnConfig  `yaml:"respawn"`
+	Maps     MapsConfig     `yaml:"maps"`
+	Dialogs  DialogsConfig  `yaml:"dialogs"`
 	Log      LogConfig      `yaml:"log"`
 	Runtime  RuntimeConfig  `yaml:"runtime"`
 	Packets  PacketConfig   `yaml:"packets"`
@@ -73,6 +111,28 @@ func Default() Config {
 			AllowAll:  false,
 			Whitelist: nil,
 		},
+		Combat: CombatConfig{
+			AutoAttack: false,
+			Range:      3.0,
+			Targets:    nil,
+		},
+		AutoEat: AutoEatConfig{
+			Enabled:   false,
+			Threshold: 14,
+			Blacklist: []string{"golden_apple", "enchanted_golden_apple"},
+		},
+		Respawn: RespawnConfig{
+			Manual:           false,
+			DelayMs:          500,
+			RerunJoinActions: false,
+		},
+		Maps: MapsConfig{
+			AutoSave: false,
+			SaveDir:  "maps",
+		},
+		Dialogs: DialogsConfig{
+			Rules: nil,
+		},
 		Log: LogConfig{
 			LogDir:     "logs",
 			MaxSize:    512,
@@ -0,0 +1,234 @@
+// Package dialog 解析 1.21.6 起服务器下发的对话框 (show_dialog)，
+// 并根据按钮和输入内容计算点击后要执行的动作
+package dialog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gmcc/internal/mcclient/chat"
+)
+
+// 对话框类型
+const (
+	TypeNotice       = "minecraft:notice"
+	TypeConfirmation = "minecraft:confirmation"
+	TypeMultiAction  = "minecraft:multi_action"
+	TypeServerLinks  = "minecraft:server_links"
+	TypeDialogList   = "minecraft:dialog_list"
+)
+
+// 正文元素类型
+const (
+	BodyPlainMessage = "minecraft:plain_message"
+	BodyItem         = "minecraft:item"
+)
+
+// 输入控件类型
+const (
+	InputText         = "minecraft:text"
+	InputBoolean      = "minecraft:boolean"
+	InputSingleOption = "minecraft:single_option"
+	InputNumberRange  = "minecraft:number_range"
+)
+
+// 按钮动作类型
+const (
+	ActionOpenURL         = "minecraft:open_url"
+	ActionRunCommand      = "minecraft:run_command"
+	ActionSuggestCommand  = "minecraft:suggest_command"
+	ActionChangePage      = "minecraft:change_page"
+	ActionCopyToClipboard = "minecraft:copy_to_clipboard"
+	ActionShowDialog      = "minecraft:show_dialog"
+	ActionCustom          = "minecraft:custom"
+	ActionDynamicCommand  = "minecraft:dynamic/run_command"
+	ActionDynamicCustom   = "minecraft:dynamic/custom"
+)
+
+// 点击按钮后对话框的行为
+const (
+	AfterClose           = "close"
+	AfterNone            = "none"
+	AfterWaitForResponse = "wait_for_response"
+)
+
+// Text 文本组件
+type Text struct {
+	JSON  string
+	Plain string
+}
+
+func (t Text) String() string { return t.Plain }
+
+// Body 正文元素
+type Body struct {
+	Type  string
+	Text  Text   // plain_message 的内容或物品的描述
+	Item  string // 物品 ID，如 minecraft:diamond
+	Count int32
+}
+
+// Option single_option 的选项
+type Option struct {
+	ID      string
+	Display Text
+	Initial bool
+}
+
+// Input 输入控件，字段按 Type 使用
+type Input struct {
+	Type  string
+	Key   string
+	Label Text
+
+	// text
+	Initial   string
+	MaxLength int32
+	Multiline bool
+
+	// boolean
+	InitialBool     bool
+	OnTrue, OnFalse string
+
+	// single_option
+	Options []Option
+
+	// number_range
+	Start, End    float32
+	Step          float32 // 0 表示连续
+	InitialNumber float32
+}
+
+// Action 按钮动作
+type Action struct {
+	Type      string
+	Value     string         // URL、命令、命令模板、剪贴板内容或页码
+	ID        string         // custom 与 dynamic/custom 的 ID
+	Payload   any            // custom 的附加 NBT
+	Additions map[string]any // dynamic/custom 附加到输入值上的字段
+}
+
+// Button 按钮
+type Button struct {
+	Label   Text
+	Tooltip Text
+	Action  *Action // 为空时只关闭对话框
+}
+
+// Dialog 对话框
+type Dialog struct {
+	ID                 string // 注册表中的名称，内联对话框为空
+	Type               string
+	Title              Text
+	ExternalTitle      Text
+	Body               []Body
+	Inputs             []Input
+	Buttons            []Button // notice 的确认按钮、confirmation 的是/否、multi_action 的按钮列表
+	Exit               *Button  // 按 Esc 时执行的按钮
+	CanCloseWithEscape bool
+	Pause              bool
+	AfterAction        string
+}
+
+// Input 按 key 查找输入控件
+func (d *Dialog) Input(key string) (*Input, bool) {
+	for i := range d.Inputs {
+		if d.Inputs[i].Key == key {
+			return &d.Inputs[i], true
+		}
+	}
+	return nil, false
+}
+
+// Button 按序号 (从 1 开始) 或按钮文字查找按钮，找不到时再匹配退出按钮
+func (d *Dialog) Button(name string) (*Button, error) {
+	name = strings.TrimSpace(name)
+	if n, err := strconv.Atoi(name); err == nil {
+		if n < 1 || n > len(d.Buttons) {
+			return nil, fmt.Errorf("按钮序号超出范围: %d (共 %d 个)", n, len(d.Buttons))
+		}
+		return &d.Buttons[n-1], nil
+	}
+	for i := range d.Buttons {
+		if strings.EqualFold(d.Buttons[i].Label.Plain, name) {
+			return &d.Buttons[i], nil
+		}
+	}
+	if d.Exit != nil && strings.EqualFold(d.Exit.Label.Plain, name) {
+		return d.Exit, nil
+	}
+	return nil, fmt.Errorf("对话框中没有按钮: %s", name)
+}
+
+// Lines 返回便于显示的纯文本描述
+func (d *Dialog) Lines() []string {
+	lines := []string{fmt.Sprintf("[对话框] %s", d.Title.Plain)}
+	for _, b := range d.Body {
+		switch b.Type {
+		case BodyItem:
+			line := fmt.Sprintf("  [物品] %s x%d", b.Item, b.Count)
+			if b.Text.Plain != "" {
+				line += " " + b.Text.Plain
+			}
+			lines = append(lines, line)
+		default:
+			for _, l := range strings.Split(b.Text.Plain, "\n") {
+				lines = append(lines, "  "+l)
+			}
+		}
+	}
+	for _, in := range d.Inputs {
+		lines = append(lines, "  "+in.describe())
+	}
+	for i, b := range d.Buttons {
+		lines = append(lines, fmt.Sprintf("  <%d> %s", i+1, b.Label.Plain))
+	}
+	if d.Exit != nil {
+		lines = append(lines, fmt.Sprintf("  <Esc> %s", d.Exit.Label.Plain))
+	}
+	return lines
+}
+
+func (in Input) describe() string {
+	label := in.Label.Plain
+	switch in.Type {
+	case InputText:
+		return fmt.Sprintf("%s=%q (文本, %s)", in.Key, in.Initial, label)
+	case InputBoolean:
+		return fmt.Sprintf("%s=%v (开关, %s)", in.Key, in.InitialBool, label)
+	case InputSingleOption:
+		ids := make([]string, 0, len(in.Options))
+		initial := ""
+		for _, o := range in.Options {
+			ids = append(ids, o.ID)
+			if o.Initial {
+				initial = o.ID
+			}
+		}
+		return fmt.Sprintf("%s=%s (选项 %s, %s)", in.Key, initial, strings.Join(ids, "|"), label)
+	case InputNumberRange:
+		return fmt.Sprintf("%s=%s (%s..%s, %s)", in.Key, formatNumber(in.InitialNumber), formatNumber(in.Start), formatNumber(in.End), label)
+	default:
+		return fmt.Sprintf("%s (%s)", in.Key, in.Type)
+	}
+}
+
+// textOf 将 NBT 文本组件转为 JSON 与纯文本
+func textOf(v any) Text {
+	if v == nil {
+		return Text{}
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return Text{}
+	}
+	return Text{JSON: string(raw), Plain: chat.ExtractPlainTextFromChatJSON(string(raw))}
+}
+
+// translatable 原版默认按钮文字
+func translatable(key, fallback string) Text {
+	raw, _ := json.Marshal(map[string]string{"translate": key, "fallback": fallback})
+	return Text{JSON: string(raw), Plain: fallback}
+}
@@ -0,0 +1,130 @@
+package dialog
+
+import (
+	"strings"
+	"testing"
+)
+
+func formDialog() map[string]any {
+	return map[string]any{
+		"type":  "multi_action",
+		"title": map[string]any{"text": "注册"},
+		"body": []any{
+			map[string]any{"type": "plain_message", "contents": "请填写信息"},
+		},
+		"inputs": []any{
+			map[string]any{"type": "text", "key": "name", "label": "名字", "max_length": int32(8)},
+			map[string]any{"type": "boolean", "key": "agree", "label": "同意", "on_true": "yes", "on_false": "no"},
+			map[string]any{"type": "single_option", "key": "team", "label": "队伍", "options": []any{
+				map[string]any{"id": "red", "display": "Red"},
+				map[string]any{"id": "blue", "display": "Blue", "initial": int8(1)},
+			}},
+			map[string]any{"type": "number_range", "key": "age", "label": "年龄", "start": float32(1), "end": float32(99), "initial": float32(18)},
+		},
+		"actions": []any{
+			map[string]any{"label": "提交", "action": map[string]any{
+				"type": "dynamic/custom", "id": "srv:register",
+				"additions": map[string]any{"v": int32(2)},
+			}},
+			map[string]any{"label": "命令", "action": map[string]any{
+				"type": "dynamic/run_command", "template": "/reg $(name) $(team) $(agree) $(age)",
+			}},
+			map[string]any{"label": "规则", "action": map[string]any{"type": "run_command", "command": "/rules"}},
+		},
+		"exit_action": map[string]any{"label": "取消"},
+	}
+}
+
+func TestParse(t *testing.T) {
+	d, err := Parse(formDialog())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if d.Type != TypeMultiAction || d.Title.Plain != "注册" || !d.CanCloseWithEscape || d.AfterAction != AfterClose {
+		t.Errorf("Dialog = %+v", d)
+	}
+	if len(d.Body) != 1 || d.Body[0].Text.Plain != "请填写信息" {
+		t.Errorf("Body = %+v", d.Body)
+	}
+	if len(d.Inputs) != 4 || len(d.Buttons) != 3 || d.Exit == nil || d.Exit.Label.Plain != "取消" {
+		t.Fatalf("Inputs = %+v, Buttons = %+v, Exit = %+v", d.Inputs, d.Buttons, d.Exit)
+	}
+	if in, ok := d.Input("team"); !ok || in.Options[0].Initial || !in.Options[1].Initial {
+		t.Errorf("team = %+v", in)
+	}
+	if b, err := d.Button("2"); err != nil || b.Label.Plain != "命令" {
+		t.Errorf("Button(2) = %+v, %v", b, err)
+	}
+	if b, err := d.Button("取消"); err != nil || b != d.Exit {
+		t.Errorf("Button(取消) = %+v, %v", b, err)
+	}
+	if _, err := d.Button("4"); err == nil {
+		t.Error("越界的序号应返回错误")
+	}
+	if lines := d.Lines(); len(lines) != 10 || !strings.Contains(lines[4], "team=blue") {
+		t.Errorf("Lines() = %q", lines)
+	}
+
+	notice, err := Parse(map[string]any{"type": "minecraft:notice", "title": "公告"})
+	if err != nil || len(notice.Buttons) != 1 || notice.Buttons[0].Label.Plain != "OK" {
+		t.Errorf("notice = %+v, %v", notice, err)
+	}
+	if _, err := Parse(map[string]any{"type": "unknown"}); err == nil {
+		t.Error("未知类型应返回错误")
+	}
+}
+
+func TestClick(t *testing.T) {
+	d, err := Parse(formDialog())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	result, err := d.Click(&d.Buttons[0], map[string]string{"name": "Steve", "agree": "true", "team": "Red"})
+	if err != nil {
+		t.Fatalf("Click() error = %v", err)
+	}
+	payload, _ := result.Payload.(map[string]any)
+	if result.Kind != ResultCustom || result.ID != "srv:register" ||
+		payload["name"] != "Steve" || payload["agree"] != int8(1) || payload["team"] != "red" ||
+		payload["age"] != float32(18) || payload["v"] != int32(2) {
+		t.Errorf("Click() = %+v", result)
+	}
+
+	result, err = d.Click(&d.Buttons[1], map[string]string{"name": "Alex", "age": "20.5"})
+	if err != nil {
+		t.Fatalf("Click() error = %v", err)
+	}
+	if result.Kind != ResultCommand || result.Command != "reg Alex blue no 20.5" {
+		t.Errorf("Click() = %+v", result)
+	}
+
+	if result, _ := d.Click(&d.Buttons[2], nil); result.Kind != ResultCommand || result.Command != "rules" {
+		t.Errorf("Click(run_command) = %+v", result)
+	}
+	if result, _ := d.Click(d.Exit, nil); result.Kind != ResultClose {
+		t.Errorf("Click(exit) = %+v", result)
+	}
+
+	for _, values := range []map[string]string{
+		{"name": "TooLongName"},
+		{"agree": "maybe"},
+		{"team": "green"},
+		{"age": "100"},
+		{"unknown": "1"},
+	} {
+		if _, err := d.Click(&d.Buttons[0], values); err == nil {
+			t.Errorf("Click(%v) 应返回错误", values)
+		}
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	button, inputs := ParseArgs(`Accept rules name="Steve Alex" agree=true`)
+	if button != "Accept rules" || len(inputs) != 2 || inputs["name"] != "Steve Alex" || inputs["agree"] != "true" {
+		t.Errorf("ParseArgs() = %q, %v", button, inputs)
+	}
+	if button, inputs := ParseArgs("2"); button != "2" || inputs != nil {
+		t.Errorf("ParseArgs(2) = %q, %v", button, inputs)
+	}
+}
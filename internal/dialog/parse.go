@@ -0,0 +1,276 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parse 从解码后的 NBT 构建对话框
+func Parse(v any) (*Dialog, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("对话框不是复合标签: %T", v)
+	}
+	d := &Dialog{
+		Type:               namespaced(str(m["type"])),
+		Title:              textOf(m["title"]),
+		ExternalTitle:      textOf(m["external_title"]),
+		CanCloseWithEscape: boolOr(m["can_close_with_escape"], true),
+		Pause:              boolOr(m["pause"], true),
+		AfterAction:        str(m["after_action"]),
+	}
+	if d.AfterAction == "" {
+		d.AfterAction = AfterClose
+	}
+
+	for _, b := range listOf(m["body"]) {
+		body, err := parseBody(b)
+		if err != nil {
+			return nil, err
+		}
+		d.Body = append(d.Body, body)
+	}
+	for i, in := range listOf(m["inputs"]) {
+		input, err := parseInput(in)
+		if err != nil {
+			return nil, fmt.Errorf("解析输入[%d]失败: %w", i, err)
+		}
+		d.Inputs = append(d.Inputs, input)
+	}
+
+	var err error
+	switch d.Type {
+	case TypeNotice:
+		button := Button{Label: translatable("gui.ok", "OK")}
+		if v, ok := m["action"]; ok {
+			if button, err = parseButton(v); err != nil {
+				return nil, err
+			}
+		}
+		d.Buttons = []Button{button}
+	case TypeConfirmation:
+		yes, err := parseButton(m["yes"])
+		if err != nil {
+			return nil, fmt.Errorf("解析 yes 按钮失败: %w", err)
+		}
+		no, err := parseButton(m["no"])
+		if err != nil {
+			return nil, fmt.Errorf("解析 no 按钮失败: %w", err)
+		}
+		d.Buttons = []Button{yes, no}
+	case TypeMultiAction:
+		for i, a := range listOf(m["actions"]) {
+			button, err := parseButton(a)
+			if err != nil {
+				return nil, fmt.Errorf("解析按钮[%d]失败: %w", i, err)
+			}
+			d.Buttons = append(d.Buttons, button)
+		}
+	case TypeServerLinks, TypeDialogList:
+		// 链接列表和子对话框需要额外的注册表数据，只保留退出按钮
+	default:
+		return nil, fmt.Errorf("未知对话框类型: %s", d.Type)
+	}
+
+	if v, ok := m["exit_action"]; ok {
+		exit, err := parseButton(v)
+		if err != nil {
+			return nil, fmt.Errorf("解析退出按钮失败: %w", err)
+		}
+		d.Exit = &exit
+	}
+	return d, nil
+}
+
+func parseBody(v any) (Body, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return Body{}, fmt.Errorf("正文元素不是复合标签: %T", v)
+	}
+	body := Body{Type: namespaced(str(m["type"]))}
+	switch body.Type {
+	case BodyPlainMessage:
+		body.Text = textOf(m["contents"])
+	case BodyItem:
+		item, _ := m["item"].(map[string]any)
+		body.Item = namespaced(str(item["id"]))
+		body.Count = intOr(item["count"], 1)
+		if desc, ok := m["description"].(map[string]any); ok {
+			body.Text = textOf(desc["contents"])
+		} else if v, ok := m["description"]; ok {
+			body.Text = textOf(v)
+		}
+	default:
+		return Body{}, fmt.Errorf("未知正文类型: %s", body.Type)
+	}
+	return body, nil
+}
+
+func parseInput(v any) (Input, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return Input{}, fmt.Errorf("输入控件不是复合标签: %T", v)
+	}
+	in := Input{
+		Type:  namespaced(str(m["type"])),
+		Key:   str(m["key"]),
+		Label: textOf(m["label"]),
+	}
+	if in.Key == "" {
+		return Input{}, fmt.Errorf("输入控件缺少 key")
+	}
+
+	switch in.Type {
+	case InputText:
+		in.Initial = str(m["initial"])
+		in.MaxLength = intOr(m["max_length"], 32)
+		_, in.Multiline = m["multiline"]
+	case InputBoolean:
+		in.InitialBool = boolOr(m["initial"], false)
+		in.OnTrue, in.OnFalse = strOr(m["on_true"], "true"), strOr(m["on_false"], "false")
+	case InputSingleOption:
+		for _, o := range listOf(m["options"]) {
+			switch o := o.(type) {
+			case string:
+				in.Options = append(in.Options, Option{ID: o})
+			case map[string]any:
+				in.Options = append(in.Options, Option{
+					ID:      str(o["id"]),
+					Display: textOf(o["display"]),
+					Initial: boolOr(o["initial"], false),
+				})
+			}
+		}
+		if len(in.Options) == 0 {
+			return Input{}, fmt.Errorf("%s 没有选项", in.Key)
+		}
+		// 没有指定初始值时选中第一个
+		initial := 0
+		for i, o := range in.Options {
+			if o.Initial {
+				initial = i
+				break
+			}
+		}
+		for i := range in.Options {
+			in.Options[i].Initial = i == initial
+		}
+	case InputNumberRange:
+		in.Start, in.End = floatOr(m["start"], 0), floatOr(m["end"], 0)
+		in.Step = floatOr(m["step"], 0)
+		in.InitialNumber = floatOr(m["initial"], (in.Start+in.End)/2)
+	default:
+		return Input{}, fmt.Errorf("未知输入类型: %s", in.Type)
+	}
+	return in, nil
+}
+
+func parseButton(v any) (Button, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return Button{}, fmt.Errorf("按钮不是复合标签: %T", v)
+	}
+	button := Button{Label: textOf(m["label"]), Tooltip: textOf(m["tooltip"])}
+	if a, ok := m["action"].(map[string]any); ok {
+		action, err := parseAction(a)
+		if err != nil {
+			return Button{}, err
+		}
+		button.Action = action
+	}
+	return button, nil
+}
+
+func parseAction(m map[string]any) (*Action, error) {
+	a := &Action{Type: namespaced(str(m["type"]))}
+	switch a.Type {
+	case ActionOpenURL:
+		a.Value = str(m["url"])
+	case ActionRunCommand, ActionSuggestCommand:
+		a.Value = str(m["command"])
+	case ActionChangePage:
+		a.Value = fmt.Sprint(intOr(m["page"], 0))
+	case ActionCopyToClipboard:
+		a.Value = str(m["value"])
+	case ActionShowDialog:
+		a.Value = str(m["dialog"])
+	case ActionCustom:
+		a.ID = namespaced(str(m["id"]))
+		a.Payload = m["payload"]
+	case ActionDynamicCommand:
+		a.Value = str(m["template"])
+	case ActionDynamicCustom:
+		a.ID = namespaced(str(m["id"]))
+		a.Additions, _ = m["additions"].(map[string]any)
+	default:
+		return nil, fmt.Errorf("未知按钮动作: %s", a.Type)
+	}
+	return a, nil
+}
+
+// listOf 兼容单个元素与列表两种写法
+func listOf(v any) []any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		return v
+	default:
+		return []any{v}
+	}
+}
+
+func namespaced(id string) string {
+	if id != "" && !strings.Contains(id, ":") {
+		return "minecraft:" + id
+	}
+	return id
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func strOr(v any, def string) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return def
+}
+
+func boolOr(v any, def bool) bool {
+	switch v := v.(type) {
+	case int8:
+		return v != 0
+	case bool:
+		return v
+	}
+	return def
+}
+
+func intOr(v any, def int32) int32 {
+	switch v := v.(type) {
+	case int8:
+		return int32(v)
+	case int16:
+		return int32(v)
+	case int32:
+		return v
+	case int64:
+		return int32(v)
+	}
+	return def
+}
+
+func floatOr(v any, def float32) float32 {
+	switch v := v.(type) {
+	case float32:
+		return v
+	case float64:
+		return float32(v)
+	case int8, int16, int32, int64:
+		return float32(intOr(v, 0))
+	}
+	return def
+}
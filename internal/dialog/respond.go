@@ -0,0 +1,187 @@
+package dialog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// 点击结果类型
+const (
+	ResultClose   = iota // 只关闭对话框 (或客户端无法执行的动作，如打开网页)
+	ResultCommand        // 执行命令
+	ResultCustom         // 发送 custom_click_action
+)
+
+// Result 点击按钮后客户端要执行的操作
+type Result struct {
+	Kind    int
+	Command string // 不带前导 /
+	ID      string
+	Payload any // custom_click_action 的 NBT，nil 表示没有
+}
+
+// value 一个输入控件的取值
+type value struct {
+	tag   any    // dynamic/custom 中的 NBT 值
+	subst string // dynamic/run_command 模板中的替换文本
+}
+
+// Click 计算点击按钮的结果，values 按输入控件的 key 给出文本形式的值，未给出的使用初始值
+func (d *Dialog) Click(button *Button, values map[string]string) (Result, error) {
+	if button == nil || button.Action == nil {
+		return Result{Kind: ResultClose}, nil
+	}
+	a := button.Action
+	switch a.Type {
+	case ActionRunCommand:
+		return Result{Kind: ResultCommand, Command: strings.TrimPrefix(a.Value, "/")}, nil
+	case ActionCustom:
+		return Result{Kind: ResultCustom, ID: a.ID, Payload: a.Payload}, nil
+	}
+	if a.Type != ActionDynamicCommand && a.Type != ActionDynamicCustom {
+		return Result{Kind: ResultClose}, nil
+	}
+
+	resolved, err := d.resolve(values)
+	if err != nil {
+		return Result{}, err
+	}
+	if a.Type == ActionDynamicCommand {
+		pairs := make([]string, 0, len(resolved)*2)
+		for key, v := range resolved {
+			pairs = append(pairs, "$("+key+")", v.subst)
+		}
+		command := strings.NewReplacer(pairs...).Replace(a.Value)
+		return Result{Kind: ResultCommand, Command: strings.TrimPrefix(command, "/")}, nil
+	}
+
+	payload := make(map[string]any, len(a.Additions)+len(resolved))
+	for k, v := range a.Additions {
+		payload[k] = v
+	}
+	for key, v := range resolved {
+		payload[key] = v.tag
+	}
+	return Result{Kind: ResultCustom, ID: a.ID, Payload: payload}, nil
+}
+
+// resolve 校验输入值，未知的 key 视为错误
+func (d *Dialog) resolve(values map[string]string) (map[string]value, error) {
+	for key := range values {
+		if _, ok := d.Input(key); !ok {
+			return nil, fmt.Errorf("对话框中没有输入项: %s", key)
+		}
+	}
+
+	resolved := make(map[string]value, len(d.Inputs))
+	for _, in := range d.Inputs {
+		raw, given := values[in.Key]
+		v, err := in.parse(raw, given)
+		if err != nil {
+			return nil, fmt.Errorf("输入项 %s: %w", in.Key, err)
+		}
+		resolved[in.Key] = v
+	}
+	return resolved, nil
+}
+
+func (in Input) parse(raw string, given bool) (value, error) {
+	switch in.Type {
+	case InputText:
+		text := in.Initial
+		if given {
+			text = raw
+		}
+		if n := len(utf16.Encode([]rune(text))); in.MaxLength > 0 && n > int(in.MaxLength) {
+			return value{}, fmt.Errorf("长度 %d 超过上限 %d", n, in.MaxLength)
+		}
+		return value{tag: text, subst: text}, nil
+
+	case InputBoolean:
+		b := in.InitialBool
+		if given {
+			var err error
+			if b, err = strconv.ParseBool(raw); err != nil {
+				return value{}, fmt.Errorf("不是有效的布尔值: %s", raw)
+			}
+		}
+		subst, tag := in.OnFalse, int8(0)
+		if b {
+			subst, tag = in.OnTrue, 1
+		}
+		return value{tag: tag, subst: subst}, nil
+
+	case InputSingleOption:
+		for _, o := range in.Options {
+			if (!given && o.Initial) || (given && (o.ID == raw || (o.Display.Plain != "" && strings.EqualFold(o.Display.Plain, raw)))) {
+				return value{tag: o.ID, subst: o.ID}, nil
+			}
+		}
+		return value{}, fmt.Errorf("没有选项: %s", raw)
+
+	case InputNumberRange:
+		n := in.InitialNumber
+		if given {
+			f, err := strconv.ParseFloat(raw, 32)
+			if err != nil {
+				return value{}, fmt.Errorf("不是有效的数字: %s", raw)
+			}
+			n = float32(f)
+		}
+		lo, hi := min(in.Start, in.End), max(in.Start, in.End)
+		if n < lo || n > hi {
+			return value{}, fmt.Errorf("%s 超出范围 %s..%s", formatNumber(n), formatNumber(lo), formatNumber(hi))
+		}
+		return value{tag: n, subst: formatNumber(n)}, nil
+	}
+	return value{}, fmt.Errorf("不支持的输入类型: %s", in.Type)
+}
+
+// formatNumber 与原版一样，整数不带小数点
+func formatNumber(n float32) string {
+	if n == float32(int64(n)) {
+		return strconv.FormatInt(int64(n), 10)
+	}
+	return strconv.FormatFloat(float64(n), 'f', -1, 32)
+}
+
+// ParseArgs 解析 `按钮 key=value ...` 形式的参数，值可以用双引号包含空格，
+// 不含 = 的部分拼接为按钮文字
+func ParseArgs(s string) (button string, inputs map[string]string) {
+	var words []string
+	var cur strings.Builder
+	quoted, started := false, false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted, started = !quoted, true
+		case r == ' ' && !quoted:
+			if started {
+				words = append(words, cur.String())
+				cur.Reset()
+				started = false
+			}
+		default:
+			cur.WriteRune(r)
+			started = true
+		}
+	}
+	if started {
+		words = append(words, cur.String())
+	}
+
+	var parts []string
+	for _, w := range words {
+		if key, val, ok := strings.Cut(w, "="); ok && key != "" {
+			if inputs == nil {
+				inputs = make(map[string]string)
+			}
+			inputs[key] = val
+			continue
+		}
+		parts = append(parts, w)
+	}
+	return strings.Join(parts, " "), inputs
+}
@@ -2,20 +2,72 @@
 package entity
 
 import (
+	"math"
 	"time"
+
+	"gmcc/internal/item"
+	"gmcc/internal/registry"
 )
 
 // Entity 表示Minecraft世界中的一个实体
 type Entity struct {
 	ID         int32
-	Type       string   // "minecraft:player" 或其他实体类型
-	UUID       [16]byte // 可选，不是所有实体都有
+	Type       string                   // "minecraft:player" 或其他实体类型
+	Info       *registry.EntityTypeInfo // 类型信息，未知类型为 nil
+	UUID       [16]byte                 // 可选，不是所有实体都有
 	Position   Position
-	Velocity   Vector3
+	Velocity   Vector3 // 每 tick 移动的格数
+	Yaw        float32
+	Pitch      float32
+	HeadYaw    float32
 	OnGround   bool
 	LastUpdate time.Time
+
+	// 骑乘关系 (set_passengers)，Vehicle 为 0 表示未骑乘
+	Vehicle    int32
+	Passengers []int32
+
+	movedAt time.Time // 最近一次位置更新时间，用于插值
+
+	// 实体数据 (set_entity_data)，按索引保存最近一次的值
+	Metadata    map[uint8]MetadataEntry
+	Flags       int8   // 见 Flag* 常量
+	CustomName  string // 自定义名称纯文本，未设置时为空
+	NameVisible bool
+	Pose        Pose
+	Health      float32         // 生物实体的生命值
+	Item        *item.ItemStack // 掉落物、物品展示框、投掷物等显示的物品
 }
 
+// 实体通用标志位 (索引 0)
+const (
+	FlagOnFire     int8 = 0x01
+	FlagSneaking   int8 = 0x02
+	FlagSprinting  int8 = 0x08
+	FlagSwimming   int8 = 0x10
+	FlagInvisible  int8 = 0x20
+	FlagGlowing    int8 = 0x40
+	FlagFallFlying int8 = -0x80
+)
+
+// 所有实体共有的数据索引，之后的索引由具体实体类型决定
+const (
+	metaIndexFlags       = 0
+	metaIndexCustomName  = 2
+	metaIndexNameVisible = 3
+	metaIndexPose        = 6
+	// 掉落物、物品展示框、投掷物等的物品
+	metaIndexItem = 8
+	// LivingEntity 的生命值
+	metaIndexHealth = 9
+)
+
+// maxExtrapolation 限制按速度外推的时长，避免长时间无更新时位置飘走
+const maxExtrapolation = time.Second
+
+// tickDuration 与服务端一致的游戏刻时长
+const tickDuration = 50 * time.Millisecond
+
 // Position 表示三维空间位置
 type Position struct {
 	X, Y, Z float64
@@ -26,20 +78,150 @@ type Vector3 struct {
 	X, Y, Z float64
 }
 
+// PredictPosition 按最近一次速度推算实体在 at 时刻的位置，
+// 外推时长不超过 maxExtrapolation
+func (e *Entity) PredictPosition(at time.Time) Position {
+	if e.movedAt.IsZero() || !at.After(e.movedAt) {
+		return e.Position
+	}
+	elapsed := min(at.Sub(e.movedAt), maxExtrapolation)
+	ticks := float64(elapsed) / float64(tickDuration)
+	return Position{
+		X: e.Position.X + e.Velocity.X*ticks,
+		Y: e.Position.Y + e.Velocity.Y*ticks,
+		Z: e.Position.Z + e.Velocity.Z*ticks,
+	}
+}
+
+// IsRiding 检查实体是否正在骑乘其他实体
+func (e *Entity) IsRiding() bool {
+	return e.Vehicle != 0
+}
+
 // IsPlayer 检查实体是否为玩家类型
 func (e *Entity) IsPlayer() bool {
 	return e.Type == "minecraft:player"
 }
 
-// DistanceTo 计算到另一个位置的距离
-func (p Position) DistanceTo(other Position) float64 {
+// Category 返回实体的刷怪分类，未知类型视为 misc
+func (e *Entity) Category() registry.EntityCategory {
+	if e.Info == nil {
+		return registry.CategoryMisc
+	}
+	return e.Info.Category
+}
+
+// LocalizedName 返回实体类型的本地化名称
+func (e *Entity) LocalizedName() string {
+	if e.Info == nil {
+		return e.Type
+	}
+	return e.Info.LocalizedName()
+}
+
+// DisplayName 返回自定义名称，掉落物等返回物品名，否则返回实体类型的本地化名称
+func (e *Entity) DisplayName() string {
+	if e.CustomName != "" {
+		return e.CustomName
+	}
+	if e.Item != nil {
+		return e.Item.DisplayName()
+	}
+	return e.LocalizedName()
+}
+
+// HasFlag 检查通用标志位，如 FlagOnFire
+func (e *Entity) HasFlag(flag int8) bool {
+	return e.Flags&flag != 0
+}
+
+// IsLiving 是否为有生命值的生物实体，未知类型视为否
+func (e *Entity) IsLiving() bool {
+	return e.Info != nil && e.Info.Living()
+}
+
+// IsDead 检查实体是否已死亡，只有收到过生命值的生物实体才可能返回 true
+func (e *Entity) IsDead() bool {
+	m, ok := e.Metadata[metaIndexHealth]
+	return ok && m.Type == MetaFloat && e.IsLiving() && e.Health <= 0
+}
+
+func (e *Entity) IsOnFire() bool    { return e.HasFlag(FlagOnFire) }
+func (e *Entity) IsSneaking() bool  { return e.HasFlag(FlagSneaking) }
+func (e *Entity) IsInvisible() bool { return e.HasFlag(FlagInvisible) }
+func (e *Entity) IsGlowing() bool   { return e.HasFlag(FlagGlowing) }
+
+// ApplyMetadata 写入实体数据并更新对应的类型化字段
+func (e *Entity) ApplyMetadata(entries []MetadataEntry) {
+	if e.Metadata == nil {
+		e.Metadata = make(map[uint8]MetadataEntry, len(entries))
+	}
+	for _, m := range entries {
+		e.Metadata[m.Index] = m
+		switch v := m.Value.(type) {
+		case int8:
+			if m.Index == metaIndexFlags {
+				e.Flags = v
+			}
+		case *string:
+			if m.Index == metaIndexCustomName {
+				e.CustomName = ""
+				if v != nil {
+					e.CustomName = *v
+				}
+			}
+		case bool:
+			if m.Index == metaIndexNameVisible {
+				e.NameVisible = v
+			}
+		case Pose:
+			if m.Index == metaIndexPose {
+				e.Pose = v
+			}
+		case *item.ItemStack:
+			if m.Index == metaIndexItem {
+				e.Item = v
+			}
+		case float32:
+			// 非生物实体的索引 9 是其他数据，如交互实体的宽度
+			if m.Index == metaIndexHealth && m.Type == MetaFloat && e.IsLiving() {
+				e.Health = v
+			}
+		}
+	}
+}
+
+// DistanceSqTo 计算到另一个位置的距离平方，比较远近时优先使用
+func (p Position) DistanceSqTo(other Position) float64 {
 	dx := p.X - other.X
 	dy := p.Y - other.Y
 	dz := p.Z - other.Z
-	return float64(dx*dx + dy*dy + dz*dz)
+	return dx*dx + dy*dy + dz*dz
+}
+
+// DistanceTo 计算到另一个位置的实际距离
+func (p Position) DistanceTo(other Position) float64 {
+	return math.Sqrt(p.DistanceSqTo(other))
 }
 
-// DistanceTo 计算实体到另一个位置的距离
+// DistanceSqTo 计算实体到另一个位置的距离平方
+func (e *Entity) DistanceSqTo(other Position) float64 {
+	return e.Position.DistanceSqTo(other)
+}
+
+// DistanceTo 计算实体到另一个位置的实际距离
 func (e *Entity) DistanceTo(other Position) float64 {
 	return e.Position.DistanceTo(other)
 }
+
+// BoundingBox 返回实体的碰撞箱，未知类型视为一个点
+func (e *Entity) BoundingBox() AABB {
+	var halfWidth, height float64
+	if e.Info != nil {
+		halfWidth, height = e.Info.Width/2, e.Info.Height
+	}
+	return AABB{
+		Min: Position{X: e.Position.X - halfWidth, Y: e.Position.Y, Z: e.Position.Z - halfWidth},
+		Max: Position{X: e.Position.X + halfWidth, Y: e.Position.Y + height, Z: e.Position.Z + halfWidth},
+	}
+}
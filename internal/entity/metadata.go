@@ -0,0 +1,417 @@
+package entity
+
+import (
+	"bytes"
+	"fmt"
+
+	"gmcc/internal/item"
+	"gmcc/internal/item/component"
+	"gmcc/internal/mcclient/chat"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/registry"
+)
+
+// MetadataType 实体数据序列化器，对应 minecraft:entity_data_serializer 的网络 ID (1.21.11)
+type MetadataType int32
+
+const (
+	MetaByte                  MetadataType = 0
+	MetaVarInt                MetadataType = 1
+	MetaVarLong               MetadataType = 2
+	MetaFloat                 MetadataType = 3
+	MetaString                MetadataType = 4
+	MetaTextComponent         MetadataType = 5
+	MetaOptionalText          MetadataType = 6
+	MetaSlot                  MetadataType = 7
+	MetaBoolean               MetadataType = 8
+	MetaRotations             MetadataType = 9
+	MetaBlockPos              MetadataType = 10
+	MetaOptionalBlockPos      MetadataType = 11
+	MetaDirection             MetadataType = 12
+	MetaOptionalLivingEntity  MetadataType = 13
+	MetaBlockState            MetadataType = 14
+	MetaOptionalBlockState    MetadataType = 15
+	MetaParticle              MetadataType = 16
+	MetaParticles             MetadataType = 17
+	MetaVillagerData          MetadataType = 18
+	MetaOptionalVarInt        MetadataType = 19
+	MetaPose                  MetadataType = 20
+	MetaCatVariant            MetadataType = 21
+	MetaCowVariant            MetadataType = 22
+	MetaWolfVariant           MetadataType = 23
+	MetaWolfSoundVariant      MetadataType = 24
+	MetaFrogVariant           MetadataType = 25
+	MetaPigVariant            MetadataType = 26
+	MetaChickenVariant        MetadataType = 27
+	MetaZombieNautilusVariant MetadataType = 28
+	MetaOptionalGlobalPos     MetadataType = 29
+	MetaPaintingVariant       MetadataType = 30
+	MetaSnifferState          MetadataType = 31
+	MetaArmadilloState        MetadataType = 32
+	MetaCopperGolemState      MetadataType = 33
+	MetaWeatheringCopperState MetadataType = 34
+	MetaVector3               MetadataType = 35
+	MetaQuaternion            MetadataType = 36
+	MetaResolvableProfile     MetadataType = 37
+	MetaHumanoidArm           MetadataType = 38
+)
+
+// metadataEnd 实体数据列表的结束标记
+const metadataEnd = 0xFF
+
+// MetadataEntry 一条实体数据。Value 的 Go 类型由 Type 决定:
+//
+//	Byte → int8, VarInt/Direction/BlockState/各种变种与状态 → int32, VarLong → int64,
+//	Float → float32, String → string, TextComponent → string (纯文本),
+//	OptionalText → *string, Slot → *item.ItemStack, Boolean → bool,
+//	Rotations/Vector3 → [3]float32, Quaternion → [4]float32, BlockPos → BlockPos,
+//	OptionalBlockPos → *BlockPos, OptionalLivingEntity → *[16]byte,
+//	OptionalBlockState/OptionalVarInt → *int32, Particle → Particle, Particles → []Particle,
+//	VillagerData → VillagerData, Pose → Pose, OptionalGlobalPos → *GlobalPos,
+//	ResolvableProfile → nil (仅跳过)
+type MetadataEntry struct {
+	Index uint8
+	Type  MetadataType
+	Value any
+}
+
+// BlockPos 方块坐标
+type BlockPos struct {
+	X, Y, Z int32
+}
+
+// GlobalPos 带维度的方块坐标
+type GlobalPos struct {
+	Dimension string
+	Pos       BlockPos
+}
+
+// VillagerData 村民的群系类型、职业与等级 (类型与职业为注册表 ID)
+type VillagerData struct {
+	Type       int32
+	Profession int32
+	Level      int32
+}
+
+// Particle 粒子效果，仅保留类型
+type Particle struct {
+	ID   int32
+	Name string
+}
+
+// Pose 实体姿态
+type Pose int32
+
+const (
+	PoseStanding Pose = iota
+	PoseFallFlying
+	PoseSleeping
+	PoseSwimming
+	PoseSpinAttack
+	PoseCrouching
+	PoseLongJumping
+	PoseDying
+	PoseCroaking
+	PoseUsingTongue
+	PoseSitting
+	PoseRoaring
+	PoseSniffing
+	PoseEmerging
+	PoseDigging
+	PoseSliding
+	PoseShooting
+	PoseInhaling
+)
+
+var poseNames = [...]string{
+	"standing", "fall_flying", "sleeping", "swimming", "spin_attack", "crouching",
+	"long_jumping", "dying", "croaking", "using_tongue", "sitting", "roaring",
+	"sniffing", "emerging", "digging", "sliding", "shooting", "inhaling",
+}
+
+func (p Pose) String() string {
+	if p >= 0 && int(p) < len(poseNames) {
+		return poseNames[p]
+	}
+	return fmt.Sprintf("pose_%d", int32(p))
+}
+
+// ReadMetadata 读取 set_entity_data 的实体数据列表，直到结束标记 0xFF。
+// 出错时返回已成功读取的条目，后续条目无法定位。
+func ReadMetadata(r *bytes.Reader) ([]MetadataEntry, error) {
+	var entries []MetadataEntry
+	for {
+		index, err := packet.ReadU8(r)
+		if err != nil {
+			return entries, err
+		}
+		if index == metadataEnd {
+			return entries, nil
+		}
+		t, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			return entries, err
+		}
+		value, err := readMetadataValue(r, MetadataType(t))
+		if err != nil {
+			return entries, fmt.Errorf("index %d (type %d): %w", index, t, err)
+		}
+		entries = append(entries, MetadataEntry{Index: index, Type: MetadataType(t), Value: value})
+	}
+}
+
+func readMetadataValue(r *bytes.Reader, t MetadataType) (any, error) {
+	switch t {
+	case MetaByte:
+		b, err := packet.ReadU8(r)
+		return int8(b), err
+	case MetaVarInt, MetaDirection, MetaBlockState,
+		MetaCatVariant, MetaCowVariant, MetaWolfVariant, MetaWolfSoundVariant,
+		MetaFrogVariant, MetaPigVariant, MetaChickenVariant, MetaZombieNautilusVariant,
+		MetaSnifferState, MetaArmadilloState, MetaCopperGolemState, MetaWeatheringCopperState,
+		MetaHumanoidArm:
+		return packet.ReadVarIntFromReader(r)
+	case MetaVarLong:
+		return readVarLong(r)
+	case MetaFloat:
+		return packet.ReadFloat32FromReader(r)
+	case MetaString:
+		return packet.ReadStringFromReader(r)
+	case MetaTextComponent:
+		return readText(r)
+	case MetaOptionalText:
+		present, err := packet.ReadBoolFromReader(r)
+		if err != nil || !present {
+			return (*string)(nil), err
+		}
+		text, err := readText(r)
+		return &text, err
+	case MetaSlot:
+		return item.ReadItemStack(r)
+	case MetaBoolean:
+		return packet.ReadBoolFromReader(r)
+	case MetaRotations, MetaVector3:
+		var v [3]float32
+		err := readFloats(r, v[:])
+		return v, err
+	case MetaQuaternion:
+		var v [4]float32
+		err := readFloats(r, v[:])
+		return v, err
+	case MetaBlockPos:
+		return readBlockPos(r)
+	case MetaOptionalBlockPos:
+		present, err := packet.ReadBoolFromReader(r)
+		if err != nil || !present {
+			return (*BlockPos)(nil), err
+		}
+		pos, err := readBlockPos(r)
+		return &pos, err
+	case MetaOptionalLivingEntity:
+		present, err := packet.ReadBoolFromReader(r)
+		if err != nil || !present {
+			return (*[16]byte)(nil), err
+		}
+		uuid, err := packet.ReadUUID(r)
+		return &uuid, err
+	case MetaOptionalBlockState:
+		// 0 (空气) 表示空
+		v, err := packet.ReadVarIntFromReader(r)
+		if err != nil || v == 0 {
+			return (*int32)(nil), err
+		}
+		return &v, nil
+	case MetaOptionalVarInt:
+		// 0 表示空，否则为 值+1
+		v, err := packet.ReadVarIntFromReader(r)
+		if err != nil || v == 0 {
+			return (*int32)(nil), err
+		}
+		v--
+		return &v, nil
+	case MetaParticle:
+		return readParticle(r)
+	case MetaParticles:
+		n, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 || int(n) > r.Len() {
+			return nil, fmt.Errorf("粒子列表长度无效: %d", n)
+		}
+		particles := make([]Particle, 0, n)
+		for i := int32(0); i < n; i++ {
+			p, err := readParticle(r)
+			if err != nil {
+				return nil, err
+			}
+			particles = append(particles, p)
+		}
+		return particles, nil
+	case MetaVillagerData:
+		var v VillagerData
+		var err error
+		if v.Type, err = packet.ReadVarIntFromReader(r); err != nil {
+			return nil, err
+		}
+		if v.Profession, err = packet.ReadVarIntFromReader(r); err != nil {
+			return nil, err
+		}
+		v.Level, err = packet.ReadVarIntFromReader(r)
+		return v, err
+	case MetaPose:
+		v, err := packet.ReadVarIntFromReader(r)
+		return Pose(v), err
+	case MetaOptionalGlobalPos:
+		present, err := packet.ReadBoolFromReader(r)
+		if err != nil || !present {
+			return (*GlobalPos)(nil), err
+		}
+		dim, err := packet.ReadStringFromReader(r)
+		if err != nil {
+			return nil, err
+		}
+		pos, err := readBlockPos(r)
+		return &GlobalPos{Dimension: dim, Pos: pos}, err
+	case MetaPaintingVariant:
+		return readPaintingVariant(r)
+	case MetaResolvableProfile:
+		return nil, packet.SkipComponent(r, component.Profile)
+	default:
+		return nil, fmt.Errorf("未知实体数据类型: %d", t)
+	}
+}
+
+func readVarLong(r *bytes.Reader) (int64, error) {
+	var result uint64
+	for shift := uint(0); shift < 70; shift += 7 {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		result |= uint64(b&0x7F) << shift
+		if b&0x80 == 0 {
+			return int64(result), nil
+		}
+	}
+	return 0, fmt.Errorf("VarLong 过长")
+}
+
+func readFloats(r *bytes.Reader, out []float32) error {
+	for i := range out {
+		v, err := packet.ReadFloat32FromReader(r)
+		if err != nil {
+			return err
+		}
+		out[i] = v
+	}
+	return nil
+}
+
+// readBlockPos 解析打包的坐标: x 26 位, z 26 位, y 12 位
+func readBlockPos(r *bytes.Reader) (BlockPos, error) {
+	packed, err := packet.ReadInt64(r)
+	if err != nil {
+		return BlockPos{}, err
+	}
+	return BlockPos{
+		X: int32(packed >> 38),
+		Y: int32(packed << 52 >> 52),
+		Z: int32(packed << 26 >> 38),
+	}, nil
+}
+
+func readText(r *bytes.Reader) (string, error) {
+	raw, err := packet.ReadAnonymousNBTJSON(r)
+	if err != nil {
+		return "", err
+	}
+	return chat.ExtractPlainTextFromChatJSON(raw), nil
+}
+
+// readPaintingVariant 读取 Holder<PaintingVariant>: 0 表示内联定义，返回 -1
+func readPaintingVariant(r *bytes.Reader) (int32, error) {
+	id, err := packet.ReadVarIntFromReader(r)
+	if err != nil || id != 0 {
+		return id - 1, err
+	}
+	for i := 0; i < 2; i++ { // width, height
+		if _, err := packet.ReadVarIntFromReader(r); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := packet.ReadStringFromReader(r); err != nil { // asset_id
+		return 0, err
+	}
+	for i := 0; i < 2; i++ { // title, author
+		present, err := packet.ReadBoolFromReader(r)
+		if err != nil {
+			return 0, err
+		}
+		if present {
+			if err := packet.SkipNBT(r); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return -1, nil
+}
+
+// readParticle 读取粒子类型并跳过其附加数据
+func readParticle(r *bytes.Reader) (Particle, error) {
+	id, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return Particle{}, err
+	}
+	p := Particle{ID: id, Name: registry.StaticEntryName("minecraft:particle_type", id)}
+	if p.Name == "" {
+		return p, fmt.Errorf("未知粒子类型: %d", id)
+	}
+
+	switch p.Name {
+	case "minecraft:block", "minecraft:block_marker", "minecraft:falling_dust",
+		"minecraft:dust_pillar", "minecraft:block_crumble", "minecraft:shriek":
+		_, err = packet.ReadVarIntFromReader(r)
+	case "minecraft:dust":
+		err = packet.DiscardN(r, 4+4) // 颜色, 大小
+	case "minecraft:dust_color_transition":
+		err = packet.DiscardN(r, 4+4+4)
+	case "minecraft:entity_effect", "minecraft:tinted_leaves", "minecraft:flash":
+		err = packet.DiscardN(r, 4)
+	case "minecraft:effect", "minecraft:instant_effect":
+		err = packet.DiscardN(r, 4+4) // 颜色, 强度
+	case "minecraft:dragon_breath", "minecraft:sculk_charge":
+		err = packet.DiscardN(r, 4)
+	case "minecraft:item":
+		_, err = item.ReadItemStack(r)
+	case "minecraft:vibration":
+		err = skipPositionSource(r)
+		if err == nil {
+			_, err = packet.ReadVarIntFromReader(r)
+		}
+	case "minecraft:trail":
+		if err = packet.DiscardN(r, 8*3+4); err == nil { // 目标坐标, 颜色
+			_, err = packet.ReadVarIntFromReader(r)
+		}
+	}
+	return p, err
+}
+
+// skipPositionSource 跳过振动粒子的目标: 0 方块坐标, 1 实体 (ID + Y 偏移)
+func skipPositionSource(r *bytes.Reader) error {
+	t, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return err
+	}
+	switch t {
+	case 0:
+		return packet.DiscardN(r, 8)
+	case 1:
+		if _, err := packet.ReadVarIntFromReader(r); err != nil {
+			return err
+		}
+		return packet.DiscardN(r, 4)
+	default:
+		return fmt.Errorf("未知振动目标类型: %d", t)
+	}
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"bytes"
+	"testing"
+
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/registry"
+)
+
+func TestReadMetadata(t *testing.T) {
+	var data []byte
+	// 0: 标志位 着火 + 隐身
+	data = append(data, 0, byte(MetaByte), byte(FlagOnFire|FlagInvisible))
+	// 2: 自定义名称 (网络 NBT 字符串)
+	data = append(data, 2, byte(MetaOptionalText), 1, 0x08, 0x00, 0x03, 'B', 'o', 'b')
+	// 6: 姿态 潜行
+	data = append(data, 6, byte(MetaPose), byte(PoseCrouching))
+	// 8: 物品 (1 个 ID=5 的物品，无组件)
+	data = append(data, 8, byte(MetaSlot), 1, 5, 0, 0)
+	// 9: 生命值
+	data = append(data, 9, byte(MetaFloat))
+	data = append(data, packet.EncodeFloat32(12.5)...)
+	// 10: 药水粒子 (1 个 entity_effect，附带颜色)
+	data = append(data, 10, byte(MetaParticles), 1)
+	data = append(data, packet.EncodeVarInt(21)...)
+	data = append(data, packet.EncodeInt32(-1)...)
+	// 11: 可选 VarInt 空
+	data = append(data, 11, byte(MetaOptionalVarInt), 0)
+	data = append(data, metadataEnd)
+
+	r := bytes.NewReader(data)
+	entries, err := ReadMetadata(r)
+	if err != nil {
+		t.Fatalf("ReadMetadata() error = %v", err)
+	}
+	if r.Len() != 0 {
+		t.Errorf("剩余 %d 字节未读取", r.Len())
+	}
+	if len(entries) != 7 {
+		t.Fatalf("len(entries) = %d, want 7", len(entries))
+	}
+	if p := entries[5].Value.([]Particle); len(p) != 1 || p[0].Name != "minecraft:entity_effect" {
+		t.Errorf("particles = %v", p)
+	}
+	if v := entries[6].Value.(*int32); v != nil {
+		t.Errorf("空的可选 VarInt = %v, want nil", *v)
+	}
+
+	e := &Entity{Info: registry.GetEntityRegistry().GetByName("minecraft:zombie")}
+	e.ApplyMetadata(entries)
+	if !e.IsOnFire() || !e.IsInvisible() || e.IsSneaking() {
+		t.Errorf("Flags = %#x", e.Flags)
+	}
+	if e.CustomName != "Bob" || e.Pose != PoseCrouching || e.Health != 12.5 {
+		t.Errorf("CustomName=%q Pose=%v Health=%v", e.CustomName, e.Pose, e.Health)
+	}
+	if e.Item == nil || e.Item.ID != 5 || e.Item.Count != 1 {
+		t.Errorf("Item = %v", e.Item)
+	}
+
+	// 交互实体的索引 9 是宽度，不是生命值
+	interaction := &Entity{Info: registry.GetEntityRegistry().GetByName("minecraft:interaction")}
+	interaction.ApplyMetadata([]MetadataEntry{{Index: metaIndexHealth, Type: MetaFloat, Value: float32(0)}})
+	if interaction.IsDead() || interaction.Health != 0 {
+		t.Errorf("交互实体 IsDead=%v Health=%v", interaction.IsDead(), interaction.Health)
+	}
+	villager := &Entity{Info: registry.GetEntityRegistry().GetByName("minecraft:villager")}
+	villager.ApplyMetadata([]MetadataEntry{{Index: metaIndexHealth, Type: MetaFloat, Value: float32(0)}})
+	if !villager.IsDead() {
+		t.Error("生命值为 0 的村民应已死亡")
+	}
+}
+
+func TestReadMetadata_Errors(t *testing.T) {
+	// 未知粒子类型无法确定数据长度，应返回已读取的条目
+	data := []byte{0, byte(MetaByte), 1, 10, byte(MetaParticle)}
+	data = append(data, packet.EncodeVarInt(int32(1000))...)
+	entries, err := ReadMetadata(bytes.NewReader(data))
+	if err == nil {
+		t.Fatal("未知粒子应返回错误")
+	}
+	if len(entries) != 1 {
+		t.Errorf("len(entries) = %d, want 1", len(entries))
+	}
+	if registry.StaticEntryName("minecraft:particle_type", 1000) != "" {
+		t.Error("测试使用的粒子 ID 不应存在")
+	}
+
+	// 缺少结束标记
+	if _, err := ReadMetadata(bytes.NewReader([]byte{4, byte(MetaBoolean), 1})); err == nil {
+		t.Error("缺少结束标记应返回错误")
+	}
+}
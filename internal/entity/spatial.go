@@ -0,0 +1,313 @@
+package entity
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"gmcc/internal/registry"
+)
+
+// 空间索引按区块列 (16x16，不分高度) 划分实体，
+// 所有查询都在 Tracker 的读锁内完成，返回的是实体本身而非副本。
+
+const chunkSize = 16
+
+// maxEntityHalfWidth 最宽实体 (末影龙) 的半宽，按碰撞箱查询时扩展搜索范围
+const maxEntityHalfWidth = 8.0
+
+// chunkPos 区块列坐标
+type chunkPos struct {
+	x, z int32
+}
+
+func chunkCoord(v float64) int32 {
+	return int32(math.Floor(v)) >> 4
+}
+
+func chunkOf(p Position) chunkPos {
+	return chunkPos{x: chunkCoord(p.X), z: chunkCoord(p.Z)}
+}
+
+// AABB 轴对齐包围盒
+type AABB struct {
+	Min, Max Position
+}
+
+// Contains 检查位置是否在包围盒内 (含边界)
+func (b AABB) Contains(p Position) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// Intersects 检查两个包围盒是否相交
+func (b AABB) Intersects(o AABB) bool {
+	return b.Min.X <= o.Max.X && b.Max.X >= o.Min.X &&
+		b.Min.Y <= o.Max.Y && b.Max.Y >= o.Min.Y &&
+		b.Min.Z <= o.Max.Z && b.Max.Z >= o.Min.Z
+}
+
+// DistanceSqTo 返回包围盒上离 p 最近的点到 p 的距离平方，p 在盒内时为 0
+func (b AABB) DistanceSqTo(p Position) float64 {
+	dx := math.Max(0, math.Max(b.Min.X-p.X, p.X-b.Max.X))
+	dy := math.Max(0, math.Max(b.Min.Y-p.Y, p.Y-b.Max.Y))
+	dz := math.Max(0, math.Max(b.Min.Z-p.Z, p.Z-b.Max.Z))
+	return dx*dx + dy*dy + dz*dz
+}
+
+// Center 返回包围盒中心
+func (b AABB) Center() Position {
+	return Position{X: (b.Min.X + b.Max.X) / 2, Y: (b.Min.Y + b.Max.Y) / 2, Z: (b.Min.Z + b.Max.Z) / 2}
+}
+
+// Grow 向各方向扩展 d 格
+func (b AABB) Grow(d float64) AABB {
+	return AABB{
+		Min: Position{X: b.Min.X - d, Y: b.Min.Y - d, Z: b.Min.Z - d},
+		Max: Position{X: b.Max.X + d, Y: b.Max.Y + d, Z: b.Max.Z + d},
+	}
+}
+
+// intersectRay 返回射线 origin + dir*t 进入包围盒时的 t，未命中返回 false。
+// dir 不要求是单位向量，t 以 dir 的长度为单位。
+func (b AABB) intersectRay(origin Position, dir Vector3, maxT float64) (float64, bool) {
+	tMin, tMax := 0.0, maxT
+	axes := [3][4]float64{
+		{origin.X, dir.X, b.Min.X, b.Max.X},
+		{origin.Y, dir.Y, b.Min.Y, b.Max.Y},
+		{origin.Z, dir.Z, b.Min.Z, b.Max.Z},
+	}
+	for _, a := range axes {
+		o, d, lo, hi := a[0], a[1], a[2], a[3]
+		if d == 0 {
+			if o < lo || o > hi {
+				return 0, false
+			}
+			continue
+		}
+		t1, t2 := (lo-o)/d, (hi-o)/d
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		tMin = math.Max(tMin, t1)
+		tMax = math.Min(tMax, t2)
+		if tMin > tMax {
+			return 0, false
+		}
+	}
+	return tMin, true
+}
+
+// Filter 筛选实体，nil 表示不筛选
+type Filter func(e *Entity) bool
+
+// OfType 按类型筛选，接受 zombie 或 minecraft:zombie
+func OfType(entityType string) Filter {
+	if !strings.Contains(entityType, ":") {
+		entityType = "minecraft:" + entityType
+	}
+	return func(e *Entity) bool { return e.Type == entityType }
+}
+
+// OfCategory 按刷怪分类筛选
+func OfCategory(category registry.EntityCategory) Filter {
+	return func(e *Entity) bool { return e.Category() == category }
+}
+
+// Players 只保留玩家实体
+func Players(e *Entity) bool { return e.IsPlayer() }
+
+func (f Filter) match(e *Entity) bool {
+	return f == nil || f(e)
+}
+
+// RayHit 射线命中的实体
+type RayHit struct {
+	Entity   *Entity
+	Distance float64 // 从起点到碰撞箱的实际距离
+}
+
+// indexAdd 将实体加入空间索引（需要在外部加锁）
+func (t *Tracker) indexAdd(e *Entity) {
+	c := chunkOf(e.Position)
+	cell, ok := t.grid[c]
+	if !ok {
+		cell = make(map[int32]*Entity)
+		t.grid[c] = cell
+	}
+	cell[e.ID] = e
+}
+
+// indexRemove 将实体从 pos 所在的格子移除（需要在外部加锁）
+func (t *Tracker) indexRemove(e *Entity, pos Position) {
+	c := chunkOf(pos)
+	if cell, ok := t.grid[c]; ok {
+		delete(cell, e.ID)
+		if len(cell) == 0 {
+			delete(t.grid, c)
+		}
+	}
+}
+
+// indexMove 实体跨区块时更新索引（需要在外部加锁）
+func (t *Tracker) indexMove(e *Entity, oldPos Position) {
+	if chunkOf(oldPos) == chunkOf(e.Position) {
+		return
+	}
+	t.indexRemove(e, oldPos)
+	t.indexAdd(e)
+}
+
+// forEachCellIn 遍历与包围盒水平投影重叠的格子。
+// 范围内的格子比已占用的格子多时直接遍历已占用的格子。
+func (t *Tracker) forEachCellIn(box AABB, fn func(cell map[int32]*Entity)) {
+	minX, maxX := chunkCoord(box.Min.X), chunkCoord(box.Max.X)
+	minZ, maxZ := chunkCoord(box.Min.Z), chunkCoord(box.Max.Z)
+
+	span := (int64(maxX) - int64(minX) + 1) * (int64(maxZ) - int64(minZ) + 1)
+	if span > int64(len(t.grid)) {
+		for c, cell := range t.grid {
+			if c.x >= minX && c.x <= maxX && c.z >= minZ && c.z <= maxZ {
+				fn(cell)
+			}
+		}
+		return
+	}
+
+	for x := minX; x <= maxX; x++ {
+		for z := minZ; z <= maxZ; z++ {
+			if cell, ok := t.grid[chunkPos{x: x, z: z}]; ok {
+				fn(cell)
+			}
+		}
+	}
+}
+
+// WithinRadius 返回与 center 实际距离不超过 radius 的实体
+func (t *Tracker) WithinRadius(center Position, radius float64, filter Filter) []*Entity {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	radiusSq := radius * radius
+	box := AABB{Min: center, Max: center}.Grow(radius)
+	result := make([]*Entity, 0)
+	t.forEachCellIn(box, func(cell map[int32]*Entity) {
+		for _, e := range cell {
+			if e.Position.DistanceSqTo(center) <= radiusSq && filter.match(e) {
+				result = append(result, e)
+			}
+		}
+	})
+	return result
+}
+
+// WithinBox 返回位置 (脚底坐标) 在包围盒内的实体
+func (t *Tracker) WithinBox(box AABB, filter Filter) []*Entity {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	result := make([]*Entity, 0)
+	t.forEachCellIn(box, func(cell map[int32]*Entity) {
+		for _, e := range cell {
+			if box.Contains(e.Position) && filter.match(e) {
+				result = append(result, e)
+			}
+		}
+	})
+	return result
+}
+
+// Nearest 返回离 center 最近的实体，从 center 所在区块向外逐圈搜索
+func (t *Tracker) Nearest(center Position, filter Filter) (*Entity, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var best *Entity
+	bestSq := math.Inf(1)
+	check := func(cell map[int32]*Entity) {
+		for _, e := range cell {
+			if d := e.Position.DistanceSqTo(center); d < bestSq && filter.match(e) {
+				best, bestSq = e, d
+			}
+		}
+	}
+
+	c := chunkOf(center)
+	visited := 0
+	for ring := int32(0); visited < len(t.grid); ring++ {
+		// 第 ring 圈的格子与 center 的水平距离至少为 (ring-1) 个区块
+		if best != nil && ring > 0 {
+			bound := float64(ring-1) * chunkSize
+			if bound*bound > bestSq {
+				break
+			}
+		}
+		// 圈比已占用的格子还多时，直接遍历全部格子
+		if int(8*ring) > len(t.grid) {
+			for _, cell := range t.grid {
+				check(cell)
+			}
+			break
+		}
+		forEachRingCell(c, ring, func(p chunkPos) {
+			if cell, ok := t.grid[p]; ok {
+				visited++
+				check(cell)
+			}
+		})
+	}
+	return best, best != nil
+}
+
+// forEachRingCell 遍历以 c 为中心、切比雪夫距离为 ring 的一圈区块
+func forEachRingCell(c chunkPos, ring int32, fn func(p chunkPos)) {
+	if ring == 0 {
+		fn(c)
+		return
+	}
+	for x := c.x - ring; x <= c.x+ring; x++ {
+		fn(chunkPos{x: x, z: c.z - ring})
+		fn(chunkPos{x: x, z: c.z + ring})
+	}
+	for z := c.z - ring + 1; z <= c.z+ring-1; z++ {
+		fn(chunkPos{x: c.x - ring, z: z})
+		fn(chunkPos{x: c.x + ring, z: z})
+	}
+}
+
+// AlongRay 返回碰撞箱与线段 origin -> origin+dir*maxDist 相交的实体，按距离由近到远排序。
+// 只检查实体，不考虑方块遮挡，用于视线判断的候选。
+func (t *Tracker) AlongRay(origin Position, dir Vector3, maxDist float64, filter Filter) []RayHit {
+	length := math.Sqrt(dir.X*dir.X + dir.Y*dir.Y + dir.Z*dir.Z)
+	if length == 0 || maxDist <= 0 {
+		return nil
+	}
+	unit := Vector3{X: dir.X / length, Y: dir.Y / length, Z: dir.Z / length}
+	end := Position{
+		X: origin.X + unit.X*maxDist,
+		Y: origin.Y + unit.Y*maxDist,
+		Z: origin.Z + unit.Z*maxDist,
+	}
+	segment := AABB{
+		Min: Position{X: math.Min(origin.X, end.X), Y: math.Min(origin.Y, end.Y), Z: math.Min(origin.Z, end.Z)},
+		Max: Position{X: math.Max(origin.X, end.X), Y: math.Max(origin.Y, end.Y), Z: math.Max(origin.Z, end.Z)},
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	hits := make([]RayHit, 0)
+	t.forEachCellIn(segment.Grow(maxEntityHalfWidth), func(cell map[int32]*Entity) {
+		for _, e := range cell {
+			if !filter.match(e) {
+				continue
+			}
+			if d, ok := e.BoundingBox().intersectRay(origin, unit, maxDist); ok {
+				hits = append(hits, RayHit{Entity: e, Distance: d})
+			}
+		}
+	})
+	sort.Slice(hits, func(i, j int) bool { return hits[i].Distance < hits[j].Distance })
+	return hits
+}
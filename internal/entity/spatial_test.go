@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// newRandomTracker 在 size x size 的范围内随机生成 n 个实体，奇数 ID 为僵尸
+func newRandomTracker(n int, size float64, seed int64) *Tracker {
+	rng := rand.New(rand.NewSource(seed))
+	tracker := NewTracker()
+	for i := 0; i < n; i++ {
+		typ := "minecraft:cow"
+		if i%2 == 1 {
+			typ = "minecraft:zombie"
+		}
+		pos := Position{X: rng.Float64()*size - size/2, Y: 64 + rng.Float64()*8, Z: rng.Float64()*size - size/2}
+		tracker.SpawnEntity(int32(i), typ, [16]byte{byte(i), byte(i >> 8)}, pos, Vector3{})
+	}
+	return tracker
+}
+
+func TestPosition_Distance(t *testing.T) {
+	a, b := Position{}, Position{X: 3, Y: 4}
+	if a.DistanceSqTo(b) != 25 || a.DistanceTo(b) != 5 {
+		t.Errorf("DistanceSqTo=%v DistanceTo=%v, want 25 5", a.DistanceSqTo(b), a.DistanceTo(b))
+	}
+}
+
+func TestTracker_WithinRadius(t *testing.T) {
+	tracker := newRandomTracker(2000, 400, 1)
+	defer tracker.Stop()
+
+	center := Position{X: 10, Y: 66, Z: -20}
+	for _, radius := range []float64{0.5, 8, 37, 500} {
+		got := tracker.WithinRadius(center, radius, OfType("zombie"))
+		want := 0
+		for _, e := range tracker.All() {
+			if e.Type == "minecraft:zombie" && e.DistanceTo(center) <= radius {
+				want++
+			}
+		}
+		if len(got) != want {
+			t.Errorf("WithinRadius(%v) = %d, want %d", radius, len(got), want)
+		}
+	}
+}
+
+func TestTracker_WithinBox(t *testing.T) {
+	tracker := newRandomTracker(2000, 400, 2)
+	defer tracker.Stop()
+
+	box := AABB{Min: Position{X: -50, Y: 60, Z: 3}, Max: Position{X: 17, Y: 68, Z: 90}}
+	want := 0
+	for _, e := range tracker.All() {
+		if box.Contains(e.Position) {
+			want++
+		}
+	}
+	if got := tracker.WithinBox(box, nil); len(got) != want {
+		t.Errorf("WithinBox() = %d, want %d", len(got), want)
+	}
+}
+
+func TestTracker_Nearest(t *testing.T) {
+	tracker := newRandomTracker(500, 1000, 3)
+	defer tracker.Stop()
+
+	for _, center := range []Position{{}, {X: 480, Z: -480}, {X: 5000, Z: 5000}} {
+		var want *Entity
+		for _, e := range tracker.All() {
+			if e.Type == "minecraft:cow" && (want == nil || e.DistanceSqTo(center) < want.DistanceSqTo(center)) {
+				want = e
+			}
+		}
+		got, ok := tracker.Nearest(center, OfType("cow"))
+		if !ok || got != want {
+			t.Errorf("Nearest(%v) = %v, want %v", center, got, want)
+		}
+	}
+
+	if _, ok := NewTracker().Nearest(Position{}, nil); ok {
+		t.Error("空跟踪器不应找到实体")
+	}
+}
+
+func TestTracker_IndexFollowsMovement(t *testing.T) {
+	tracker := NewTracker()
+	defer tracker.Stop()
+	tracker.SpawnEntity(1, "minecraft:zombie", [16]byte{1}, Position{X: 1, Y: 64, Z: 1}, Vector3{})
+
+	tracker.UpdatePosition(1, Position{X: 100, Y: 64, Z: 100})
+	waitPosition(t, tracker, 1, Position{X: 100, Y: 64, Z: 100})
+
+	if got := tracker.WithinRadius(Position{X: 1, Y: 64, Z: 1}, 5, nil); len(got) != 0 {
+		t.Errorf("旧位置仍能查到实体: %v", got)
+	}
+	if got := tracker.WithinRadius(Position{X: 100, Y: 64, Z: 100}, 5, nil); len(got) != 1 {
+		t.Errorf("新位置查不到实体")
+	}
+
+	tracker.RemoveEntity(1)
+	if len(tracker.grid) != 0 || len(tracker.byType) != 0 {
+		t.Errorf("移除后索引未清空: grid=%d byType=%d", len(tracker.grid), len(tracker.byType))
+	}
+}
+
+func TestTracker_AlongRay(t *testing.T) {
+	tracker := NewTracker()
+	defer tracker.Stop()
+	// 僵尸碰撞箱 0.6 x 1.95
+	tracker.SpawnEntity(1, "minecraft:zombie", [16]byte{1}, Position{X: 0, Y: 64, Z: 10}, Vector3{})
+	tracker.SpawnEntity(2, "minecraft:zombie", [16]byte{2}, Position{X: 0, Y: 64, Z: 5}, Vector3{})
+	tracker.SpawnEntity(3, "minecraft:zombie", [16]byte{3}, Position{X: 3, Y: 64, Z: 5}, Vector3{})
+
+	hits := tracker.AlongRay(Position{X: 0, Y: 65, Z: 0}, Vector3{Z: 2}, 20, nil)
+	if len(hits) != 2 || hits[0].Entity.ID != 2 || hits[1].Entity.ID != 1 {
+		t.Fatalf("AlongRay() = %+v", hits)
+	}
+	if math.Abs(hits[0].Distance-4.7) > 1e-9 {
+		t.Errorf("Distance = %v, want 4.7", hits[0].Distance)
+	}
+	if hits := tracker.AlongRay(Position{X: 0, Y: 65, Z: 0}, Vector3{Z: 1}, 4, nil); len(hits) != 0 {
+		t.Errorf("超出最大距离仍命中: %+v", hits)
+	}
+}
+
+func BenchmarkWithinRadius(b *testing.B) {
+	tracker := newRandomTracker(5000, 256, 1)
+	defer tracker.Stop()
+	center := Position{Y: 64}
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tracker.WithinRadius(center, 16, nil)
+		}
+	})
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			n := 0
+			for _, e := range tracker.All() {
+				if e.DistanceSqTo(center) <= 16*16 {
+					n++
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkNearest(b *testing.B) {
+	tracker := newRandomTracker(5000, 256, 1)
+	defer tracker.Stop()
+	center := Position{Y: 64}
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tracker.Nearest(center, nil)
+		}
+	})
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var best *Entity
+			for _, e := range tracker.All() {
+				if best == nil || e.DistanceSqTo(center) < best.DistanceSqTo(center) {
+					best = e
+				}
+			}
+		}
+	})
+}
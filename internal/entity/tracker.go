@@ -2,8 +2,11 @@
 package entity
 
 import (
+	"strings"
 	"sync"
 	"time"
+
+	"gmcc/internal/registry"
 )
 
 // Callbacks 定义实体事件回调
@@ -18,7 +21,10 @@ type Tracker struct {
 	mu             sync.RWMutex
 	entities       map[int32]*Entity
 	byUUID         map[[16]byte]*Entity
+	byType         map[string]map[int32]*Entity
+	grid           map[chunkPos]map[int32]*Entity // 空间索引，见 spatial.go
 	pendingUpdates map[int32]*pendingUpdate
+	vehicles       map[int32]int32 // 乘客 -> 载具，乘客可能未被跟踪 (如自身)
 	callbacks      Callbacks
 }
 
@@ -34,7 +40,10 @@ func NewTracker() *Tracker {
 	return &Tracker{
 		entities:       make(map[int32]*Entity),
 		byUUID:         make(map[[16]byte]*Entity),
+		byType:         make(map[string]map[int32]*Entity),
+		grid:           make(map[chunkPos]map[int32]*Entity),
 		pendingUpdates: make(map[int32]*pendingUpdate),
+		vehicles:       make(map[int32]int32),
 	}
 }
 
@@ -50,17 +59,32 @@ func (t *Tracker) SpawnEntity(id int32, entityType string, uuid [16]byte, pos Po
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	now := time.Now()
 	entity := &Entity{
 		ID:         id,
 		Type:       entityType,
+		Info:       registry.GetEntityRegistry().GetByName(entityType),
 		UUID:       uuid,
 		Position:   pos,
 		Velocity:   velocity,
-		LastUpdate: time.Now(),
+		LastUpdate: now,
+		Vehicle:    t.vehicles[id],
+		movedAt:    now,
 	}
 
+	// 同一 ID 重复生成时替换旧实体
+	if old, ok := t.entities[id]; ok {
+		t.unindex(old)
+	}
 	t.entities[id] = entity
 	t.byUUID[uuid] = entity
+	t.indexAdd(entity)
+	sameType, ok := t.byType[entityType]
+	if !ok {
+		sameType = make(map[int32]*Entity)
+		t.byType[entityType] = sameType
+	}
+	sameType[id] = entity
 
 	if t.callbacks.OnSpawn != nil {
 		// 复制数据后回调
@@ -71,36 +95,98 @@ func (t *Tracker) SpawnEntity(id int32, entityType string, uuid [16]byte, pos Po
 	return entity
 }
 
+// UpdateMetadata 更新实体数据，实体不存在时忽略
+func (t *Tracker) UpdateMetadata(id int32, entries []MetadataEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if e, ok := t.entities[id]; ok {
+		e.ApplyMetadata(entries)
+		e.LastUpdate = time.Now()
+	}
+}
+
 // UpdatePosition 更新实体位置（完整位置）
 func (t *Tracker) UpdatePosition(id int32, newPos Position) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	_, exists := t.entities[id]
-	if !exists {
+	if _, exists := t.entities[id]; !exists {
 		return
 	}
+	t.schedulePosition(id, newPos)
+}
 
-	// 检查是否已有待处理的更新
-	if pending, ok := t.pendingUpdates[id]; ok {
-		pending.newPos = newPos
+// UpdatePositionDelta 更新实体位置（增量）
+func (t *Tracker) UpdatePositionDelta(id int32, deltaX, deltaY, deltaZ int16, onGround bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e, exists := t.entities[id]
+	if !exists {
 		return
 	}
+	e.OnGround = onGround
 
-	// 创建延迟回调
-	timer := time.AfterFunc(100*time.Millisecond, func() {
-		t.executePositionUpdate(id, newPos)
+	// Delta 值需要除以 4096 转换为实际坐标
+	base := t.basePosition(e)
+	t.schedulePosition(id, Position{
+		X: base.X + float64(deltaX)/4096.0,
+		Y: base.Y + float64(deltaY)/4096.0,
+		Z: base.Z + float64(deltaZ)/4096.0,
 	})
+}
+
+// UpdateRotation 更新实体朝向
+func (t *Tracker) UpdateRotation(id int32, yaw, pitch float32, onGround bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
-	t.pendingUpdates[id] = &pendingUpdate{
-		entityID: id,
-		newPos:   newPos,
-		timer:    timer,
+	if e, ok := t.entities[id]; ok {
+		e.Yaw, e.Pitch = yaw, pitch
+		e.OnGround = onGround
+		e.LastUpdate = time.Now()
 	}
 }
 
-// UpdatePositionDelta 更新实体位置（增量）
-func (t *Tracker) UpdatePositionDelta(id int32, deltaX, deltaY, deltaZ int16) {
+// UpdateHeadYaw 更新实体头部朝向
+func (t *Tracker) UpdateHeadYaw(id int32, headYaw float32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if e, ok := t.entities[id]; ok {
+		e.HeadYaw = headYaw
+		e.LastUpdate = time.Now()
+	}
+}
+
+// UpdateVelocity 更新实体速度
+func (t *Tracker) UpdateVelocity(id int32, velocity Vector3) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if e, ok := t.entities[id]; ok {
+		e.Velocity = velocity
+		e.LastUpdate = time.Now()
+	}
+}
+
+// 传送的相对标志位，置位的分量与当前值相加
+const (
+	RelativeX int32 = 1 << iota
+	RelativeY
+	RelativeZ
+	RelativeYaw
+	RelativePitch
+	RelativeDeltaX
+	RelativeDeltaY
+	RelativeDeltaZ
+	RelativeRotateDelta
+)
+
+// Teleport 设置实体的位置、速度和朝向，relative 为 Relative* 标志位组合。
+// entity_position_sync 使用 relative=0 的绝对同步。
+func (t *Tracker) Teleport(id int32, pos Position, velocity Vector3, yaw, pitch float32, relative int32, onGround bool) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -109,46 +195,143 @@ func (t *Tracker) UpdatePositionDelta(id int32, deltaX, deltaY, deltaZ int16) {
 		return
 	}
 
-	// Delta 值需要除以 4096 转换为实际坐标
-	newPos := Position{
-		X: e.Position.X + float64(deltaX)/4096.0,
-		Y: e.Position.Y + float64(deltaY)/4096.0,
-		Z: e.Position.Z + float64(deltaZ)/4096.0,
+	base := t.basePosition(e)
+	if relative&RelativeX != 0 {
+		pos.X += base.X
+	}
+	if relative&RelativeY != 0 {
+		pos.Y += base.Y
+	}
+	if relative&RelativeZ != 0 {
+		pos.Z += base.Z
+	}
+	if relative&RelativeYaw != 0 {
+		yaw += e.Yaw
+	}
+	if relative&RelativePitch != 0 {
+		pitch += e.Pitch
+	}
+	if relative&RelativeDeltaX != 0 {
+		velocity.X += e.Velocity.X
+	}
+	if relative&RelativeDeltaY != 0 {
+		velocity.Y += e.Velocity.Y
 	}
+	if relative&RelativeDeltaZ != 0 {
+		velocity.Z += e.Velocity.Z
+	}
+
+	e.Velocity = velocity
+	e.Yaw, e.Pitch = yaw, pitch
+	e.OnGround = onGround
+	t.schedulePosition(id, pos)
+}
+
+// SetPassengers 设置载具的全部乘客，不在列表中的原乘客视为已下车
+func (t *Tracker) SetPassengers(vehicle int32, passengers []int32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
+	t.dismount(vehicle)
+	for _, p := range passengers {
+		// 乘客换乘时从原载具移除
+		if old, ok := t.vehicles[p]; ok && old != vehicle {
+			if v, ok := t.entities[old]; ok {
+				v.Passengers = removeID(v.Passengers, p)
+			}
+		}
+		t.vehicles[p] = vehicle
+		if e, ok := t.entities[p]; ok {
+			e.Vehicle = vehicle
+		}
+	}
+	if v, ok := t.entities[vehicle]; ok {
+		v.Passengers = append([]int32(nil), passengers...)
+		v.LastUpdate = time.Now()
+	}
+}
+
+// VehicleOf 返回实体正在骑乘的载具 ID，实体可以不在跟踪列表中
+func (t *Tracker) VehicleOf(id int32) (int32, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	v, ok := t.vehicles[id]
+	return v, ok
+}
+
+// dismount 清除载具的所有乘客（需要在外部加锁）
+func (t *Tracker) dismount(vehicle int32) {
+	for p, v := range t.vehicles {
+		if v != vehicle {
+			continue
+		}
+		delete(t.vehicles, p)
+		if e, ok := t.entities[p]; ok {
+			e.Vehicle = 0
+		}
+	}
+	if v, ok := t.entities[vehicle]; ok {
+		v.Passengers = nil
+	}
+}
+
+func removeID(ids []int32, id int32) []int32 {
+	out := ids[:0:0]
+	for _, v := range ids {
+		if v != id {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// basePosition 返回增量更新的基准位置，优先使用尚未生效的待处理位置（需要在外部加锁）
+func (t *Tracker) basePosition(e *Entity) Position {
+	if pending, ok := t.pendingUpdates[e.ID]; ok {
+		return pending.newPos
+	}
+	return e.Position
+}
+
+// schedulePosition 合并短时间内的位置更新，延迟后统一生效（需要在外部加锁）
+func (t *Tracker) schedulePosition(id int32, newPos Position) {
 	// 检查是否已有待处理的更新
 	if pending, ok := t.pendingUpdates[id]; ok {
 		pending.newPos = newPos
 		return
 	}
 
-	// 创建延迟回调
-	timer := time.AfterFunc(100*time.Millisecond, func() {
-		t.executePositionUpdate(id, newPos)
+	pending := &pendingUpdate{entityID: id, newPos: newPos}
+	// 创建延迟回调，执行时读取合并后的最新位置
+	pending.timer = time.AfterFunc(100*time.Millisecond, func() {
+		t.executePositionUpdate(pending)
 	})
-
-	t.pendingUpdates[id] = &pendingUpdate{
-		entityID: id,
-		newPos:   newPos,
-		timer:    timer,
-	}
+	t.pendingUpdates[id] = pending
 }
 
-// executePositionUpdate 执行位置更新（内部方法，需要在外部加锁）
-func (t *Tracker) executePositionUpdate(id int32, newPos Position) {
+// executePositionUpdate 执行位置更新（由定时器调用，内部加锁）
+func (t *Tracker) executePositionUpdate(pending *pendingUpdate) {
 	t.mu.Lock()
+	id := pending.entityID
+	if t.pendingUpdates[id] != pending {
+		// 已被移除或由 Stop 清理
+		t.mu.Unlock()
+		return
+	}
+	delete(t.pendingUpdates, id)
+
 	e, exists := t.entities[id]
 	if !exists {
-		// 实体已被移除，清理待处理更新
-		delete(t.pendingUpdates, id)
 		t.mu.Unlock()
 		return
 	}
 
+	now := time.Now()
 	oldPos := e.Position
-	e.Position = newPos
-	e.LastUpdate = time.Now()
-	delete(t.pendingUpdates, id)
+	e.Position = pending.newPos
+	e.LastUpdate = now
+	e.movedAt = now
+	t.indexMove(e, oldPos)
 
 	// 复制数据用于回调
 	entityCopy := *e
@@ -176,8 +359,17 @@ func (t *Tracker) RemoveEntity(id int32) {
 		delete(t.pendingUpdates, id)
 	}
 
+	// 解除骑乘关系
+	t.dismount(id)
+	if v, ok := t.vehicles[id]; ok {
+		delete(t.vehicles, id)
+		if vehicle, ok := t.entities[v]; ok {
+			vehicle.Passengers = removeID(vehicle.Passengers, id)
+		}
+	}
+
 	delete(t.entities, id)
-	delete(t.byUUID, e.UUID)
+	t.unindex(e)
 
 	if t.callbacks.OnRemove != nil {
 		// 复制数据后回调
@@ -186,6 +378,20 @@ func (t *Tracker) RemoveEntity(id int32) {
 	}
 }
 
+// unindex 从 UUID、类型和空间索引中移除实体（需要在外部加锁）
+func (t *Tracker) unindex(e *Entity) {
+	if t.byUUID[e.UUID] == e {
+		delete(t.byUUID, e.UUID)
+	}
+	if sameType, ok := t.byType[e.Type]; ok {
+		delete(sameType, e.ID)
+		if len(sameType) == 0 {
+			delete(t.byType, e.Type)
+		}
+	}
+	t.indexRemove(e, e.Position)
+}
+
 // RemoveEntities 批量移除实体
 func (t *Tracker) RemoveEntities(ids []int32) {
 	for _, id := range ids {
@@ -221,14 +427,31 @@ func (t *Tracker) All() []*Entity {
 	return result
 }
 
-// ByType 按类型筛选实体
+// ByType 按类型筛选实体，接受 zombie 或 minecraft:zombie
 func (t *Tracker) ByType(entityType string) []*Entity {
+	if !strings.Contains(entityType, ":") {
+		entityType = "minecraft:" + entityType
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	sameType := t.byType[entityType]
+	result := make([]*Entity, 0, len(sameType))
+	for _, e := range sameType {
+		result = append(result, e)
+	}
+	return result
+}
+
+// ByCategory 按刷怪分类筛选实体
+func (t *Tracker) ByCategory(category registry.EntityCategory) []*Entity {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
 	result := make([]*Entity, 0)
 	for _, e := range t.entities {
-		if e.Type == entityType {
+		if e.Category() == category {
 			result = append(result, e)
 		}
 	}
@@ -244,6 +467,11 @@ func (t *Tracker) Count() int {
 
 // Stop 停止跟踪器并清理资源
 func (t *Tracker) Stop() {
+	t.Clear()
+}
+
+// Clear 移除所有实体，不触发移除回调。用于切换世界时整体丢弃。
+func (t *Tracker) Clear() {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -254,4 +482,7 @@ func (t *Tracker) Stop() {
 	t.pendingUpdates = make(map[int32]*pendingUpdate)
 	t.entities = make(map[int32]*Entity)
 	t.byUUID = make(map[[16]byte]*Entity)
+	t.byType = make(map[string]map[int32]*Entity)
+	t.grid = make(map[chunkPos]map[int32]*Entity)
+	t.vehicles = make(map[int32]int32)
 }
@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"gmcc/internal/registry"
+)
+
+func TestTracker_ByTypeAndCategory(t *testing.T) {
+	tracker := NewTracker()
+	defer tracker.Stop()
+
+	tracker.SpawnEntity(1, "minecraft:zombie", [16]byte{1}, Position{}, Vector3{})
+	tracker.SpawnEntity(2, "minecraft:zombie", [16]byte{2}, Position{}, Vector3{})
+	tracker.SpawnEntity(3, "minecraft:cow", [16]byte{3}, Position{}, Vector3{})
+	tracker.SpawnEntity(4, "minecraft:player", [16]byte{4}, Position{}, Vector3{})
+
+	if got := len(tracker.ByType("zombie")); got != 2 {
+		t.Errorf("ByType(zombie) = %d, want 2", got)
+	}
+	if got := len(tracker.ByType("minecraft:cow")); got != 1 {
+		t.Errorf("ByType(minecraft:cow) = %d, want 1", got)
+	}
+	if got := len(tracker.ByCategory(registry.CategoryMonster)); got != 2 {
+		t.Errorf("ByCategory(monster) = %d, want 2", got)
+	}
+	if got := len(tracker.ByCategory(registry.CategoryCreature)); got != 1 {
+		t.Errorf("ByCategory(creature) = %d, want 1", got)
+	}
+
+	p, ok := tracker.Get(4)
+	if !ok || !p.IsPlayer() || p.Info == nil || p.Info.Height != 1.8 {
+		t.Errorf("player = %+v", p)
+	}
+}
+
+// waitPosition 等待延迟的位置更新生效
+func waitPosition(t *testing.T, tracker *Tracker, id int32, want Position) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		tracker.mu.RLock()
+		got := tracker.entities[id].Position
+		tracker.mu.RUnlock()
+		if got == want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("实体 %d 位置未更新为 %v", id, want)
+}
+
+func TestTracker_Movement(t *testing.T) {
+	tracker := NewTracker()
+	defer tracker.Stop()
+	tracker.SpawnEntity(1, "minecraft:zombie", [16]byte{1}, Position{X: 10, Y: 64, Z: 10}, Vector3{})
+
+	// 合并前的多次增量需要累加
+	tracker.UpdatePositionDelta(1, 4096, 0, 0, false)
+	tracker.UpdatePositionDelta(1, 4096, 0, -8192, true)
+	waitPosition(t, tracker, 1, Position{X: 12, Y: 64, Z: 8})
+
+	tracker.UpdateRotation(1, 90, -45, true)
+	tracker.Teleport(1, Position{X: 1, Y: 0, Z: 0}, Vector3{Y: 0.5}, 10, 5, RelativeX|RelativeY|RelativeZ|RelativeYaw, false)
+	waitPosition(t, tracker, 1, Position{X: 13, Y: 64, Z: 8})
+
+	e, _ := tracker.Get(1)
+	tracker.mu.RLock()
+	defer tracker.mu.RUnlock()
+	if e.Yaw != 100 || e.Pitch != 5 || e.OnGround || e.Velocity.Y != 0.5 {
+		t.Errorf("Yaw=%v Pitch=%v OnGround=%v Velocity=%v", e.Yaw, e.Pitch, e.OnGround, e.Velocity)
+	}
+}
+
+func TestEntity_PredictPosition(t *testing.T) {
+	now := time.Now()
+	e := &Entity{Position: Position{Y: 64}, Velocity: Vector3{X: 0.5}, movedAt: now}
+
+	if got := e.PredictPosition(now.Add(10 * tickDuration)); got != (Position{X: 5, Y: 64}) {
+		t.Errorf("PredictPosition(+10 tick) = %v", got)
+	}
+	// 外推不超过 maxExtrapolation
+	if got := e.PredictPosition(now.Add(time.Hour)); got != (Position{X: 10, Y: 64}) {
+		t.Errorf("PredictPosition(+1h) = %v", got)
+	}
+	if got := e.PredictPosition(now.Add(-time.Second)); got != e.Position {
+		t.Errorf("PredictPosition(过去) = %v", got)
+	}
+}
+
+func TestTracker_Passengers(t *testing.T) {
+	tracker := NewTracker()
+	defer tracker.Stop()
+	tracker.SpawnEntity(1, "minecraft:horse", [16]byte{1}, Position{}, Vector3{})
+	tracker.SpawnEntity(2, "minecraft:boat", [16]byte{2}, Position{}, Vector3{})
+
+	// 99 为自身，不在跟踪列表中
+	tracker.SetPassengers(1, []int32{99})
+	if v, ok := tracker.VehicleOf(99); !ok || v != 1 {
+		t.Errorf("VehicleOf(99) = %d, %v, want 1", v, ok)
+	}
+
+	// 换乘到船上
+	tracker.SetPassengers(2, []int32{99})
+	horse, _ := tracker.Get(1)
+	boat, _ := tracker.Get(2)
+	if len(horse.Passengers) != 0 || len(boat.Passengers) != 1 {
+		t.Errorf("horse=%v boat=%v", horse.Passengers, boat.Passengers)
+	}
+
+	// 载具移除后自动下车
+	tracker.RemoveEntity(2)
+	if _, ok := tracker.VehicleOf(99); ok {
+		t.Error("载具移除后仍处于骑乘状态")
+	}
+
+	tracker.SpawnEntity(3, "minecraft:pig", [16]byte{3}, Position{}, Vector3{})
+	tracker.SetPassengers(1, []int32{3})
+	if pig, _ := tracker.Get(3); !pig.IsRiding() || pig.Vehicle != 1 {
+		t.Errorf("pig.Vehicle = %d", pig.Vehicle)
+	}
+	tracker.SetPassengers(1, nil)
+	if pig, _ := tracker.Get(3); pig.IsRiding() {
+		t.Error("清空乘客后仍处于骑乘状态")
+	}
+}
+
+func TestEntityRegistry(t *testing.T) {
+	reg := registry.GetEntityRegistry()
+	info := reg.GetByName("minecraft:zombie")
+	if info == nil {
+		t.Fatal("GetByName(minecraft:zombie) = nil")
+	}
+	if got := reg.GetByID(info.ID); got != info {
+		t.Errorf("GetByID(%d) = %v, want zombie", info.ID, got)
+	}
+	if info.TranslationKey() != "entity.minecraft.zombie" || info.Category != registry.CategoryMonster {
+		t.Errorf("zombie = %+v", info)
+	}
+	if got := registry.EntryName("minecraft:entity_type", info.ID); got != "minecraft:zombie" {
+		t.Errorf("EntryName() = %q", got)
+	}
+}
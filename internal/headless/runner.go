@@ -11,6 +11,7 @@ import (
 	"gmcc/internal/logx"
 	"gmcc/internal/mcclient"
 	"gmcc/internal/mcclient/chat"
+	"gmcc/internal/player"
 )
 
 // Runner 无界面运行器
@@ -50,6 +51,39 @@ func (r *Runner) Run(ctx context.Context) error {
 			logx.Infof("[聊天] %s", text)
 		}
 	})
+	r.client.SetTitleHandler(func(msg mcclient.TitleMessage) {
+		switch msg.Type {
+		case "title":
+			logx.Infof("[标题] %s", msg.PlainText)
+		case "subtitle":
+			logx.Infof("[副标题] %s", msg.PlainText)
+		}
+	})
+	r.client.BossBars.SetCallbacks(player.BossBarCallbacks{
+		OnAdd: func(bar player.BossBar) {
+			logx.Infof("[Boss栏] %s (%.0f%%)", chat.ExtractPlainTextFromChatJSON(bar.Title), bar.Progress*100)
+		},
+		OnUpdate: func(bar player.BossBar) {
+			logx.Debugf("[Boss栏] %s (%.0f%%)", chat.ExtractPlainTextFromChatJSON(bar.Title), bar.Progress*100)
+		},
+		OnRemove: func(bar player.BossBar) {
+			logx.Infof("[Boss栏] 移除 %s", chat.ExtractPlainTextFromChatJSON(bar.Title))
+		},
+	})
+	r.client.SetDialogHandler(func(e mcclient.DialogEvent) {
+		if e.Dialog == nil {
+			logx.Infof("[对话框] 已关闭")
+			return
+		}
+		for _, line := range e.Dialog.Lines() {
+			logx.Infof("%s", line)
+		}
+	})
+	r.client.SetMerchantHandler(func(e mcclient.MerchantEvent) {
+		for i := range e.Merchant.Offers {
+			logx.Infof("[交易] <%d> %s", i+1, &e.Merchant.Offers[i])
+		}
+	})
 
 	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
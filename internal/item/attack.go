@@ -0,0 +1,102 @@
+package item
+
+import (
+	"slices"
+	"strings"
+
+	"gmcc/internal/item/component"
+	"gmcc/internal/registry"
+)
+
+// BaseAttackSpeed 玩家攻击速度属性的基础值 (每秒可满蓄力攻击次数)
+const BaseAttackSpeed = 4.0
+
+// 各类武器工具的默认攻击速度，来自原版物品的 attribute_modifiers 默认组件。
+// 默认组件不随网络下发，只能在此维护。
+var (
+	attackSpeedBySuffix = map[string]float64{
+		"_sword":   1.6,
+		"_pickaxe": 1.2,
+		"_shovel":  1.0,
+	}
+	attackSpeedByName = map[string]float64{
+		"wooden_axe": 0.8, "stone_axe": 0.8, "copper_axe": 0.8, "iron_axe": 0.9,
+		"golden_axe": 1.0, "diamond_axe": 1.0, "netherite_axe": 1.0,
+		"wooden_hoe": 1.0, "golden_hoe": 1.0, "stone_hoe": 2.0, "copper_hoe": 2.0,
+		"iron_hoe": 3.0, "diamond_hoe": 4.0, "netherite_hoe": 4.0,
+		"trident": 1.1,
+		"mace":    0.6,
+	}
+)
+
+// AttackSpeed 返回手持该物品时的攻击速度，nil 表示空手
+func (s *ItemStack) AttackSpeed() float64 {
+	if s == nil {
+		return BaseAttackSpeed
+	}
+	if slices.Contains(s.Removed, component.AttributeModifiers) {
+		return BaseAttackSpeed
+	}
+	if c, ok := s.Components[component.AttributeModifiers]; ok {
+		if speed, ok := attackSpeedFromModifiers(c.Raw); ok {
+			return speed
+		}
+	}
+
+	info := registry.GetItemRegistry().GetByID(s.ID)
+	if info == nil {
+		return BaseAttackSpeed
+	}
+	if speed, ok := attackSpeedByName[info.Name]; ok {
+		return speed
+	}
+	for suffix, speed := range attackSpeedBySuffix {
+		if strings.HasSuffix(info.Name, suffix) {
+			return speed
+		}
+	}
+	return BaseAttackSpeed
+}
+
+// AttackCooldownTicks 返回满蓄力攻击所需的游戏刻数
+func (s *ItemStack) AttackCooldownTicks() float64 {
+	speed := s.AttackSpeed()
+	if speed <= 0 {
+		return 0
+	}
+	return 20 / speed
+}
+
+// attackSpeedFromModifiers 从覆盖的 attribute_modifiers 组件计算主手攻击速度
+func attackSpeedFromModifiers(raw []byte) (float64, bool) {
+	v, err := component.DecodeComponent(component.AttributeModifiers, raw)
+	if err != nil {
+		return 0, false
+	}
+	list, _ := v.([]any)
+
+	var add, multiplyBase float64
+	multiplyTotal := 1.0
+	for _, entry := range list {
+		m, ok := entry.(map[string]any)
+		if !ok || m["type"] != "minecraft:attack_speed" {
+			continue
+		}
+		// 未写出 slot 时为默认值 any
+		if slot, ok := m["slot"].(string); ok && slot != "mainhand" && slot != "hand" {
+			continue
+		}
+		amount, _ := m["amount"].(float64)
+		switch m["operation"] {
+		case "add_value":
+			add += amount
+		case "add_multiplied_base":
+			multiplyBase += amount
+		case "add_multiplied_total":
+			multiplyTotal *= 1 + amount
+		}
+	}
+
+	base := BaseAttackSpeed + add
+	return (base + base*multiplyBase) * multiplyTotal, true
+}
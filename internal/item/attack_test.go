@@ -0,0 +1,59 @@
+package item
+
+import (
+	"testing"
+
+	"gmcc/internal/item/component"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/registry"
+)
+
+func TestItemStack_AttackSpeed(t *testing.T) {
+	reg := registry.GetItemRegistry()
+	tests := []struct {
+		name string
+		want float64
+	}{
+		{"diamond_sword", 1.6},
+		{"iron_axe", 0.9},
+		{"stone_pickaxe", 1.2},
+		{"mace", 0.6},
+		{"dirt", BaseAttackSpeed},
+	}
+	for _, tt := range tests {
+		stack := &ItemStack{ID: reg.NameToID(tt.name), Count: 1}
+		if got := stack.AttackSpeed(); got != tt.want {
+			t.Errorf("%s AttackSpeed() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	var empty *ItemStack
+	if got := empty.AttackCooldownTicks(); got != 5 {
+		t.Errorf("空手 AttackCooldownTicks() = %v, want 5", got)
+	}
+}
+
+func TestItemStack_AttackSpeedModifiers(t *testing.T) {
+	// 一个主手 attack_speed add_value -2.0 的修饰符
+	var raw []byte
+	raw = append(raw, packet.EncodeVarInt(1)...)
+	raw = append(raw, packet.EncodeVarInt(4)...) // minecraft:attack_speed
+	raw = append(raw, packet.EncodeString("minecraft:base_attack_speed")...)
+	raw = append(raw, packet.EncodeFloat64(-2.0)...)
+	raw = append(raw, packet.EncodeVarInt(0)...) // add_value
+	raw = append(raw, packet.EncodeVarInt(1)...) // mainhand
+	raw = append(raw, packet.EncodeVarInt(0)...) // 默认显示
+
+	sword := registry.GetItemRegistry().NameToID("diamond_sword")
+	stack := &ItemStack{ID: sword, Count: 1, Components: map[int32]*component.ComponentResult{
+		component.AttributeModifiers: {TypeID: component.AttributeModifiers, Raw: raw},
+	}}
+	if got := stack.AttackSpeed(); got != 2.0 {
+		t.Errorf("AttackSpeed() = %v, want 2.0", got)
+	}
+
+	removed := &ItemStack{ID: sword, Count: 1, Removed: []int32{component.AttributeModifiers}}
+	if got := removed.AttackSpeed(); got != BaseAttackSpeed {
+		t.Errorf("移除组件后 AttackSpeed() = %v, want %v", got, BaseAttackSpeed)
+	}
+}
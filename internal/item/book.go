@@ -0,0 +1,45 @@
+package item
+
+import "gmcc/internal/item/component"
+
+// 书与笔的限制 (与原版 WritableBookContent/BookEditScreen 一致)
+const (
+	MaxBookPages      = 100
+	MaxBookPageLength = 1024 // UTF-16 码元
+	MaxBookTitleLen   = 32
+)
+
+// WritableBook 返回书与笔的内容，没有该组件时返回 false
+func (s *ItemStack) WritableBook() (*component.WritableBook, bool) {
+	v, ok := s.Component(component.WritableBookContent)
+	if !ok {
+		return nil, false
+	}
+	book, ok := v.(*component.WritableBook)
+	return book, ok && book != nil
+}
+
+// WrittenBook 返回成书的内容，没有该组件时返回 false
+func (s *ItemStack) WrittenBook() (*component.WrittenBook, bool) {
+	v, ok := s.Component(component.WrittenBookContent)
+	if !ok {
+		return nil, false
+	}
+	book, ok := v.(*component.WrittenBook)
+	return book, ok && book != nil
+}
+
+// BookPages 返回书的每页纯文本，成书优先
+func (s *ItemStack) BookPages() []string {
+	if book, ok := s.WrittenBook(); ok {
+		pages := make([]string, 0, len(book.Pages))
+		for _, tc := range book.Pages {
+			pages = append(pages, tc.ToPlain())
+		}
+		return pages
+	}
+	if book, ok := s.WritableBook(); ok {
+		return append([]string(nil), book.Pages...)
+	}
+	return nil
+}
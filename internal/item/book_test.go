@@ -0,0 +1,76 @@
+package item
+
+import (
+	"bytes"
+	"testing"
+
+	"gmcc/internal/item/component"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/registry"
+)
+
+// nbtString 编码网络 NBT 字符串标签 (纯文本组件)
+func nbtString(s string) []byte {
+	return append([]byte{0x08, byte(len(s) >> 8), byte(len(s))}, s...)
+}
+
+func TestItemStack_WrittenBook(t *testing.T) {
+	id := registry.GetItemRegistry().NameToID("written_book")
+	if id < 0 {
+		t.Fatal("注册表缺少 written_book")
+	}
+
+	data := append([]byte{0x01}, packet.EncodeVarInt(id)...)
+	data = append(data, 0x01, 0x00, byte(component.WrittenBookContent))
+	data = append(data, packet.EncodeString("Rules")...)
+	data = append(data, packet.EncodeBool(true)...) // 过滤后的书名
+	data = append(data, packet.EncodeString("R***s")...)
+	data = append(data, packet.EncodeString("Admin")...)
+	data = append(data, packet.EncodeVarInt(component.BookCopyOfOriginal)...)
+	data = append(data, packet.EncodeVarInt(2)...)
+	data = append(data, nbtString("Page one")...)
+	data = append(data, packet.EncodeBool(false)...)
+	data = append(data, nbtString("Type /agree")...)
+	data = append(data, packet.EncodeBool(false)...)
+	data = append(data, packet.EncodeBool(true)...) // resolved
+
+	stack, err := ReadItemStack(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadItemStack() error = %v", err)
+	}
+	book, ok := stack.WrittenBook()
+	if !ok {
+		t.Fatal("WrittenBook() ok = false")
+	}
+	if book.Title != "Rules" || book.Author != "Admin" || book.Generation != component.BookCopyOfOriginal || !book.Resolved {
+		t.Errorf("WrittenBook() = %+v", book)
+	}
+	pages := stack.BookPages()
+	if len(pages) != 2 || pages[0] != "Page one" || pages[1] != "Type /agree" {
+		t.Errorf("BookPages() = %q", pages)
+	}
+}
+
+func TestItemStack_WritableBook(t *testing.T) {
+	id := registry.GetItemRegistry().NameToID("writable_book")
+	if id < 0 {
+		t.Fatal("注册表缺少 writable_book")
+	}
+
+	data := append([]byte{0x01}, packet.EncodeVarInt(id)...)
+	data = append(data, 0x01, 0x00, byte(component.WritableBookContent))
+	data = append(data, packet.EncodeVarInt(1)...)
+	data = append(data, packet.EncodeString("draft")...)
+	data = append(data, packet.EncodeBool(false)...)
+
+	stack, err := ReadItemStack(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadItemStack() error = %v", err)
+	}
+	if _, ok := stack.WrittenBook(); ok {
+		t.Error("书与笔不应有成书内容")
+	}
+	if pages := stack.BookPages(); len(pages) != 1 || pages[0] != "draft" {
+		t.Errorf("BookPages() = %q", pages)
+	}
+}
@@ -116,3 +116,36 @@ const MaxComponentID int32 = 103
 
 // ComponentCount 组件总数
 const ComponentCount int = 104
+
+// componentNames 组件的注册表名 (不含 minecraft: 前缀)，下标为组件ID
+var componentNames = [ComponentCount]string{
+	"custom_data", "max_stack_size", "max_damage", "damage", "unbreakable",
+	"use_effects", "custom_name", "minimum_attack_charge", "damage_type", "item_name",
+	"item_model", "lore", "rarity", "enchantments", "can_place_on",
+	"can_break", "attribute_modifiers", "custom_model_data", "tooltip_display", "repair_cost",
+	"creative_slot_lock", "enchantment_glint_override", "intangible_projectile", "food", "consumable",
+	"use_remainder", "use_cooldown", "damage_resistant", "tool", "weapon",
+	"attack_range", "enchantable", "equippable", "repairable", "glider",
+	"tooltip_style", "death_protection", "blocks_attacks", "piercing_weapon", "kinetic_weapon",
+	"swing_animation", "stored_enchantments", "dyed_color", "map_color", "map_id",
+	"map_decorations", "map_post_processing", "potion_duration_scale", "charged_projectiles", "bundle_contents",
+	"potion_contents", "suspicious_stew_effects", "writable_book_content", "written_book_content", "trim",
+	"debug_stick_state", "entity_data", "bucket_entity_data", "block_entity_data", "instrument",
+	"provides_trim_material", "ominous_bottle_amplifier", "jukebox_playable", "provides_banner_patterns", "recipes",
+	"lodestone_tracker", "firework_explosion", "fireworks", "profile", "note_block_sound",
+	"banner_patterns", "base_color", "pot_decorations", "container", "block_state",
+	"bees", "lock", "container_loot", "break_sound", "villager/variant",
+	"wolf/variant", "cat/variant", "frog/variant", "axolotl/variant", "painting/variant",
+	"shulker/color", "goat_variant", "sniffer_variant", "ghoul_variant", "breeze_variant",
+	"bogged_variant", "bundle_remaining_space", "entity_color", "buckable", "armor_trim",
+	"equippable_color", "trim_material", "trim_pattern", "compass_color", "map_display_color",
+	"frame_type", "banner_pattern", "base_color_component", "color_component",
+}
+
+// Name 返回组件的注册表名，如 minecraft:damage，未知ID返回空字符串
+func Name(typeID int32) string {
+	if typeID < MinComponentID || typeID > MaxComponentID {
+		return ""
+	}
+	return "minecraft:" + componentNames[typeID]
+}
@@ -2,22 +2,30 @@ package component
 
 import (
 	"bytes"
+	"fmt"
 
-	"gmcc/internal/logx"
 	"gmcc/internal/mcclient/packet"
 )
 
 // containerCallback 全局回调变量
 var containerCallback func(size int32) error
 
+// stackReader 由 item 包注册，用于解析嵌套的物品槽 (避免循环依赖)
+var stackReader func(r *bytes.Reader) (any, error)
+
 // SetContainerCallback 注册容器回调
 func SetContainerCallback(callback func(size int32) error) {
 	containerCallback = callback
 }
 
-// ContainerComponentHandler 容器组件特殊处理器
+// SetStackReader 注册嵌套物品槽的解析函数
+func SetStackReader(reader func(r *bytes.Reader) (any, error)) {
+	stackReader = reader
+}
+
+// ContainerComponentHandler 容器组件特殊处理器，Data 为按槽位顺序的物品列表
 func ContainerComponentHandler(typeID int32, r *bytes.Reader) (*ComponentResult, error) {
-	// 1. 读取容器大小
+	// 1. 读取槽位数量
 	size, err := packet.ReadVarIntFromReader(r)
 	if err != nil {
 		return nil, err
@@ -30,80 +38,56 @@ func ContainerComponentHandler(typeID int32, r *bytes.Reader) (*ComponentResult,
 		}
 	}
 
-	// 3. 继续读取容器内容
-	length, err := packet.ReadVarIntFromReader(r)
+	// 3. 读取容器中的物品槽
+	items, err := readItemList(r, size)
 	if err != nil {
 		return nil, err
 	}
-
-	// 读取容器中的物品槽（使用内部简化版）
-	for i := int32(0); i < length; i++ {
-		if err := skipSlotDataInternal(r); err != nil {
-			logx.Debugf("跳过容器物品槽失败: index=%d, err=%v", i, err)
-			// 继续处理，不中断
-		}
-	}
-
-	return &ComponentResult{TypeID: typeID}, nil
+	return &ComponentResult{TypeID: typeID, Data: items}, nil
 }
 
-// skipSlotDataInternal 内部使用的简化版物品槽跳过函数
-// 避免与 optimized.go 产生初始化循环
-func skipSlotDataInternal(r *bytes.Reader) error {
-	// 读取数量
-	count, err := packet.ReadVarIntFromReader(r)
+// ParseItemList 解析物品列表组件 (bundle_contents、charged_projectiles)
+func ParseItemList(typeID int32, r *bytes.Reader) (*ComponentResult, error) {
+	size, err := packet.ReadVarIntFromReader(r)
 	if err != nil {
-		return err
-	}
-	if count == 0 {
-		return nil // 空物品
+		return nil, err
 	}
-
-	// 读取物品ID
-	_, err = packet.ReadVarIntFromReader(r)
+	items, err := readItemList(r, size)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	// 跳过组件
-	return skipComponentsInternal(r)
+	return &ComponentResult{TypeID: typeID, Data: items}, nil
 }
 
-// skipComponentsInternal 内部使用的组件跳过函数
-func skipComponentsInternal(r *bytes.Reader) error {
-	// 读取添加的组件数量
-	numAdd, err := packet.ReadVarIntFromReader(r)
-	if err != nil {
-		return err
+// ParseUseRemainder 解析 use_remainder 组件 (ID: 25, 单个物品)
+func ParseUseRemainder(typeID int32, r *bytes.Reader) (*ComponentResult, error) {
+	if stackReader == nil {
+		return &ComponentResult{TypeID: typeID}, packet.SkipSlot(r)
 	}
-
-	// 读取移除的组件数量
-	numRemove, err := packet.ReadVarIntFromReader(r)
+	stack, err := stackReader(r)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return &ComponentResult{TypeID: typeID, Data: stack}, nil
+}
 
-	// 跳过添加的组件
-	for i := int32(0); i < numAdd; i++ {
-		// 读取组件类型
-		typeID, err := packet.ReadVarIntFromReader(r)
-		if err != nil {
-			return err
+func readItemList(r *bytes.Reader, size int32) ([]any, error) {
+	if size < 0 || int(size) > r.Len() {
+		return nil, fmt.Errorf("物品列表长度无效: %d", size)
+	}
+	items := make([]any, 0, size)
+	for i := int32(0); i < size; i++ {
+		if stackReader == nil {
+			if err := packet.SkipSlot(r); err != nil {
+				return nil, fmt.Errorf("跳过容器物品槽 %d 失败: %w", i, err)
+			}
+			continue
 		}
-		// 直接创建新解析器（避免初始化循环）
-		parser := NewParser()
-		_, err = parser.ParseComponent(typeID, r)
+		stack, err := stackReader(r)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("读取容器物品槽 %d 失败: %w", i, err)
 		}
+		items = append(items, stack)
 	}
-
-	// 跳过移除的组件
-	for i := int32(0); i < numRemove; i++ {
-		if _, err := packet.ReadVarIntFromReader(r); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return items, nil
 }
@@ -2,36 +2,17 @@ package component
 
 import (
 	"bytes"
+	"fmt"
 
-	"gmcc/internal/logx"
 	"gmcc/internal/mcclient/packet"
 )
 
+// makeDiscardHandler 未单独解析的组件按类型跳过，只保留类型ID
 func makeDiscardHandler(typeID int32) ComponentHandler {
 	return func(id int32, r *bytes.Reader) (*ComponentResult, error) {
-		err := skipComponentByID(id, r)
-		if err != nil {
-			logx.Debugf("跳过组件失败: typeID=%d, err=%v", id, err)
+		if err := packet.SkipComponent(r, id); err != nil {
+			return nil, fmt.Errorf("跳过组件 %d 失败: %w", id, err)
 		}
 		return &ComponentResult{TypeID: id}, nil
 	}
 }
-
-func skipComponentByID(id int32, r *bytes.Reader) error {
-	switch id {
-	case Unbreakable, CreativeSlotLock, Glider:
-		return nil
-	case DyedColor, MapColor, CustomModelData, EnchantmentGlintOverride,
-		PotionDurationScale, UseEffects:
-		_, err := packet.ReadInt32FromReader(r)
-		return err
-	case MaxStackSize, MaxDamage, Damage, MinimumAttackCharge, Rarity,
-		RepairCost, Enchantable, MapID, MapPostProcessing,
-		OminousBottleAmplifier, BundleRemainingSpace, FrameType,
-		BaseColorComponent, ColorComponent:
-		_, err := packet.ReadVarIntFromReader(r)
-		return err
-	default:
-		return packet.SkipNBT(r)
-	}
-}
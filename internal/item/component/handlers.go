@@ -27,6 +27,16 @@ func defaultHandlers() map[int32]ComponentHandler {
 	handlers[Lore] = ParseLore
 	handlers[Rarity] = ParseRarity
 	handlers[Enchantments] = ParseEnchantments
+	handlers[StoredEnchantments] = ParseEnchantments
+
+	// 书
+	handlers[WritableBookContent] = ParseWritableBookContent
+	handlers[WrittenBookContent] = ParseWrittenBookContent
+
+	// 嵌套物品
+	handlers[UseRemainder] = ParseUseRemainder
+	handlers[ChargedProjectiles] = ParseItemList
+	handlers[BundleContents] = ParseItemList
 
 	// ID 范围 MinComponentID-MaxComponentID - 其他使用丢弃处理器
 	for typeID := MinComponentID; typeID <= MaxComponentID; typeID++ {
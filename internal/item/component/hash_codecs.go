@@ -0,0 +1,838 @@
+package component
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/nbt"
+	"gmcc/internal/registry"
+)
+
+// 1.21.5 起 container_click 只携带物品的“哈希堆叠”: 每个组件发送其 codec 编码
+// (而非网络编码) 经 HashOps 计算的 CRC32C。这里把组件的网络字节还原为 codec
+// 形态的通用值 (map/list/字符串/数值)，再交给 HashValue 计算。
+//
+// 注册表条目在 codec 中以名称出现，名称来自物品注册表、registry_data 动态注册表
+// 以及 registry 包内置的少量静态表；查不到名称的组件无法计算哈希，返回错误。
+
+var (
+	rarityNames        = []string{"common", "uncommon", "rare", "epic"}
+	dyeColorNames      = []string{"white", "orange", "magenta", "light_blue", "yellow", "lime", "pink", "gray", "light_gray", "cyan", "purple", "blue", "brown", "green", "red", "black"}
+	equipmentSlotNames = []string{"mainhand", "feet", "legs", "chest", "head", "offhand", "body", "saddle"}
+	slotGroupNames     = []string{"any", "mainhand", "offhand", "hand", "feet", "legs", "chest", "head", "armor", "body", "saddle"}
+	attributeOpNames   = []string{"add_value", "add_multiplied_base", "add_multiplied_total"}
+	useAnimationNames  = []string{"none", "eat", "drink", "block", "bow", "spear", "crossbow", "spyglass", "toot_horn", "brush", "bundle"}
+	consumeEffectNames = []string{"apply_effects", "remove_effects", "clear_all_effects", "teleport_randomly", "play_sound"}
+	fireworkShapeNames = []string{"small_ball", "large_ball", "star", "creeper", "burst"}
+	axolotlNames       = []string{"lucy", "wild", "gold", "cyan", "blue"}
+	swingTypeNames     = []string{"none", "whack", "stab"}
+	modelTypeNames     = []string{"wide", "slim"}
+)
+
+// 文本组件 Style 中的布尔字段，网络 NBT 中以 Byte 存储
+var textBoolKeys = map[string]bool{
+	"bold": true, "italic": true, "underlined": true, "strikethrough": true,
+	"obfuscated": true, "interpret": true,
+}
+
+// HashComponent 计算单个组件的哈希，raw 为组件的网络编码 (ComponentResult.Raw)
+func HashComponent(typeID int32, raw []byte) (int32, error) {
+	v, err := DecodeComponent(typeID, raw)
+	if err != nil {
+		return 0, err
+	}
+	h, err := HashValue(v)
+	if err != nil {
+		return 0, fmt.Errorf("组件 %s(%d): %w", Name(typeID), typeID, err)
+	}
+	return int32(h), nil
+}
+
+// DecodeComponent 把组件的网络编码转换为 codec 形态的值 (map/list/基本类型)，
+// 字段名与原版数据包中的 JSON 一致
+func DecodeComponent(typeID int32, raw []byte) (any, error) {
+	c := &codecReader{r: bytes.NewReader(raw)}
+	v := c.component(typeID)
+	if c.err == nil && c.r.Len() > 0 {
+		c.err = fmt.Errorf("剩余 %d 字节未解析", c.r.Len())
+	}
+	if c.err != nil {
+		return nil, fmt.Errorf("组件 %s(%d): %w", Name(typeID), typeID, c.err)
+	}
+	return v, nil
+}
+
+// codecReader 读取组件网络编码并构造 codec 形态的值。
+// 第一次出错后记录错误，后续读取均返回零值，由调用方统一检查 err。
+type codecReader struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (c *codecReader) fail(format string, args ...any) {
+	if c.err == nil {
+		c.err = fmt.Errorf(format, args...)
+	}
+}
+
+func (c *codecReader) varInt() int32 {
+	if c.err != nil {
+		return 0
+	}
+	v, err := packet.ReadVarIntFromReader(c.r)
+	c.err = err
+	return v
+}
+
+func (c *codecReader) readInt() int32 {
+	if c.err != nil {
+		return 0
+	}
+	v, err := packet.ReadInt32FromReader(c.r)
+	c.err = err
+	return v
+}
+
+func (c *codecReader) readLong() int64 {
+	if c.err != nil {
+		return 0
+	}
+	var buf [8]byte
+	if _, err := c.r.Read(buf[:]); err != nil {
+		c.err = err
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(buf[:]))
+}
+
+func (c *codecReader) float() float32 {
+	if c.err != nil {
+		return 0
+	}
+	v, err := packet.ReadFloat32FromReader(c.r)
+	c.err = err
+	return v
+}
+
+func (c *codecReader) double() float64 {
+	if c.err != nil {
+		return 0
+	}
+	v, err := packet.ReadFloat64FromReader(c.r)
+	c.err = err
+	return v
+}
+
+func (c *codecReader) boolean() bool {
+	if c.err != nil {
+		return false
+	}
+	v, err := packet.ReadBoolFromReader(c.r)
+	c.err = err
+	return v
+}
+
+func (c *codecReader) str() string {
+	if c.err != nil {
+		return ""
+	}
+	v, err := packet.ReadStringFromReader(c.r)
+	c.err = err
+	return v
+}
+
+// count 读取列表长度并做基本校验
+func (c *codecReader) count() int32 {
+	n := c.varInt()
+	if n < 0 || int(n) > c.r.Len() {
+		c.fail("列表长度无效: %d", n)
+		return 0
+	}
+	return n
+}
+
+// nbtTag 读取一个网络 NBT 并转换为哈希值
+func (c *codecReader) nbtTag(boolKeys map[string]bool) any {
+	if c.err != nil {
+		return nil
+	}
+	var v any
+	if err := nbt.NewDecoder(c.r).NetworkFormat(true).Decode(&v); err != nil {
+		c.err = err
+		return nil
+	}
+	return nbtValue(v, boolKeys)
+}
+
+// text 读取 NBT 文本组件
+func (c *codecReader) text() any {
+	return c.nbtTag(textBoolKeys)
+}
+
+func (c *codecReader) enum(names []string) string {
+	id := c.varInt()
+	if id < 0 || int(id) >= len(names) {
+		c.fail("枚举值越界: %d", id)
+		return ""
+	}
+	return names[id]
+}
+
+// entry 把注册表网络 ID 转换为条目名
+func (c *codecReader) entry(registryID string, id int32) string {
+	if c.err != nil {
+		return ""
+	}
+	name := registry.EntryName(registryID, id)
+	if name == "" {
+		c.fail("%s 注册表中未知的 ID %d", registryID, id)
+	}
+	return name
+}
+
+// holderRegistry 只允许引用注册表条目的 Holder (ByteBufCodecs.holderRegistry)
+func (c *codecReader) holderRegistry(registryID string) string {
+	return c.entry(registryID, c.varInt())
+}
+
+// holder 可内联的 Holder (ByteBufCodecs.holder): 0 表示直接值，否则为 ID+1
+func (c *codecReader) holder(registryID string, direct func() any) any {
+	n := c.varInt()
+	if n == 0 {
+		if direct == nil {
+			c.fail("%s 不支持内联值", registryID)
+			return nil
+		}
+		return direct()
+	}
+	return c.entry(registryID, n-1)
+}
+
+// eitherHolder 读取 EitherHolder: Holder 或注册表键名，codec 中均为名称
+func (c *codecReader) eitherHolder(registryID string, plainID bool) any {
+	if c.boolean() {
+		if plainID {
+			return c.holderRegistry(registryID)
+		}
+		return c.holder(registryID, nil)
+	}
+	return c.str()
+}
+
+// holderSet 读取 HolderSet: 0 后跟标签名，否则为 数量+1 个 ID
+func (c *codecReader) holderSet(registryID string) any {
+	n := c.varInt()
+	if n == 0 {
+		return "#" + c.str()
+	}
+	n--
+	if n < 0 || int(n) > c.r.Len() {
+		c.fail("HolderSet 长度无效: %d", n)
+		return nil
+	}
+	names := make([]any, 0, n)
+	for i := int32(0); i < n; i++ {
+		names = append(names, c.holderRegistry(registryID))
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return names
+}
+
+// sound 读取 Holder<SoundEvent>
+func (c *codecReader) sound() any {
+	return c.holder("minecraft:sound_event", func() any {
+		m := map[string]any{"sound_id": c.str()}
+		if c.boolean() {
+			m["range"] = c.float()
+		}
+		return m
+	})
+}
+
+func (c *codecReader) optional(m map[string]any, key string, read func() any) {
+	if c.boolean() {
+		m[key] = read()
+	}
+}
+
+// putDefault 仅在值与默认值不同时写入 (对应 optionalFieldOf(name, default))
+func putDefault[T comparable](m map[string]any, key string, v, def T) {
+	if v != def {
+		m[key] = v
+	}
+}
+
+func putList(m map[string]any, key string, list []any) {
+	if len(list) > 0 {
+		m[key] = list
+	}
+}
+
+func (c *codecReader) list(read func() any) []any {
+	n := c.count()
+	list := make([]any, 0, n)
+	for i := int32(0); i < n && c.err == nil; i++ {
+		list = append(list, read())
+	}
+	return list
+}
+
+// itemStack 读取可为空的物品槽，codec 形态为 {id, count, components}
+func (c *codecReader) itemStack() any {
+	count := c.varInt()
+	if count <= 0 || c.err != nil {
+		return nil
+	}
+	m := map[string]any{
+		"id":    c.holderRegistry("minecraft:item"),
+		"count": count,
+	}
+	added, removed := c.count(), c.count()
+	patch := make(map[string]any, added+removed)
+	for i := int32(0); i < added && c.err == nil; i++ {
+		typeID := c.varInt()
+		patch[c.componentName(typeID)] = c.component(typeID)
+	}
+	for i := int32(0); i < removed && c.err == nil; i++ {
+		patch["!"+c.componentName(c.varInt())] = map[string]any{}
+	}
+	if len(patch) > 0 {
+		m["components"] = patch
+	}
+	return m
+}
+
+func (c *codecReader) componentName(typeID int32) string {
+	name := Name(typeID)
+	if name == "" {
+		c.fail("未知组件类型 %d", typeID)
+	}
+	return name
+}
+
+func (c *codecReader) component(typeID int32) any {
+	switch typeID {
+	case CustomData, MapDecorations, DebugStickState, BucketEntityData, Recipes, Lock, ContainerLoot:
+		return c.nbtTag(nil)
+	case MaxStackSize, MaxDamage, Damage, RepairCost, MapID, OminousBottleAmplifier:
+		return c.varInt()
+	case Unbreakable, CreativeSlotLock, IntangibleProjectile, Glider:
+		return map[string]any{}
+	case UseEffects:
+		m := map[string]any{}
+		putDefault(m, "can_sprint", c.boolean(), false)
+		putDefault(m, "interact_vibrations", c.boolean(), true)
+		putDefault(m, "speed_multiplier", c.float(), float32(0.2))
+		return m
+	case CustomName, ItemName:
+		return c.text()
+	case MinimumAttackCharge, PotionDurationScale:
+		return c.float()
+	case DamageType:
+		return c.eitherHolder("minecraft:damage_type", true)
+	case ItemModel, TooltipStyle, NoteBlockSound:
+		return c.str()
+	case Lore:
+		return c.list(c.text)
+	case Rarity:
+		return c.enum(rarityNames)
+	case Enchantments, StoredEnchantments:
+		n := c.count()
+		m := make(map[string]any, n)
+		for i := int32(0); i < n && c.err == nil; i++ {
+			name := c.holderRegistry("minecraft:enchantment")
+			m[name] = c.varInt()
+		}
+		return m
+	case CanPlaceOn, CanBreak:
+		list := c.list(c.blockPredicate)
+		if len(list) == 1 {
+			return list[0]
+		}
+		return list
+	case AttributeModifiers:
+		return c.list(c.attributeModifier)
+	case CustomModelData:
+		m := map[string]any{}
+		putList(m, "floats", c.list(func() any { return c.float() }))
+		putList(m, "flags", c.list(func() any { return c.boolean() }))
+		putList(m, "strings", c.list(func() any { return c.str() }))
+		putList(m, "colors", c.list(func() any { return c.readInt() }))
+		return m
+	case TooltipDisplay:
+		m := map[string]any{}
+		putDefault(m, "hide_tooltip", c.boolean(), false)
+		putList(m, "hidden_components", c.list(func() any { return c.componentName(c.varInt()) }))
+		return m
+	case EnchantmentGlintOverride:
+		return c.boolean()
+	case Food:
+		m := map[string]any{"nutrition": c.varInt(), "saturation": c.float()}
+		putDefault(m, "can_always_eat", c.boolean(), false)
+		return m
+	case Consumable:
+		m := map[string]any{}
+		putDefault(m, "consume_seconds", c.float(), float32(1.6))
+		putDefault(m, "animation", c.enum(useAnimationNames), "eat")
+		if s := c.sound(); s != "minecraft:entity.generic.eat" {
+			m["sound"] = s
+		}
+		putDefault(m, "has_consume_particles", c.boolean(), true)
+		putList(m, "on_consume_effects", c.list(c.consumeEffect))
+		return m
+	case UseRemainder:
+		return map[string]any{"convert_into": c.itemStack()}
+	case UseCooldown:
+		m := map[string]any{"seconds": c.float()}
+		c.optional(m, "cooldown_group", func() any { return c.str() })
+		return m
+	case DamageResistant:
+		return map[string]any{"types": "#" + c.str()}
+	case Tool:
+		m := map[string]any{"rules": c.list(func() any {
+			rule := map[string]any{"blocks": c.holderSet("minecraft:block")}
+			c.optional(rule, "speed", func() any { return c.float() })
+			c.optional(rule, "correct_for_drops", func() any { return c.boolean() })
+			return rule
+		})}
+		putDefault(m, "default_mining_speed", c.float(), float32(1))
+		putDefault(m, "damage_per_block", c.varInt(), int32(1))
+		putDefault(m, "can_destroy_blocks_in_creative", c.boolean(), true)
+		return m
+	case Weapon:
+		m := map[string]any{}
+		putDefault(m, "item_damage_per_attack", c.varInt(), int32(1))
+		putDefault(m, "disable_blocking_for_seconds", c.float(), float32(0))
+		return m
+	case AttackRange:
+		m := map[string]any{}
+		putDefault(m, "min_reach", c.float(), float32(0))
+		putDefault(m, "max_reach", c.float(), float32(3))
+		putDefault(m, "min_creative_reach", c.float(), float32(0))
+		putDefault(m, "max_creative_reach", c.float(), float32(5))
+		putDefault(m, "hitbox_margin", c.float(), float32(0.3))
+		putDefault(m, "mob_factor", c.float(), float32(1))
+		return m
+	case Enchantable:
+		return map[string]any{"value": c.varInt()}
+	case Equippable:
+		return c.equippable()
+	case Repairable:
+		return map[string]any{"items": c.holderSet("minecraft:item")}
+	case DeathProtection:
+		m := map[string]any{}
+		putList(m, "death_effects", c.list(c.consumeEffect))
+		return m
+	case BlocksAttacks:
+		return c.blocksAttacks()
+	case PiercingWeapon:
+		m := map[string]any{}
+		putDefault(m, "deals_knockback", c.boolean(), true)
+		putDefault(m, "dismounts", c.boolean(), false)
+		c.optional(m, "sound", c.sound)
+		c.optional(m, "hit_sound", c.sound)
+		return m
+	case KineticWeapon:
+		return c.kineticWeapon()
+	case SwingAnimation:
+		m := map[string]any{}
+		putDefault(m, "type", c.enum(swingTypeNames), "whack")
+		putDefault(m, "duration", c.varInt(), int32(6))
+		return m
+	case DyedColor, MapColor:
+		return c.readInt()
+	case MapPostProcessing:
+		c.fail("map_post_processing 没有 codec，不参与哈希")
+		return nil
+	case ChargedProjectiles, BundleContents:
+		return c.list(c.itemStack)
+	case PotionContents:
+		return c.potionContents()
+	case SuspiciousStewEffects:
+		return c.list(func() any {
+			m := map[string]any{"id": c.holderRegistry("minecraft:mob_effect")}
+			putDefault(m, "duration", c.varInt(), int32(160))
+			return m
+		})
+	case WritableBookContent:
+		m := map[string]any{}
+		putList(m, "pages", c.list(func() any { return c.filterable(func() any { return c.str() }) }))
+		return m
+	case WrittenBookContent:
+		m := map[string]any{
+			"title":  c.filterable(func() any { return c.str() }),
+			"author": c.str(),
+		}
+		putDefault(m, "generation", c.varInt(), int32(0))
+		putList(m, "pages", c.list(func() any { return c.filterable(c.text) }))
+		putDefault(m, "resolved", c.boolean(), false)
+		return m
+	case Trim, ArmorTrim:
+		return map[string]any{
+			"material": c.holder("minecraft:trim_material", nil),
+			"pattern":  c.holder("minecraft:trim_pattern", nil),
+		}
+	case EntityData:
+		return c.typedEntityData("minecraft:entity_type")
+	case BlockEntityData:
+		return c.typedEntityData("minecraft:block_entity_type")
+	case Instrument:
+		return c.eitherHolder("minecraft:instrument", false)
+	case ProvidesTrimMaterial:
+		return c.eitherHolder("minecraft:trim_material", false)
+	case JukeboxPlayable:
+		return c.eitherHolder("minecraft:jukebox_song", false)
+	case ProvidesBannerPatterns:
+		return "#" + c.str()
+	case LodestoneTracker:
+		m := map[string]any{}
+		c.optional(m, "target", c.globalPos)
+		putDefault(m, "tracked", c.boolean(), true)
+		return m
+	case FireworkExplosion:
+		return c.fireworkExplosion()
+	case Fireworks:
+		m := map[string]any{}
+		putDefault(m, "flight_duration", int8(c.varInt()), 0)
+		putList(m, "explosions", c.list(c.fireworkExplosion))
+		return m
+	case Profile:
+		return c.profile()
+	case BannerPatterns:
+		return c.list(func() any {
+			return map[string]any{
+				"pattern": c.holder("minecraft:banner_pattern", nil),
+				"color":   c.enum(dyeColorNames),
+			}
+		})
+	case BaseColor, ShulkerVariant:
+		return c.enum(dyeColorNames)
+	case PotDecorations:
+		return c.list(func() any { return c.holderRegistry("minecraft:item") })
+	case Container:
+		n := c.count()
+		slots := make([]any, 0, n)
+		for i := int32(0); i < n && c.err == nil; i++ {
+			if stack := c.itemStack(); stack != nil {
+				slots = append(slots, map[string]any{"slot": i, "item": stack})
+			}
+		}
+		return slots
+	case BlockState:
+		n := c.count()
+		m := make(map[string]any, n)
+		for i := int32(0); i < n && c.err == nil; i++ {
+			key := c.str()
+			m[key] = c.str()
+		}
+		return m
+	case Bees:
+		return c.list(func() any {
+			return map[string]any{
+				"entity_data":       c.typedEntityData("minecraft:entity_type"),
+				"ticks_in_hive":     c.varInt(),
+				"min_ticks_in_hive": c.varInt(),
+			}
+		})
+	case BreakSound:
+		return c.sound()
+	case VillagerVariant:
+		return c.holderRegistry("minecraft:villager_type")
+	case WolfVariant:
+		return c.holderRegistry("minecraft:wolf_variant")
+	case CatVariant:
+		return c.holderRegistry("minecraft:cat_variant")
+	case FrogVariant:
+		return c.holderRegistry("minecraft:frog_variant")
+	case AxolotlVariant:
+		return c.enum(axolotlNames)
+	case PaintingVariant:
+		return c.holder("minecraft:painting_variant", nil)
+	case TrimMaterial:
+		return c.holderRegistry("minecraft:trim_material")
+	case TrimPattern:
+		return c.holderRegistry("minecraft:trim_pattern")
+	case GoatVariant, SnifferVariant, GhoulVariant, BreezeVariant, BoggedVariant,
+		BundleRemainingSpace, EntityColor, Buckable, EquippableColor, CompassColor,
+		MapDisplayColor, FrameType, BannerPattern, BaseColorComponent, ColorComponent:
+		return c.varInt()
+	default:
+		c.fail("未知组件类型 %d", typeID)
+		return nil
+	}
+}
+
+// filterable 读取 Filterable<T>: 原文 + 可选的过滤后文本，无过滤文本时 codec 直接编码原文
+func (c *codecReader) filterable(read func() any) any {
+	raw := read()
+	if c.boolean() {
+		return map[string]any{"raw": raw, "filtered": read()}
+	}
+	return raw
+}
+
+func (c *codecReader) blockPredicate() any {
+	m := map[string]any{}
+	c.optional(m, "blocks", func() any { return c.holderSet("minecraft:block") })
+	c.optional(m, "state", func() any {
+		n := c.count()
+		props := make(map[string]any, n)
+		for i := int32(0); i < n && c.err == nil; i++ {
+			name := c.str()
+			if c.boolean() {
+				props[name] = c.str()
+			} else {
+				props[name] = map[string]any{"min": c.str(), "max": c.str()}
+			}
+		}
+		return props
+	})
+	if c.boolean() {
+		// NbtPredicate 的 codec 是 SNBT 字符串，无法从网络 NBT 还原出相同文本
+		c.fail("方块谓词中的 nbt 条件不支持哈希")
+		return nil
+	}
+	exact := c.count()
+	if exact > 0 {
+		components := make(map[string]any, exact)
+		for i := int32(0); i < exact && c.err == nil; i++ {
+			typeID := c.varInt()
+			components[c.componentName(typeID)] = c.component(typeID)
+		}
+		m["components"] = components
+	}
+	if c.count() > 0 {
+		c.fail("方块谓词中的组件子谓词不支持哈希")
+	}
+	return m
+}
+
+func (c *codecReader) attributeModifier() any {
+	m := map[string]any{
+		"type":      c.holderRegistry("minecraft:attribute"),
+		"id":        c.str(),
+		"amount":    c.double(),
+		"operation": c.enum(attributeOpNames),
+	}
+	putDefault(m, "slot", c.enum(slotGroupNames), "any")
+	switch display := c.varInt(); display {
+	case 0:
+	case 1:
+		m["display"] = map[string]any{"type": "hidden"}
+	case 2:
+		m["display"] = map[string]any{"type": "override", "value": c.text()}
+	default:
+		c.fail("未知的属性修饰符显示类型 %d", display)
+	}
+	return m
+}
+
+func (c *codecReader) mobEffectDetails() map[string]any {
+	m := map[string]any{}
+	putDefault(m, "amplifier", int8(c.varInt()), 0)
+	putDefault(m, "duration", c.varInt(), int32(0))
+	putDefault(m, "ambient", c.boolean(), false)
+	putDefault(m, "show_particles", c.boolean(), true)
+	m["show_icon"] = c.boolean()
+	if c.boolean() {
+		m["hidden_effect"] = c.mobEffectDetails()
+	}
+	return m
+}
+
+func (c *codecReader) mobEffect() any {
+	id := c.holderRegistry("minecraft:mob_effect")
+	m := c.mobEffectDetails()
+	m["id"] = id
+	return m
+}
+
+func (c *codecReader) consumeEffect() any {
+	kind := c.enum(consumeEffectNames)
+	m := map[string]any{"type": "minecraft:" + kind}
+	switch kind {
+	case "apply_effects":
+		m["effects"] = c.list(c.mobEffect)
+		putDefault(m, "probability", c.float(), float32(1))
+	case "remove_effects":
+		m["effects"] = c.holderSet("minecraft:mob_effect")
+	case "teleport_randomly":
+		putDefault(m, "diameter", c.float(), float32(16))
+	case "play_sound":
+		m["sound"] = c.sound()
+	}
+	return m
+}
+
+func (c *codecReader) equippable() any {
+	m := map[string]any{"slot": c.enum(equipmentSlotNames)}
+	if s := c.sound(); s != "minecraft:item.armor.equip_generic" {
+		m["equip_sound"] = s
+	}
+	c.optional(m, "asset_id", func() any { return c.str() })
+	c.optional(m, "camera_overlay", func() any { return c.str() })
+	c.optional(m, "allowed_entities", func() any { return c.holderSet("minecraft:entity_type") })
+	putDefault(m, "dispensable", c.boolean(), true)
+	putDefault(m, "swappable", c.boolean(), true)
+	putDefault(m, "damage_on_hurt", c.boolean(), true)
+	putDefault(m, "equip_on_interact", c.boolean(), false)
+	putDefault(m, "can_be_sheared", c.boolean(), false)
+	if s := c.sound(); s != "minecraft:item.shears.snip" {
+		m["shearing_sound"] = s
+	}
+	return m
+}
+
+func (c *codecReader) blocksAttacks() any {
+	m := map[string]any{}
+	putDefault(m, "block_delay_seconds", c.float(), float32(0))
+	putDefault(m, "disable_cooldown_scale", c.float(), float32(1))
+
+	reductions := c.list(func() any {
+		r := map[string]any{}
+		putDefault(r, "horizontal_blocking_angle", c.float(), float32(90))
+		c.optional(r, "type", func() any { return c.holderSet("minecraft:damage_type") })
+		r["base"] = c.float()
+		r["factor"] = c.float()
+		return r
+	})
+	// 默认值为单条 {base: 0, factor: 1}
+	if len(reductions) == 1 {
+		if r := reductions[0].(map[string]any); len(r) == 2 && r["base"] == float32(0) && r["factor"] == float32(1) {
+			reductions = nil
+		}
+	}
+	if reductions != nil {
+		m["damage_reductions"] = reductions
+	}
+
+	threshold, base, factor := c.float(), c.float(), c.float()
+	if threshold != 1 || base != 0 || factor != 1 {
+		m["item_damage"] = map[string]any{"threshold": threshold, "base": base, "factor": factor}
+	}
+	c.optional(m, "bypassed_by", func() any { return "#" + c.str() })
+	c.optional(m, "block_sound", c.sound)
+	c.optional(m, "disable_sound", c.sound)
+	return m
+}
+
+func (c *codecReader) kineticWeapon() any {
+	m := map[string]any{}
+	putDefault(m, "contact_cooldown_ticks", c.varInt(), int32(10))
+	putDefault(m, "delay_ticks", c.varInt(), int32(0))
+	for _, key := range []string{"dismount_conditions", "knockback_conditions", "damage_conditions"} {
+		c.optional(m, key, func() any {
+			cond := map[string]any{"max_duration_ticks": c.varInt()}
+			putDefault(cond, "min_speed", c.float(), float32(0))
+			putDefault(cond, "min_relative_speed", c.float(), float32(0))
+			return cond
+		})
+	}
+	putDefault(m, "forward_movement", c.float(), float32(0))
+	putDefault(m, "damage_multiplier", c.float(), float32(1))
+	c.optional(m, "sound", c.sound)
+	c.optional(m, "hit_sound", c.sound)
+	return m
+}
+
+func (c *codecReader) potionContents() any {
+	m := map[string]any{}
+	c.optional(m, "potion", func() any { return c.holderRegistry("minecraft:potion") })
+	c.optional(m, "custom_color", func() any { return c.readInt() })
+	putList(m, "custom_effects", c.list(c.mobEffect))
+	c.optional(m, "custom_name", func() any { return c.str() })
+	return m
+}
+
+// typedEntityData 读取实体/方块实体数据: 类型ID + NBT，codec 中类型名合并为 id 字段
+func (c *codecReader) typedEntityData(registryID string) any {
+	id := c.holderRegistry(registryID)
+	tag, _ := c.nbtTag(nil).(map[string]any)
+	if tag == nil {
+		tag = map[string]any{}
+	}
+	tag["id"] = id
+	return tag
+}
+
+// globalPos 读取维度名 + 压缩坐标，codec 中坐标为 int 数组 [x, y, z]
+func (c *codecReader) globalPos() any {
+	dimension := c.str()
+	packed := c.readLong()
+	x := int32(packed >> 38)
+	y := int32(packed << 52 >> 52)
+	z := int32(packed << 26 >> 38)
+	return map[string]any{"dimension": dimension, "pos": []int32{x, y, z}}
+}
+
+func (c *codecReader) fireworkExplosion() any {
+	m := map[string]any{"shape": c.enum(fireworkShapeNames)}
+	putList(m, "colors", c.list(func() any { return c.readInt() }))
+	putList(m, "fade_colors", c.list(func() any { return c.readInt() }))
+	putDefault(m, "has_trail", c.boolean(), false)
+	putDefault(m, "has_twinkle", c.boolean(), false)
+	return m
+}
+
+func (c *codecReader) profile() any {
+	m := map[string]any{}
+	switch kind := c.varInt(); kind {
+	case 0:
+		c.optional(m, "name", func() any { return c.str() })
+		c.optional(m, "id", c.uuid)
+	case 1:
+		m["id"] = c.uuid()
+		m["name"] = c.str()
+	default:
+		c.fail("未知的玩家档案类型 %d", kind)
+		return nil
+	}
+	putList(m, "properties", c.list(func() any {
+		p := map[string]any{"name": c.str(), "value": c.str()}
+		c.optional(p, "signature", func() any { return c.str() })
+		return p
+	}))
+	for _, key := range []string{"texture", "cape", "elytra"} {
+		c.optional(m, key, func() any { return c.str() })
+	}
+	c.optional(m, "model", func() any { return c.enum(modelTypeNames) })
+	return m
+}
+
+// uuid 读取 UUID，codec 中为 4 个 int 组成的数组
+func (c *codecReader) uuid() any {
+	most, least := c.readLong(), c.readLong()
+	return []int32{int32(most >> 32), int32(most), int32(least >> 32), int32(least)}
+}
+
+// nbtValue 把解码后的 NBT 转换为哈希值: 展开异构列表的 {"": v} 包装，
+// 并把 boolKeys 中以 Byte 存储的字段还原为布尔值
+func nbtValue(v any, boolKeys map[string]bool) any {
+	switch val := v.(type) {
+	case map[string]any:
+		if inner, ok := val[""]; ok && len(val) == 1 {
+			return nbtValue(inner, boolKeys)
+		}
+		out := make(map[string]any, len(val))
+		for k, elem := range val {
+			if b, ok := elem.(int8); ok && boolKeys[k] {
+				out[k] = b != 0
+				continue
+			}
+			out[k] = nbtValue(elem, boolKeys)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, elem := range val {
+			out[i] = nbtValue(elem, boolKeys)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,166 @@
+package component
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"sort"
+	"unicode/utf16"
+)
+
+// HashOps 类型标签，与原版 net.minecraft.util.HashOps 一致
+const (
+	hashTagEmpty          byte = 1
+	hashTagMapStart       byte = 2
+	hashTagMapEnd         byte = 3
+	hashTagListStart      byte = 4
+	hashTagListEnd        byte = 5
+	hashTagByte           byte = 6
+	hashTagShort          byte = 7
+	hashTagInt            byte = 8
+	hashTagLong           byte = 9
+	hashTagFloat          byte = 10
+	hashTagDouble         byte = 11
+	hashTagString         byte = 12
+	hashTagBoolean        byte = 13
+	hashTagByteArrayStart byte = 14
+	hashTagByteArrayEnd   byte = 15
+	hashTagIntArrayStart  byte = 16
+	hashTagIntArrayEnd    byte = 17
+	hashTagLongArrayStart byte = 18
+	hashTagLongArrayEnd   byte = 19
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// hasher 对应 Guava Hasher: 多字节数值按小端写入
+type hasher struct {
+	buf []byte
+}
+
+func (h *hasher) putByte(b byte) {
+	h.buf = append(h.buf, b)
+}
+
+func (h *hasher) putShort(v int16) {
+	h.buf = binary.LittleEndian.AppendUint16(h.buf, uint16(v))
+}
+
+func (h *hasher) putInt(v int32) {
+	h.buf = binary.LittleEndian.AppendUint32(h.buf, uint32(v))
+}
+
+func (h *hasher) putLong(v int64) {
+	h.buf = binary.LittleEndian.AppendUint64(h.buf, uint64(v))
+}
+
+// putHash 写入子值的哈希 (HashCode.asBytes，小端)
+func (h *hasher) putHash(v uint32) {
+	h.buf = binary.LittleEndian.AppendUint32(h.buf, v)
+}
+
+func (h *hasher) sum() uint32 {
+	return crc32.Checksum(h.buf, crc32cTable)
+}
+
+// HashValue 按 HashOps 规则计算值的 CRC32C 哈希。支持的 Go 类型:
+//
+//	nil → empty, bool, int8 → byte, int16 → short, int32/int → int, int64 → long,
+//	float32, float64, string, []byte → byte 数组, []int32 → int 数组,
+//	[]int64 → long 数组, []any → list, map[string]any → map
+func HashValue(v any) (uint32, error) {
+	var h hasher
+	switch val := v.(type) {
+	case nil:
+		h.putByte(hashTagEmpty)
+	case bool:
+		h.putByte(hashTagBoolean)
+		if val {
+			h.putByte(1)
+		} else {
+			h.putByte(0)
+		}
+	case int8:
+		h.putByte(hashTagByte)
+		h.putByte(byte(val))
+	case int16:
+		h.putByte(hashTagShort)
+		h.putShort(val)
+	case int32:
+		h.putByte(hashTagInt)
+		h.putInt(val)
+	case int:
+		h.putByte(hashTagInt)
+		h.putInt(int32(val))
+	case int64:
+		h.putByte(hashTagLong)
+		h.putLong(val)
+	case float32:
+		h.putByte(hashTagFloat)
+		h.putInt(int32(math.Float32bits(val)))
+	case float64:
+		h.putByte(hashTagDouble)
+		h.putLong(int64(math.Float64bits(val)))
+	case string:
+		// Java String.length() 与 putUnencodedChars 均按 UTF-16 码元计算
+		units := utf16.Encode([]rune(val))
+		h.putByte(hashTagString)
+		h.putInt(int32(len(units)))
+		for _, u := range units {
+			h.putShort(int16(u))
+		}
+	case []byte:
+		h.putByte(hashTagByteArrayStart)
+		h.buf = append(h.buf, val...)
+		h.putByte(hashTagByteArrayEnd)
+	case []int32:
+		h.putByte(hashTagIntArrayStart)
+		for _, n := range val {
+			h.putInt(n)
+		}
+		h.putByte(hashTagIntArrayEnd)
+	case []int64:
+		h.putByte(hashTagLongArrayStart)
+		for _, n := range val {
+			h.putLong(n)
+		}
+		h.putByte(hashTagLongArrayEnd)
+	case []any:
+		h.putByte(hashTagListStart)
+		for i, elem := range val {
+			sub, err := HashValue(elem)
+			if err != nil {
+				return 0, fmt.Errorf("[%d]: %w", i, err)
+			}
+			h.putHash(sub)
+		}
+		h.putByte(hashTagListEnd)
+	case map[string]any:
+		entries := make([][2]uint32, 0, len(val))
+		for key, elem := range val {
+			k, _ := HashValue(key)
+			sub, err := HashValue(elem)
+			if err != nil {
+				return 0, fmt.Errorf("%s: %w", key, err)
+			}
+			entries = append(entries, [2]uint32{k, sub})
+		}
+		// 条目按 (键哈希, 值哈希) 的无符号值排序
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i][0] != entries[j][0] {
+				return entries[i][0] < entries[j][0]
+			}
+			return entries[i][1] < entries[j][1]
+		})
+		h.putByte(hashTagMapStart)
+		for _, e := range entries {
+			h.putHash(e[0])
+			h.putHash(e[1])
+		}
+		h.putByte(hashTagMapEnd)
+	default:
+		return 0, fmt.Errorf("不支持哈希的类型 %T", v)
+	}
+	return h.sum(), nil
+}
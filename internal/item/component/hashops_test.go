@@ -0,0 +1,140 @@
+package component
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+
+	"gmcc/internal/registry"
+)
+
+func crc32c(data []byte) uint32 {
+	return crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))
+}
+
+func le32(v uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, v)
+}
+
+func mustHash(t *testing.T, v any) uint32 {
+	t.Helper()
+	h, err := HashValue(v)
+	if err != nil {
+		t.Fatalf("HashValue(%v) error = %v", v, err)
+	}
+	return h
+}
+
+func TestHasher_CRC32CCheckValue(t *testing.T) {
+	// CRC-32C 标准校验值
+	h := hasher{buf: []byte("123456789")}
+	if got := h.sum(); got != 0xE3069283 {
+		t.Errorf("crc32c(123456789) = %08x, want e3069283", got)
+	}
+}
+
+func TestHashValue_Primitives(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  []byte
+	}{
+		{"empty", nil, []byte{1}},
+		{"true", true, []byte{13, 1}},
+		{"byte", int8(-1), []byte{6, 0xFF}},
+		{"short", int16(0x0102), []byte{7, 0x02, 0x01}},
+		{"int", int32(1), []byte{8, 1, 0, 0, 0}},
+		{"long", int64(1), []byte{9, 1, 0, 0, 0, 0, 0, 0, 0}},
+		{"float", float32(1), []byte{10, 0x00, 0x00, 0x80, 0x3F}},
+		{"string", "ab", []byte{12, 2, 0, 0, 0, 'a', 0, 'b', 0}},
+		{"utf16", "中", []byte{12, 1, 0, 0, 0, 0x2D, 0x4E}},
+		{"int_array", []int32{1}, []byte{16, 1, 0, 0, 0, 17}},
+		{"empty_list", []any{}, []byte{4, 5}},
+		{"empty_map", map[string]any{}, []byte{2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := mustHash(t, tt.value), crc32c(tt.want); got != want {
+				t.Errorf("HashValue(%v) = %08x, want %08x", tt.value, got, want)
+			}
+		})
+	}
+}
+
+func TestHashValue_MapEntriesSortedByHash(t *testing.T) {
+	m := map[string]any{"a": int32(1), "b": int32(2), "c": "x"}
+
+	type entry struct{ k, v uint32 }
+	entries := []entry{
+		{mustHash(t, "a"), mustHash(t, int32(1))},
+		{mustHash(t, "b"), mustHash(t, int32(2))},
+		{mustHash(t, "c"), mustHash(t, "x")},
+	}
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			if entries[j].k < entries[i].k {
+				entries[i], entries[j] = entries[j], entries[i]
+			}
+		}
+	}
+	want := []byte{2}
+	for _, e := range entries {
+		want = append(want, le32(e.k)...)
+		want = append(want, le32(e.v)...)
+	}
+	want = append(want, 3)
+
+	if got := mustHash(t, m); got != crc32c(want) {
+		t.Errorf("map 哈希 = %08x, want %08x", got, crc32c(want))
+	}
+}
+
+// TestHashComponent 的期望值不经过 HashValue: 按原版 HashOps 布局 (标签字节、小端数值、
+// UTF-16 字符串、按键/值哈希排序的 map) 手工拼出字节流，在 Go 之外独立计算 CRC32C 得到。
+// 它们不是从原版客户端抓取的 container_click
+func TestHashComponent(t *testing.T) {
+	registry.SetDynamicEntries("minecraft:enchantment", []string{"minecraft:aqua_affinity", "minecraft:sharpness"})
+	defer registry.ClearDynamicEntries()
+
+	tests := []struct {
+		name   string
+		typeID int32
+		raw    []byte
+		want   uint32
+	}{
+		{"damage", Damage, []byte{0x05}, 0x2672E6EF},
+		{"unbreakable", Unbreakable, nil, 0xC574B4C8},
+		{"rarity", Rarity, []byte{0x03}, 0xEE8D827D},
+		// {"minecraft:sharpness": 5}
+		{"enchantments", Enchantments, []byte{0x01, 0x01, 0x05}, 0x66A963EF},
+		// {"minecraft:sharpness": 5, "minecraft:aqua_affinity": 1}
+		{"enchantments_two", Enchantments, []byte{0x02, 0x01, 0x05, 0x00, 0x01}, 0xC3944B83},
+		// 无样式的纯文本折叠为字符串
+		{"custom_name_plain", CustomName, []byte{0x08, 0x00, 0x02, 'h', 'i'}, 0xCBCCF40A},
+		{"custom_name_utf16", CustomName, append([]byte{0x08, 0x00, 0x09}, "钻石剑"...), 0xE0118EFC},
+		// can_always_eat 为默认值 false 时省略
+		{"food_default_omitted", Food, []byte{0x04, 0x3F, 0x80, 0x00, 0x00, 0x00}, 0x88F2AA1A},
+		{"food_can_always_eat", Food, []byte{0x04, 0x40, 0x19, 0x99, 0x9A, 0x01}, 0xE2FD3A03},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HashComponent(tt.typeID, tt.raw)
+			if err != nil {
+				t.Fatalf("HashComponent() error = %v", err)
+			}
+			if uint32(got) != tt.want {
+				t.Errorf("HashComponent() = %08x, want %08x", uint32(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestHashComponent_Errors(t *testing.T) {
+	registry.ClearDynamicEntries()
+	if _, err := HashComponent(Enchantments, []byte{0x01, 0x07, 0x01}); err == nil {
+		t.Error("未知附魔应返回错误")
+	}
+	if _, err := HashComponent(Damage, []byte{0x05, 0x00}); err == nil {
+		t.Error("多余字节应返回错误")
+	}
+}
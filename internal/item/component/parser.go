@@ -9,8 +9,9 @@ import (
 
 // ComponentResult 组件解析结果
 type ComponentResult struct {
-	TypeID int32 // 组件类型ID
-	Data   any   // 解析后的数据（当前阶段可能为nil）
+	TypeID int32  // 组件类型ID
+	Data   any    // 解析后的数据（仅跳过的组件为nil）
+	Raw    []byte // 组件的原始网络字节，用于比较与重新编码
 }
 
 // ComponentHandler 组件处理器函数类型
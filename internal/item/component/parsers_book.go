@@ -0,0 +1,99 @@
+package component
+
+import (
+	"bytes"
+	"fmt"
+
+	"gmcc/internal/mcclient/chat"
+	"gmcc/internal/mcclient/packet"
+)
+
+// 成书的版本
+const (
+	BookOriginal = iota
+	BookCopyOfOriginal
+	BookCopyOfCopy
+	BookTattered
+)
+
+// WritableBook writable_book_content 组件 (书与笔)
+type WritableBook struct {
+	Pages []string
+}
+
+// WrittenBook written_book_content 组件 (成书)
+type WrittenBook struct {
+	Title      string
+	Author     string
+	Generation int32
+	Pages      []*chat.TextComponent
+	Resolved   bool
+}
+
+// ParseWritableBookContent 解析 writable_book_content 组件 (ID: 52)
+func ParseWritableBookContent(typeID int32, r *bytes.Reader) (*ComponentResult, error) {
+	count, err := readListLen(r)
+	if err != nil {
+		return nil, err
+	}
+	book := &WritableBook{Pages: make([]string, 0, count)}
+	for i := int32(0); i < count; i++ {
+		page, err := readFilterable(r, func() (string, error) { return packet.ReadStringFromReader(r) })
+		if err != nil {
+			return nil, fmt.Errorf("解析第 %d 页失败: %w", i, err)
+		}
+		book.Pages = append(book.Pages, page)
+	}
+	return &ComponentResult{TypeID: typeID, Data: book}, nil
+}
+
+// ParseWrittenBookContent 解析 written_book_content 组件 (ID: 53)
+func ParseWrittenBookContent(typeID int32, r *bytes.Reader) (*ComponentResult, error) {
+	var book WrittenBook
+	var err error
+	if book.Title, err = readFilterable(r, func() (string, error) { return packet.ReadStringFromReader(r) }); err != nil {
+		return nil, fmt.Errorf("解析书名失败: %w", err)
+	}
+	if book.Author, err = packet.ReadStringFromReader(r); err != nil {
+		return nil, fmt.Errorf("解析作者失败: %w", err)
+	}
+	if book.Generation, err = readVarInt(r); err != nil {
+		return nil, fmt.Errorf("解析版本失败: %w", err)
+	}
+
+	count, err := readListLen(r)
+	if err != nil {
+		return nil, err
+	}
+	book.Pages = make([]*chat.TextComponent, 0, count)
+	for i := int32(0); i < count; i++ {
+		page, err := readFilterable(r, func() (*chat.TextComponent, error) { return ParseTextComponent(r) })
+		if err != nil {
+			return nil, fmt.Errorf("解析第 %d 页失败: %w", i, err)
+		}
+		book.Pages = append(book.Pages, page)
+	}
+
+	if book.Resolved, err = packet.ReadBoolFromReader(r); err != nil {
+		return nil, fmt.Errorf("解析 resolved 失败: %w", err)
+	}
+	return &ComponentResult{TypeID: typeID, Data: &book}, nil
+}
+
+// readFilterable 读取 Filterable<T>: 原文 + 可选的过滤后文本，只保留原文
+func readFilterable[T any](r *bytes.Reader, read func() (T, error)) (T, error) {
+	raw, err := read()
+	if err != nil {
+		return raw, err
+	}
+	hasFiltered, err := packet.ReadBoolFromReader(r)
+	if err != nil {
+		return raw, err
+	}
+	if hasFiltered {
+		if _, err := read(); err != nil {
+			return raw, err
+		}
+	}
+	return raw, nil
+}
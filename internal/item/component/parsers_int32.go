@@ -2,6 +2,7 @@ package component
 
 import (
 	"bytes"
+	"fmt"
 
 	"gmcc/internal/mcclient/packet"
 )
@@ -18,18 +19,77 @@ func ParseInt32(typeID int32, r *bytes.Reader) (*ComponentResult, error) {
 	}, nil
 }
 
-// ParseCustomModelData 解析 custom_model_data 组件 (ID: 17, Int32)
+// CustomModelDataValue custom_model_data 组件内容
+type CustomModelDataValue struct {
+	Floats  []float32
+	Flags   []bool
+	Strings []string
+	Colors  []int32
+}
+
+// ParseCustomModelData 解析 custom_model_data 组件 (ID: 17)
+// 格式: 四个 VarInt 前缀列表 (float、bool、string、int 颜色)
 func ParseCustomModelData(typeID int32, r *bytes.Reader) (*ComponentResult, error) {
-	value, err := packet.ReadInt32FromReader(r)
+	var data CustomModelDataValue
+	n, err := readListLen(r)
 	if err != nil {
 		return nil, err
 	}
+	for i := int32(0); i < n; i++ {
+		v, err := packet.ReadFloat32FromReader(r)
+		if err != nil {
+			return nil, err
+		}
+		data.Floats = append(data.Floats, v)
+	}
+	if n, err = readListLen(r); err != nil {
+		return nil, err
+	}
+	for i := int32(0); i < n; i++ {
+		v, err := packet.ReadBoolFromReader(r)
+		if err != nil {
+			return nil, err
+		}
+		data.Flags = append(data.Flags, v)
+	}
+	if n, err = readListLen(r); err != nil {
+		return nil, err
+	}
+	for i := int32(0); i < n; i++ {
+		v, err := packet.ReadStringFromReader(r)
+		if err != nil {
+			return nil, err
+		}
+		data.Strings = append(data.Strings, v)
+	}
+	if n, err = readListLen(r); err != nil {
+		return nil, err
+	}
+	for i := int32(0); i < n; i++ {
+		v, err := packet.ReadInt32FromReader(r)
+		if err != nil {
+			return nil, err
+		}
+		data.Colors = append(data.Colors, v)
+	}
 	return &ComponentResult{
 		TypeID: typeID,
-		Data:   value,
+		Data:   data,
 	}, nil
 }
 
+// readListLen 读取列表长度并做基本校验
+func readListLen(r *bytes.Reader) (int32, error) {
+	n, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || int(n) > r.Len() {
+		return 0, fmt.Errorf("列表长度无效: %d", n)
+	}
+	return n, nil
+}
+
 // ParseDyedColor 解析 dyed_color 组件 (ID: 42, Int32)
 func ParseDyedColor(typeID int32, r *bytes.Reader) (*ComponentResult, error) {
 	value, err := packet.ReadInt32FromReader(r)
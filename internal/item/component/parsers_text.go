@@ -3,9 +3,12 @@ package component
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 
 	"gmcc/internal/mcclient/chat"
+	"gmcc/internal/mcclient/packet"
 	"gmcc/internal/nbt"
+	"gmcc/internal/registry"
 )
 
 // ParseTextComponent 解析文本组件 (NBT格式)
@@ -13,10 +16,14 @@ func ParseTextComponent(r *bytes.Reader) (*chat.TextComponent, error) {
 	dec := nbt.NewDecoder(r)
 	dec.NetworkFormat(true)
 
-	var nbtData map[string]any
+	var nbtData any
 	if err := dec.Decode(&nbtData); err != nil {
 		return nil, err
 	}
+	// 纯文本可能直接编码为 String 标签
+	if text, ok := nbtData.(string); ok {
+		return &chat.TextComponent{Text: text}, nil
+	}
 
 	jsonBytes, err := json.Marshal(nbtData)
 	if err != nil {
@@ -31,29 +38,23 @@ func ParseTextComponent(r *bytes.Reader) (*chat.TextComponent, error) {
 	return &tc, nil
 }
 
-// ParseTextComponentList 解析文本组件列表 (lore组件)
+// ParseTextComponentList 解析文本组件列表 (lore组件: VarInt 数量 + 逐个 NBT 文本)
 func ParseTextComponentList(r *bytes.Reader) ([]*chat.TextComponent, error) {
-	dec := nbt.NewDecoder(r)
-	dec.NetworkFormat(true)
-
-	var list []any
-	if err := dec.Decode(&list); err != nil {
+	count, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
 		return nil, err
 	}
+	if count < 0 || int(count) > r.Len() {
+		return nil, fmt.Errorf("文本列表长度无效: %d", count)
+	}
 
-	result := make([]*chat.TextComponent, 0, len(list))
-	for _, item := range list {
-		jsonBytes, err := json.Marshal(item)
+	result := make([]*chat.TextComponent, 0, count)
+	for i := int32(0); i < count; i++ {
+		tc, err := ParseTextComponent(r)
 		if err != nil {
-			continue
-		}
-
-		var tc chat.TextComponent
-		if err := json.Unmarshal(jsonBytes, &tc); err != nil {
-			continue
+			return nil, fmt.Errorf("解析第 %d 行文本失败: %w", i, err)
 		}
-
-		result = append(result, &tc)
+		result = append(result, tc)
 	}
 
 	return result, nil
@@ -131,24 +132,35 @@ func ParseVarInt(r *bytes.Reader) (*ComponentResult, error) {
 
 // EnchantmentEntry 附魔条目结构
 type EnchantmentEntry struct {
-	ID    string
+	ID    int32  // minecraft:enchantment 注册表网络 ID
+	Name  string // 注册表条目名，如 minecraft:sharpness (注册表未知时为空)
 	Level int32
 }
 
-// ParseEnchantments 解析 enchantments 组件 (ID: 13)
+// ParseEnchantments 解析 enchantments / stored_enchantments 组件 (ID: 13, 41)
+// 格式: VarInt 数量 + (VarInt 附魔ID, VarInt 等级)
 func ParseEnchantments(typeID int32, r *bytes.Reader) (*ComponentResult, error) {
-	dec := nbt.NewDecoder(r)
-	dec.NetworkFormat(true)
-
-	var enchantments map[string]int32
-	if err := dec.Decode(&enchantments); err != nil {
+	count, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
 		return nil, err
 	}
+	if count < 0 || int(count) > r.Len() {
+		return nil, fmt.Errorf("附魔列表长度无效: %d", count)
+	}
 
-	result := make([]EnchantmentEntry, 0, len(enchantments))
-	for id, level := range enchantments {
+	result := make([]EnchantmentEntry, 0, count)
+	for i := int32(0); i < count; i++ {
+		id, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			return nil, err
+		}
+		level, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			return nil, err
+		}
 		result = append(result, EnchantmentEntry{
 			ID:    id,
+			Name:  registry.DynamicEntryName("minecraft:enchantment", id),
 			Level: level,
 		})
 	}
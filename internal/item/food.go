@@ -0,0 +1,136 @@
+package item
+
+import (
+	"slices"
+
+	"gmcc/internal/item/component"
+	"gmcc/internal/registry"
+)
+
+// DefaultConsumeSeconds consumable 组件的默认食用时长
+const DefaultConsumeSeconds = 1.6
+
+// FoodInfo 描述食物的营养与食用方式
+type FoodInfo struct {
+	Nutrition      int32
+	Saturation     float32 // 食用后增加的饱和度 (绝对值)
+	ConsumeSeconds float32
+	CanAlwaysEat   bool // 饱食度满时仍可食用
+	Harmful        bool // 食用后可能获得负面效果或随机传送
+}
+
+// 原版食物的默认 food/consumable 组件，默认组件不随网络下发，只能在此维护
+var defaultFoods = map[string]FoodInfo{
+	"apple":                  {Nutrition: 4, Saturation: 2.4},
+	"baked_potato":           {Nutrition: 5, Saturation: 6.0},
+	"beef":                   {Nutrition: 3, Saturation: 1.8},
+	"beetroot":               {Nutrition: 1, Saturation: 1.2},
+	"beetroot_soup":          {Nutrition: 6, Saturation: 7.2},
+	"bread":                  {Nutrition: 5, Saturation: 6.0},
+	"carrot":                 {Nutrition: 3, Saturation: 3.6},
+	"chicken":                {Nutrition: 2, Saturation: 1.2, Harmful: true},
+	"chorus_fruit":           {Nutrition: 4, Saturation: 2.4, CanAlwaysEat: true, Harmful: true},
+	"cod":                    {Nutrition: 2, Saturation: 0.4},
+	"cooked_beef":            {Nutrition: 8, Saturation: 12.8},
+	"cooked_chicken":         {Nutrition: 6, Saturation: 7.2},
+	"cooked_cod":             {Nutrition: 5, Saturation: 6.0},
+	"cooked_mutton":          {Nutrition: 6, Saturation: 9.6},
+	"cooked_porkchop":        {Nutrition: 8, Saturation: 12.8},
+	"cooked_rabbit":          {Nutrition: 5, Saturation: 6.0},
+	"cooked_salmon":          {Nutrition: 6, Saturation: 9.6},
+	"cookie":                 {Nutrition: 2, Saturation: 0.4},
+	"dried_kelp":             {Nutrition: 1, Saturation: 0.6, ConsumeSeconds: 0.8},
+	"enchanted_golden_apple": {Nutrition: 4, Saturation: 9.6, CanAlwaysEat: true},
+	"glow_berries":           {Nutrition: 2, Saturation: 0.4},
+	"golden_apple":           {Nutrition: 4, Saturation: 9.6, CanAlwaysEat: true},
+	"golden_carrot":          {Nutrition: 6, Saturation: 14.4},
+	"honey_bottle":           {Nutrition: 6, Saturation: 1.2, ConsumeSeconds: 2.0},
+	"melon_slice":            {Nutrition: 2, Saturation: 1.2},
+	"mushroom_stew":          {Nutrition: 6, Saturation: 7.2},
+	"mutton":                 {Nutrition: 2, Saturation: 1.2},
+	"poisonous_potato":       {Nutrition: 2, Saturation: 1.2, Harmful: true},
+	"porkchop":               {Nutrition: 3, Saturation: 1.8},
+	"potato":                 {Nutrition: 1, Saturation: 0.6},
+	"pufferfish":             {Nutrition: 1, Saturation: 0.2, Harmful: true},
+	"pumpkin_pie":            {Nutrition: 8, Saturation: 4.8},
+	"rabbit":                 {Nutrition: 3, Saturation: 1.8},
+	"rabbit_stew":            {Nutrition: 10, Saturation: 12.0},
+	"rotten_flesh":           {Nutrition: 4, Saturation: 0.8, Harmful: true},
+	"salmon":                 {Nutrition: 2, Saturation: 0.4},
+	"spider_eye":             {Nutrition: 2, Saturation: 3.2, Harmful: true},
+	// 效果由 suspicious_stew_effects 决定，无法预知
+	"suspicious_stew": {Nutrition: 6, Saturation: 7.2, CanAlwaysEat: true, Harmful: true},
+	"sweet_berries":   {Nutrition: 2, Saturation: 0.4},
+	"tropical_fish":   {Nutrition: 1, Saturation: 0.2},
+}
+
+// harmfulEffects 原版分类为 HARMFUL 的状态效果
+var harmfulEffects = map[string]bool{
+	"minecraft:slowness": true, "minecraft:mining_fatigue": true, "minecraft:instant_damage": true,
+	"minecraft:nausea": true, "minecraft:blindness": true, "minecraft:hunger": true,
+	"minecraft:weakness": true, "minecraft:poison": true, "minecraft:wither": true,
+	"minecraft:levitation": true, "minecraft:unluck": true, "minecraft:darkness": true,
+	"minecraft:wind_charged": true, "minecraft:weaving": true, "minecraft:oozing": true,
+	"minecraft:infested": true,
+}
+
+// Food 返回物品的食物信息，组件覆盖优先于原版默认值。不可食用时返回 false。
+func (s *ItemStack) Food() (FoodInfo, bool) {
+	if s.IsEmpty() {
+		return FoodInfo{}, false
+	}
+	if slices.Contains(s.Removed, component.Food) || slices.Contains(s.Removed, component.Consumable) {
+		return FoodInfo{}, false
+	}
+
+	var info FoodInfo
+	ok := false
+	if def := registry.GetItemRegistry().GetByID(s.ID); def != nil {
+		info, ok = defaultFoods[def.Name]
+	}
+	if info.ConsumeSeconds == 0 {
+		info.ConsumeSeconds = DefaultConsumeSeconds
+	}
+
+	if c, found := s.Components[component.Food]; found {
+		if v, err := component.DecodeComponent(component.Food, c.Raw); err == nil {
+			m, _ := v.(map[string]any)
+			info.Nutrition, _ = m["nutrition"].(int32)
+			info.Saturation, _ = m["saturation"].(float32)
+			info.CanAlwaysEat, _ = m["can_always_eat"].(bool)
+			ok = true
+		}
+	}
+	if c, found := s.Components[component.Consumable]; found && ok {
+		if v, err := component.DecodeComponent(component.Consumable, c.Raw); err == nil {
+			m, _ := v.(map[string]any)
+			info.ConsumeSeconds = DefaultConsumeSeconds
+			if secs, found := m["consume_seconds"].(float32); found {
+				info.ConsumeSeconds = secs
+			}
+			info.Harmful = hasHarmfulConsumeEffect(m["on_consume_effects"])
+		}
+	}
+	return info, ok
+}
+
+// hasHarmfulConsumeEffect 检查 on_consume_effects 中是否有负面效果或随机传送
+func hasHarmfulConsumeEffect(v any) bool {
+	effects, _ := v.([]any)
+	for _, e := range effects {
+		m, _ := e.(map[string]any)
+		switch m["type"] {
+		case "minecraft:teleport_randomly":
+			return true
+		case "minecraft:apply_effects":
+			list, _ := m["effects"].([]any)
+			for _, effect := range list {
+				em, _ := effect.(map[string]any)
+				if id, _ := em["id"].(string); harmfulEffects[id] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package item
+
+import (
+	"testing"
+
+	"gmcc/internal/item/component"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/registry"
+)
+
+func TestItemStack_Food(t *testing.T) {
+	reg := registry.GetItemRegistry()
+	tests := []struct {
+		name   string
+		want   FoodInfo
+		edible bool
+	}{
+		{"cooked_beef", FoodInfo{Nutrition: 8, Saturation: 12.8, ConsumeSeconds: DefaultConsumeSeconds}, true},
+		{"dried_kelp", FoodInfo{Nutrition: 1, Saturation: 0.6, ConsumeSeconds: 0.8}, true},
+		{"rotten_flesh", FoodInfo{Nutrition: 4, Saturation: 0.8, ConsumeSeconds: DefaultConsumeSeconds, Harmful: true}, true},
+		{"dirt", FoodInfo{}, false},
+	}
+	for _, tt := range tests {
+		stack := &ItemStack{ID: reg.NameToID(tt.name), Count: 1}
+		got, ok := stack.Food()
+		if ok != tt.edible || (ok && got != tt.want) {
+			t.Errorf("%s Food() = %+v, %v, want %+v, %v", tt.name, got, ok, tt.want, tt.edible)
+		}
+	}
+
+	var empty *ItemStack
+	if _, ok := empty.Food(); ok {
+		t.Error("空槽位不应可食用")
+	}
+}
+
+func TestItemStack_FoodComponents(t *testing.T) {
+	// 能吃的泥土: 饥饿值 3，饱和度 1.5，食用 0.5 秒，100% 中毒
+	var food []byte
+	food = append(food, packet.EncodeVarInt(3)...)
+	food = append(food, packet.EncodeFloat32(1.5)...)
+	food = append(food, packet.EncodeBool(false)...)
+
+	var consumable []byte
+	consumable = append(consumable, packet.EncodeFloat32(0.5)...)
+	consumable = append(consumable, packet.EncodeVarInt(1)...) // eat
+	consumable = append(consumable, packet.EncodeVarInt(0)...) // 内联音效
+	consumable = append(consumable, packet.EncodeString("minecraft:entity.generic.eat")...)
+	consumable = append(consumable, packet.EncodeBool(false)...)
+	consumable = append(consumable, packet.EncodeBool(true)...)
+	consumable = append(consumable, packet.EncodeVarInt(1)...)  // 1 个效果
+	consumable = append(consumable, packet.EncodeVarInt(0)...)  // apply_effects
+	consumable = append(consumable, packet.EncodeVarInt(1)...)  // 1 个状态效果
+	consumable = append(consumable, packet.EncodeVarInt(18)...) // minecraft:poison
+	consumable = append(consumable, packet.EncodeVarInt(0)...)
+	consumable = append(consumable, packet.EncodeVarInt(100)...)
+	consumable = append(consumable, packet.EncodeBool(false)...)
+	consumable = append(consumable, packet.EncodeBool(true)...)
+	consumable = append(consumable, packet.EncodeBool(true)...)
+	consumable = append(consumable, packet.EncodeBool(false)...)
+	consumable = append(consumable, packet.EncodeFloat32(1)...)
+
+	dirt := registry.GetItemRegistry().NameToID("dirt")
+	stack := &ItemStack{ID: dirt, Count: 1, Components: map[int32]*component.ComponentResult{
+		component.Food:       {TypeID: component.Food, Raw: food},
+		component.Consumable: {TypeID: component.Consumable, Raw: consumable},
+	}}
+	want := FoodInfo{Nutrition: 3, Saturation: 1.5, ConsumeSeconds: 0.5, Harmful: true}
+	if got, ok := stack.Food(); !ok || got != want {
+		t.Errorf("Food() = %+v, %v, want %+v", got, ok, want)
+	}
+
+	bread := registry.GetItemRegistry().NameToID("bread")
+	removed := &ItemStack{ID: bread, Count: 1, Removed: []int32{component.Consumable}}
+	if _, ok := removed.Food(); ok {
+		t.Error("移除 consumable 后不应可食用")
+	}
+}
@@ -0,0 +1,433 @@
+package item
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gmcc/internal/i18n"
+	"gmcc/internal/item/component"
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/chat"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/registry"
+)
+
+func init() {
+	// 容器、收纳袋等组件中的嵌套物品也解析为 ItemStack
+	component.SetStackReader(func(r *bytes.Reader) (any, error) {
+		return ReadItemStack(r)
+	})
+}
+
+// ItemStack 表示一个物品堆叠: 注册表ID、数量以及解码后的数据组件
+type ItemStack struct {
+	ID         int32                                // 物品注册表ID
+	Count      int32                                // 数量
+	Components map[int32]*component.ComponentResult // 相对默认值新增/覆盖的组件
+	Removed    []int32                              // 相对默认值移除的组件类型
+}
+
+// ReadItemStack 从 Reader 读取物品槽数据，空槽位返回 nil
+func ReadItemStack(r *bytes.Reader) (*ItemStack, error) {
+	// 读取数量 (VarInt)
+	count, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, nil // 空物品
+	}
+
+	// 读取物品ID (VarInt)
+	itemID, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	stack := &ItemStack{ID: itemID, Count: count}
+	if err := stack.readComponents(r); err != nil {
+		logx.Warnf("Slot组件解析失败: itemID=%d, count=%d, err=%v", itemID, count, err)
+		return nil, err
+	}
+	return stack, nil
+}
+
+// ReadItemCost 读取交易的 ItemCost: 物品ID、数量以及必须完全匹配的组件
+func ReadItemCost(r *bytes.Reader) (*ItemStack, error) {
+	itemID, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read cost item: %w", err)
+	}
+	count, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read cost count: %w", err)
+	}
+	n, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read cost component count: %w", err)
+	}
+
+	stack := &ItemStack{ID: itemID, Count: count}
+	if err := stack.readAddedComponents(r, n); err != nil {
+		return nil, err
+	}
+	return stack, nil
+}
+
+// readComponents 读取物品组件列表，同时保留每个组件的原始字节
+func (s *ItemStack) readComponents(r *bytes.Reader) error {
+	// 读取添加的组件数量
+	numAdd, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("read add component count: %w", err)
+	}
+
+	// 读取移除的组件数量
+	numRemove, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("read remove component count: %w", err)
+	}
+
+	if err := s.readAddedComponents(r, numAdd); err != nil {
+		return err
+	}
+
+	// 读取移除的组件
+	for i := int32(0); i < numRemove; i++ {
+		typeID, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			return fmt.Errorf("read removed component %d: %w", i, err)
+		}
+		s.Removed = append(s.Removed, typeID)
+	}
+
+	return nil
+}
+
+// readAddedComponents 读取 n 个 (类型ID, 数据) 组件
+func (s *ItemStack) readAddedComponents(r *bytes.Reader, n int32) error {
+	if n > 0 {
+		s.Components = make(map[int32]*component.ComponentResult, n)
+	}
+
+	// 从池获取解析器
+	parser := component.Acquire()
+	defer component.Release(parser)
+
+	// 解析添加的组件
+	for i := int32(0); i < n; i++ {
+		// 读取组件类型ID
+		typeID, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			return fmt.Errorf("read component type %d: %w", i, err)
+		}
+
+		start := r.Size() - int64(r.Len())
+		result, err := parser.ParseComponent(typeID, r)
+		if err != nil {
+			return fmt.Errorf("parse component %d: %w", typeID, err)
+		}
+		result.TypeID = typeID
+		result.Raw, err = sliceFrom(r, start)
+		if err != nil {
+			return fmt.Errorf("copy component %d: %w", typeID, err)
+		}
+
+		s.Components[typeID] = result
+	}
+	return nil
+}
+
+// sliceFrom 返回从 start 到当前位置之间的字节副本
+func sliceFrom(r *bytes.Reader, start int64) ([]byte, error) {
+	end := r.Size() - int64(r.Len())
+	raw := make([]byte, end-start)
+	if _, err := r.ReadAt(raw, start); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// IsEmpty 检查槽位是否为空
+func (s *ItemStack) IsEmpty() bool {
+	return s == nil || s.ID == 0 || s.Count <= 0
+}
+
+// Clone 复制物品 (组件数据只读，共享引用)
+func (s *ItemStack) Clone() *ItemStack {
+	if s.IsEmpty() {
+		return nil
+	}
+	c := *s
+	if s.Components != nil {
+		c.Components = make(map[int32]*component.ComponentResult, len(s.Components))
+		for k, v := range s.Components {
+			c.Components[k] = v
+		}
+	}
+	c.Removed = slices.Clone(s.Removed)
+	return &c
+}
+
+// WithCount 返回数量改变后的副本，count<=0 时返回 nil
+func (s *ItemStack) WithCount(count int32) *ItemStack {
+	if s.IsEmpty() || count <= 0 {
+		return nil
+	}
+	c := s.Clone()
+	c.Count = count
+	return c
+}
+
+// SameItem 判断两个物品能否堆叠 (物品ID与组件完全一致)
+func (s *ItemStack) SameItem(o *ItemStack) bool {
+	if s.IsEmpty() || o.IsEmpty() || s.ID != o.ID {
+		return false
+	}
+	if len(s.Components) != len(o.Components) || len(s.Removed) != len(o.Removed) {
+		return false
+	}
+	for typeID, c := range s.Components {
+		oc, ok := o.Components[typeID]
+		if !ok || !bytes.Equal(c.Raw, oc.Raw) {
+			return false
+		}
+	}
+	a, b := slices.Clone(s.Removed), slices.Clone(o.Removed)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// ComponentHashes 计算各组件的 HashOps 哈希 (container_click 的哈希堆叠使用)。
+// 部分组件无法计算时仍返回其余组件的结果，并附带错误。
+func (s *ItemStack) ComponentHashes() (map[int32]int32, error) {
+	if s.IsEmpty() {
+		return nil, nil
+	}
+	hashes := make(map[int32]int32, len(s.Components))
+	var errs []error
+	for typeID, c := range s.Components {
+		h, err := component.HashComponent(typeID, c.Raw)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		hashes[typeID] = h
+	}
+	return hashes, errors.Join(errs...)
+}
+
+// Component 返回指定组件的解析数据
+func (s *ItemStack) Component(typeID int32) (any, bool) {
+	if s == nil {
+		return nil, false
+	}
+	c, ok := s.Components[typeID]
+	if !ok {
+		return nil, false
+	}
+	return c.Data, true
+}
+
+// Name 返回带命名空间的物品ID，如 minecraft:diamond_sword
+func (s *ItemStack) Name() string {
+	if s.IsEmpty() {
+		return ""
+	}
+	if info := registry.GetItemRegistry().GetByID(s.ID); info != nil {
+		return "minecraft:" + info.Name
+	}
+	return fmt.Sprintf("minecraft:unknown_%d", s.ID)
+}
+
+// IDToString 同 Name，保留给旧调用方
+func (s *ItemStack) IDToString() string {
+	return s.Name()
+}
+
+// DisplayName 返回显示名称: custom_name > item_name > 本地化名称
+func (s *ItemStack) DisplayName() string {
+	if s.IsEmpty() {
+		return ""
+	}
+	for _, typeID := range []int32{component.CustomName, component.ItemName} {
+		if v, ok := s.Component(typeID); ok {
+			if tc, ok := v.(*chat.TextComponent); ok && tc != nil {
+				if text := tc.ToPlain(); text != "" {
+					return text
+				}
+			}
+		}
+	}
+	return registry.GetItemRegistry().LocalizedName(s.ID)
+}
+
+// HasCustomName 是否被铁砧等方式重命名
+func (s *ItemStack) HasCustomName() bool {
+	_, ok := s.Component(component.CustomName)
+	return ok
+}
+
+// Lore 返回物品描述的纯文本行
+func (s *ItemStack) Lore() []string {
+	v, ok := s.Component(component.Lore)
+	if !ok {
+		return nil
+	}
+	list, _ := v.([]*chat.TextComponent)
+	lines := make([]string, 0, len(list))
+	for _, tc := range list {
+		lines = append(lines, tc.ToPlain())
+	}
+	return lines
+}
+
+// MaxStackSize 返回最大堆叠数 (组件覆盖优先)
+func (s *ItemStack) MaxStackSize() int32 {
+	if s.IsEmpty() {
+		return 64
+	}
+	if v, ok := s.Component(component.MaxStackSize); ok {
+		if n, ok := v.(int32); ok && n > 0 {
+			return n
+		}
+	}
+	if info := registry.GetItemRegistry().GetByID(s.ID); info != nil && info.StackSize > 0 {
+		return info.StackSize
+	}
+	return 64
+}
+
+// EquipmentSlot 装备槽位，取值与 equippable 组件的 slot 一致
+type EquipmentSlot int32
+
+const (
+	EquipMainhand EquipmentSlot = iota
+	EquipFeet
+	EquipLegs
+	EquipChest
+	EquipHead
+	EquipOffhand
+	EquipBody
+	EquipSaddle
+)
+
+// IsHumanoidArmor 是否为玩家的盔甲槽位 (脚、腿、胸、头)
+func (e EquipmentSlot) IsHumanoidArmor() bool {
+	return e >= EquipFeet && e <= EquipHead
+}
+
+// EquipmentSlot 返回物品穿戴的槽位，不可穿戴时为主手。
+// 服务端只下发与默认值不同的组件，原版盔甲、头颅等按物品名补全
+func (s *ItemStack) EquipmentSlot() EquipmentSlot {
+	if s.IsEmpty() || slices.Contains(s.Removed, component.Equippable) {
+		return EquipMainhand
+	}
+	if c, ok := s.Components[component.Equippable]; ok {
+		// equippable 的第一个字段是 slot (VarInt，取值都小于 128)
+		if len(c.Raw) > 0 && c.Raw[0] <= byte(EquipSaddle) {
+			return EquipmentSlot(c.Raw[0])
+		}
+		return EquipMainhand
+	}
+	name := strings.TrimPrefix(s.Name(), "minecraft:")
+	switch {
+	case strings.HasSuffix(name, "_helmet"), strings.HasSuffix(name, "_head"),
+		strings.HasSuffix(name, "_skull"), name == "carved_pumpkin":
+		return EquipHead
+	case strings.HasSuffix(name, "_chestplate"), name == "elytra":
+		return EquipChest
+	case strings.HasSuffix(name, "_leggings"):
+		return EquipLegs
+	case strings.HasSuffix(name, "_boots"):
+		return EquipFeet
+	}
+	return EquipMainhand
+}
+
+// Durability 返回剩余耐久与最大耐久，物品不可损坏时 ok=false。
+// 服务端只下发与物品默认值不同的组件，原版工具的 max_damage 需要从注册表补全。
+func (s *ItemStack) Durability() (remaining, maxDamage int32, ok bool) {
+	if s.IsEmpty() {
+		return 0, 0, false
+	}
+	if _, unbreakable := s.Component(component.Unbreakable); unbreakable {
+		return 0, 0, false
+	}
+	if v, has := s.Component(component.MaxDamage); has {
+		maxDamage, _ = v.(int32)
+	} else if info := registry.GetItemRegistry().GetByID(s.ID); info != nil {
+		maxDamage = info.MaxDamage
+	}
+	if maxDamage <= 0 {
+		return 0, 0, false
+	}
+	return maxDamage - s.Damage(), maxDamage, true
+}
+
+// Damage 返回已损耗的耐久值
+func (s *ItemStack) Damage() int32 {
+	if v, ok := s.Component(component.Damage); ok {
+		if d, ok := v.(int32); ok {
+			return d
+		}
+	}
+	return 0
+}
+
+// Enchantments 返回物品上的附魔 (附魔书返回 stored_enchantments)
+func (s *ItemStack) Enchantments() []component.EnchantmentEntry {
+	for _, typeID := range []int32{component.Enchantments, component.StoredEnchantments} {
+		if v, ok := s.Component(typeID); ok {
+			if list, ok := v.([]component.EnchantmentEntry); ok && len(list) > 0 {
+				return list
+			}
+		}
+	}
+	return nil
+}
+
+// EnchantmentLevel 返回指定附魔的等级，name 形如 minecraft:sharpness
+func (s *ItemStack) EnchantmentLevel(name string) int32 {
+	for _, e := range s.Enchantments() {
+		if e.Name == name {
+			return e.Level
+		}
+	}
+	return 0
+}
+
+// EnchantmentNames 返回本地化的附魔描述，如 "锋利 5"
+func (s *ItemStack) EnchantmentNames() []string {
+	list := s.Enchantments()
+	names := make([]string, 0, len(list))
+	for _, e := range list {
+		name := fmt.Sprintf("#%d", e.ID)
+		if e.Name != "" {
+			name = i18n.EnchantmentName(trimNamespace(e.Name))
+		}
+		names = append(names, fmt.Sprintf("%s %d", name, e.Level))
+	}
+	return names
+}
+
+// String 便于日志输出
+func (s *ItemStack) String() string {
+	if s.IsEmpty() {
+		return "empty"
+	}
+	return fmt.Sprintf("%s x%d", s.Name(), s.Count)
+}
+
+func trimNamespace(name string) string {
+	if len(name) > 10 && name[:10] == "minecraft:" {
+		return name[10:]
+	}
+	return name
+}
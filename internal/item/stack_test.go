@@ -0,0 +1,139 @@
+package item
+
+import (
+	"bytes"
+	"testing"
+
+	"gmcc/internal/item/component"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/registry"
+)
+
+func TestReadItemStack(t *testing.T) {
+	// 测试空物品
+	data := []byte{0x00} // count = 0
+	r := bytes.NewReader(data)
+
+	stack, err := ReadItemStack(r)
+	if err != nil {
+		t.Errorf("ReadItemStack(empty) error = %v", err)
+	}
+	if stack != nil {
+		t.Errorf("ReadItemStack(empty) = %v, want nil", stack)
+	}
+
+	// 测试非空物品（简单物品）
+	data = []byte{0x01, 0x01, 0x00, 0x00} // count=1, itemID=1, addCount=0, removeCount=0
+	r = bytes.NewReader(data)
+
+	stack, err = ReadItemStack(r)
+	if err != nil {
+		t.Errorf("ReadItemStack(simple) error = %v", err)
+	}
+	if stack == nil {
+		t.Errorf("ReadItemStack(simple) = nil, want stack")
+		return
+	}
+	if stack.ID != 1 || stack.Count != 1 {
+		t.Errorf("ReadItemStack(simple) = {ID:%d, Count:%d}, want {ID:1, Count:1}", stack.ID, stack.Count)
+	}
+	if len(stack.Components) != 0 {
+		t.Errorf("ReadItemStack(simple) Components len = %d, want 0", len(stack.Components))
+	}
+}
+
+func TestItemStack_IsEmpty(t *testing.T) {
+	tests := []struct {
+		name  string
+		stack *ItemStack
+		want  bool
+	}{
+		{"nil", nil, true},
+		{"air", &ItemStack{ID: 0, Count: 1}, true},
+		{"zero_count", &ItemStack{ID: 1, Count: 0}, true},
+		{"negative_count", &ItemStack{ID: 1, Count: -1}, true},
+		{"valid", &ItemStack{ID: 1, Count: 1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stack.IsEmpty(); got != tt.want {
+				t.Errorf("ItemStack.IsEmpty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemStack_Components(t *testing.T) {
+	reg := registry.GetItemRegistry()
+	sword := reg.NameToID("diamond_sword")
+	if sword < 0 {
+		t.Fatal("注册表缺少 diamond_sword")
+	}
+	registry.SetDynamicEntries("minecraft:enchantment", []string{"minecraft:aqua_affinity", "minecraft:sharpness"})
+	defer registry.ClearDynamicEntries()
+
+	// count=1, id, addCount=2, removeCount=0,
+	// damage(3)=100, enchantments(13)=[{1, 5}]
+	data := append([]byte{0x01}, packet.EncodeVarInt(sword)...)
+	data = append(data, 0x02, 0x00)
+	data = append(data, byte(component.Damage), 0x64)
+	data = append(data, byte(component.Enchantments), 0x01, 0x01, 0x05)
+
+	stack, err := ReadItemStack(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadItemStack() error = %v", err)
+	}
+	if stack.Name() != "minecraft:diamond_sword" {
+		t.Errorf("Name() = %q, want minecraft:diamond_sword", stack.Name())
+	}
+	remaining, maxDamage, ok := stack.Durability()
+	if !ok || maxDamage != 1561 || remaining != 1461 {
+		t.Errorf("Durability() = %d/%d ok=%v, want 1461/1561", remaining, maxDamage, ok)
+	}
+	if got := stack.EnchantmentLevel("minecraft:sharpness"); got != 5 {
+		t.Errorf("EnchantmentLevel(sharpness) = %d, want 5", got)
+	}
+
+	other := stack.WithCount(3)
+	if !stack.SameItem(other) {
+		t.Error("相同组件的物品应可堆叠")
+	}
+	if stack.SameItem(&ItemStack{ID: sword, Count: 1}) {
+		t.Error("组件不同的物品不应堆叠")
+	}
+}
+
+func TestItemStack_EquipmentSlot(t *testing.T) {
+	reg := registry.GetItemRegistry()
+	stack := func(name string) *ItemStack { return &ItemStack{ID: reg.NameToID(name), Count: 1} }
+
+	tests := []struct {
+		stack *ItemStack
+		want  EquipmentSlot
+	}{
+		{stack("iron_chestplate"), EquipChest},
+		{stack("turtle_helmet"), EquipHead},
+		{stack("player_head"), EquipHead},
+		{stack("elytra"), EquipChest},
+		{stack("leather_boots"), EquipFeet},
+		{stack("stone"), EquipMainhand},
+	}
+	for _, tt := range tests {
+		if got := tt.stack.EquipmentSlot(); got != tt.want {
+			t.Errorf("%s: EquipmentSlot() = %d, want %d", tt.stack.Name(), got, tt.want)
+		}
+	}
+
+	// 组件覆盖默认槽位
+	custom := stack("stone")
+	custom.Components = map[int32]*component.ComponentResult{component.Equippable: {Raw: []byte{byte(EquipOffhand)}}}
+	if got := custom.EquipmentSlot(); got != EquipOffhand {
+		t.Errorf("equippable 组件 EquipmentSlot() = %d, want offhand", got)
+	}
+	removed := stack("iron_chestplate")
+	removed.Removed = []int32{component.Equippable}
+	if got := removed.EquipmentSlot(); got != EquipMainhand {
+		t.Errorf("移除 equippable 后 EquipmentSlot() = %d, want mainhand", got)
+	}
+}
@@ -0,0 +1,185 @@
+// Package mapdata 保存 map_item_data 下发的地图内容，并导出为 PNG
+package mapdata
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 地图边长 (像素)
+const Size = 128
+
+// Decoration 地图上的标记，坐标范围 -128..127，对应地图像素 x*2
+type Decoration struct {
+	Type     string // 如 minecraft:player、minecraft:banner_red，未知时为空
+	TypeID   int32
+	X, Y     int8
+	Rotation int8   // 0-15，每级 22.5 度
+	Name     string // JSON 文本组件，可能为空
+}
+
+// Patch 颜色更新，覆盖从 (X, Y) 开始的 Width*Height 区域
+type Patch struct {
+	X, Y          int
+	Width, Height int
+	Colors        []byte
+}
+
+// Map 一张地图
+type Map struct {
+	ID          int32
+	Scale       int8
+	Locked      bool
+	Colors      [Size * Size]byte // 按行存储的颜色下标
+	Decorations []Decoration
+	UpdatedAt   time.Time
+}
+
+// Image 将地图渲染为 128x128 图像，未探索区域透明
+func (m *Map) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, Size, Size))
+	for i, index := range m.Colors {
+		img.SetRGBA(i%Size, i/Size, Color(index))
+	}
+	return img
+}
+
+// WritePNG 以 PNG 格式写出地图
+func (m *Map) WritePNG(w io.Writer) error {
+	return png.Encode(w, m.Image())
+}
+
+// Store 按地图 ID 保存地图
+type Store struct {
+	mu    sync.RWMutex
+	maps  map[int32]*Map
+	dirty map[int32]bool
+}
+
+func NewStore() *Store {
+	return &Store{
+		maps:  make(map[int32]*Map),
+		dirty: make(map[int32]bool),
+	}
+}
+
+// Update 合并一次 map_item_data。decorations 为 nil 表示标记不变，patch 为 nil 表示颜色不变。
+func (s *Store) Update(id int32, scale int8, locked bool, decorations []Decoration, patch *Patch) error {
+	if patch != nil {
+		if patch.X < 0 || patch.Y < 0 || patch.Width <= 0 || patch.Height <= 0 ||
+			patch.X+patch.Width > Size || patch.Y+patch.Height > Size {
+			return fmt.Errorf("地图 %d 颜色更新越界: (%d,%d) %dx%d", id, patch.X, patch.Y, patch.Width, patch.Height)
+		}
+		if len(patch.Colors) != patch.Width*patch.Height {
+			return fmt.Errorf("地图 %d 颜色数据长度 %d 与 %dx%d 不符", id, len(patch.Colors), patch.Width, patch.Height)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.maps[id]
+	if !ok {
+		m = &Map{ID: id}
+		s.maps[id] = m
+	}
+	m.Scale, m.Locked = scale, locked
+	if decorations != nil {
+		m.Decorations = decorations
+	}
+	if patch != nil {
+		for row := 0; row < patch.Height; row++ {
+			dst := (patch.Y+row)*Size + patch.X
+			copy(m.Colors[dst:dst+patch.Width], patch.Colors[row*patch.Width:(row+1)*patch.Width])
+		}
+		s.dirty[id] = true
+	}
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// Get 返回地图副本
+func (s *Store) Get(id int32) (Map, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	m, ok := s.maps[id]
+	if !ok {
+		return Map{}, false
+	}
+	c := *m
+	c.Decorations = append([]Decoration(nil), m.Decorations...)
+	return c, true
+}
+
+// IDs 返回所有已知地图 ID，从小到大排序
+func (s *Store) IDs() []int32 {
+	s.mu.RLock()
+	ids := make([]int32, 0, len(s.maps))
+	for id := range s.maps {
+		ids = append(ids, id)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// TakeDirty 返回自上次调用以来颜色有变化的地图 ID
+func (s *Store) TakeDirty() []int32 {
+	s.mu.Lock()
+	ids := make([]int32, 0, len(s.dirty))
+	for id := range s.dirty {
+		ids = append(ids, id)
+	}
+	s.dirty = make(map[int32]bool)
+	s.mu.Unlock()
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// SavePNG 将地图保存为 dir/map_<id>.png，返回文件路径
+func (s *Store) SavePNG(dir string, id int32) (string, error) {
+	m, ok := s.Get(id)
+	if !ok {
+		return "", fmt.Errorf("未知地图: %d", id)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("创建地图目录失败: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("map_%d.png", id))
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return "", fmt.Errorf("创建地图文件失败: %w", err)
+	}
+	if err := m.WritePNG(f); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return "", fmt.Errorf("写入地图 PNG 失败: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("写入地图 PNG 失败: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("保存地图 PNG 失败: %w", err)
+	}
+	return path, nil
+}
+
+// Clear 清空所有地图 (切换服务器时，地图 ID 不再对应)
+func (s *Store) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maps = make(map[int32]*Map)
+	s.dirty = make(map[int32]bool)
+}
@@ -0,0 +1,104 @@
+package mapdata
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	tests := []struct {
+		index byte
+		want  color.RGBA
+	}{
+		{0, color.RGBA{}},
+		{3, color.RGBA{}},                         // 基础颜色 0 的任何明暗都是透明
+		{1*4 + 2, color.RGBA{127, 178, 56, 255}},  // GRASS HIGH 原色
+		{1*4 + 0, color.RGBA{89, 125, 39, 255}},   // GRASS LOW *180/255
+		{8*4 + 1, color.RGBA{220, 220, 220, 255}}, // SNOW NORMAL
+		{8*4 + 3, color.RGBA{135, 135, 135, 255}}, // SNOW LOWEST
+		{255, color.RGBA{}},                       // 超出调色板
+	}
+	for _, tt := range tests {
+		if got := Color(tt.index); got != tt.want {
+			t.Errorf("Color(%d) = %v, want %v", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestStoreUpdate(t *testing.T) {
+	s := NewStore()
+	err := s.Update(7, 2, false, []Decoration{{Type: "minecraft:player"}}, &Patch{
+		X: 126, Y: 10, Width: 2, Height: 2,
+		Colors: []byte{10, 11, 12, 13},
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	// 只更新标记，颜色保持不变
+	if err := s.Update(7, 2, true, nil, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	m, ok := s.Get(7)
+	if !ok || m.Scale != 2 || !m.Locked || len(m.Decorations) != 1 {
+		t.Fatalf("Get(7) = %+v, %v", m, ok)
+	}
+	if m.Colors[10*Size+126] != 10 || m.Colors[10*Size+127] != 11 || m.Colors[11*Size+126] != 12 || m.Colors[11*Size+127] != 13 {
+		t.Error("颜色更新位置错误")
+	}
+	if dirty := s.TakeDirty(); len(dirty) != 1 || dirty[0] != 7 {
+		t.Errorf("TakeDirty() = %v", dirty)
+	}
+	if dirty := s.TakeDirty(); len(dirty) != 0 {
+		t.Errorf("第二次 TakeDirty() = %v", dirty)
+	}
+
+	if err := s.Update(7, 0, false, nil, &Patch{X: 127, Width: 2, Height: 1, Colors: []byte{1, 2}}); err == nil {
+		t.Error("越界的颜色更新应返回错误")
+	}
+	if err := s.Update(7, 0, false, nil, &Patch{Width: 2, Height: 2, Colors: []byte{1}}); err == nil {
+		t.Error("长度不符的颜色更新应返回错误")
+	}
+}
+
+func TestSavePNG(t *testing.T) {
+	s := NewStore()
+	colors := make([]byte, Size*Size)
+	for i := range colors {
+		colors[i] = 12*4 + 2 // WATER HIGH
+	}
+	if err := s.Update(3, 0, false, nil, &Patch{Width: Size, Height: Size, Colors: colors}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "maps")
+	path, err := s.SavePNG(dir, 3)
+	if err != nil {
+		t.Fatalf("SavePNG() error = %v", err)
+	}
+	if filepath.Base(path) != "map_3.png" {
+		t.Errorf("path = %s", path)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != Size || b.Dy() != Size {
+		t.Errorf("图片尺寸 = %v", b)
+	}
+	if r, g, b, _ := img.At(64, 64).RGBA(); r>>8 != 0x40 || g>>8 != 0x40 || b>>8 != 0xFF {
+		t.Errorf("像素颜色 = %d,%d,%d", r>>8, g>>8, b>>8)
+	}
+
+	if _, err := s.SavePNG(dir, 99); err == nil {
+		t.Error("未知地图应返回错误")
+	}
+}
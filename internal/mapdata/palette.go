@@ -0,0 +1,34 @@
+package mapdata
+
+import "image/color"
+
+// 原版 MapColor 基础颜色，下标即颜色 ID，0 为透明
+var baseColors = [...]uint32{
+	0x000000, 0x7FB238, 0xF7E9A3, 0xC7C7C7, 0xFF0000, 0xA0A0FF, 0xA7A7A7, 0x007C00,
+	0xFFFFFF, 0xA4A8B8, 0x976D4D, 0x707070, 0x4040FF, 0x8F7748, 0xFFFCF5, 0xD87F33,
+	0xB24CD8, 0x6699D8, 0xE5E533, 0x7FCC19, 0xF27FA5, 0x4C4C4C, 0x999999, 0x4C7F99,
+	0x7F3FB2, 0x334CB2, 0x664C33, 0x667F33, 0x993333, 0x191919, 0xFAEE4D, 0x5CDBD5,
+	0x4A80FF, 0x00D93A, 0x815631, 0x700200, 0xD1B1A1, 0x9F5224, 0x95576C, 0x706C8A,
+	0xBA8524, 0x677535, 0xA04D4E, 0x392923, 0x876B62, 0x575C5C, 0x7A4958, 0x4C3E5C,
+	0x4C3223, 0x4C522A, 0x8E3C2E, 0x251610, 0xBD3031, 0x943F61, 0x5C191D, 0x167E86,
+	0x3A8E8C, 0x562C3E, 0x14B485, 0x646464, 0xD8AF93, 0x7FA796,
+}
+
+// 四种明暗: LOW, NORMAL, HIGH, LOWEST
+var shadeMultipliers = [4]uint32{180, 220, 255, 135}
+
+// Color 将地图颜色下标 (基础颜色 * 4 + 明暗) 转换为 RGBA，未知颜色视为透明
+func Color(index byte) color.RGBA {
+	base := int(index >> 2)
+	if base == 0 || base >= len(baseColors) {
+		return color.RGBA{}
+	}
+	rgb := baseColors[base]
+	m := shadeMultipliers[index&3]
+	return color.RGBA{
+		R: uint8((rgb >> 16 & 0xFF) * m / 255),
+		G: uint8((rgb >> 8 & 0xFF) * m / 255),
+		B: uint8((rgb & 0xFF) * m / 255),
+		A: 0xFF,
+	}
+}
@@ -0,0 +1,256 @@
+package mcclient
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gmcc/internal/item"
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/mcclient/protocol"
+	"gmcc/internal/player"
+)
+
+const (
+	maxFoodLevel = 20
+	// 食用时长之外多等一会，等服务器发回物品和饱食度变化
+	eatConfirmDelay = 300 * time.Millisecond
+	// 自动进食失败 (如没有食物) 后的重试间隔
+	autoEatRetryDelay = 5 * time.Second
+)
+
+// eatState 记录自动进食设置与正在进行的进食
+type eatState struct {
+	mu        sync.Mutex
+	auto      bool
+	threshold int32
+	blacklist map[string]bool // 完整物品名，如 minecraft:golden_apple
+	eating    bool
+	retryAt   time.Time
+}
+
+// begin 标记开始进食，已在进食时返回 false
+func (s *eatState) begin() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.eating {
+		return false
+	}
+	s.eating = true
+	return true
+}
+
+func (s *eatState) end(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.eating = false
+	if err != nil {
+		s.retryAt = time.Now().Add(autoEatRetryDelay)
+	}
+}
+
+func (s *eatState) isEating() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.eating
+}
+
+// SendUseItem 发送使用物品包 (右键)，进食、拉弓等会持续到松开或切换物品
+func (c *Client) SendUseItem(hand int32) error {
+	if c.state != protocol.StatePlay {
+		return fmt.Errorf("当前状态不是 Play，无法发送使用物品数据包")
+	}
+	if c.conn == nil {
+		return fmt.Errorf("连接未初始化")
+	}
+
+	_, _, _, yaw, pitch, _ := c.Player.GetMovementState()
+	payload := make([]byte, 0, 16)
+	payload = append(payload, packet.EncodeVarInt(hand)...)
+	payload = append(payload, packet.EncodeVarInt(c.sequence.Add(1))...)
+	payload = append(payload, packet.EncodeFloat32(yaw)...)
+	payload = append(payload, packet.EncodeFloat32(pitch)...)
+	return c.conn.WritePacket(protocol.PlayServerUseItem, payload)
+}
+
+// SendReleaseUseItem 松开使用键，中断进食
+func (c *Client) SendReleaseUseItem() error {
+	if c.state != protocol.StatePlay {
+		return fmt.Errorf("当前状态不是 Play，无法发送玩家动作数据包")
+	}
+	if c.conn == nil {
+		return fmt.Errorf("连接未初始化")
+	}
+
+	// 原版固定发送原点、朝下、序号 0
+	payload := make([]byte, 0, 11)
+	payload = append(payload, packet.EncodeVarInt(protocol.PlayerActionReleaseUseItem)...)
+	payload = append(payload, packet.EncodeInt64(0)...)
+	payload = append(payload, 0)
+	payload = append(payload, packet.EncodeVarInt(0)...)
+	return c.conn.WritePacket(protocol.PlayServerPlayerAction, payload)
+}
+
+// SetAutoEat 开启或关闭自动进食
+func (c *Client) SetAutoEat(enabled bool) {
+	c.eat.mu.Lock()
+	defer c.eat.mu.Unlock()
+	c.eat.auto = enabled
+	c.eat.retryAt = time.Time{}
+}
+
+// AutoEatEnabled 返回自动进食是否开启
+func (c *Client) AutoEatEnabled() bool {
+	c.eat.mu.Lock()
+	defer c.eat.mu.Unlock()
+	return c.eat.auto
+}
+
+// SetAutoEatOptions 设置进食阈值和不吃的物品
+func (c *Client) SetAutoEatOptions(threshold int, blacklist []string) {
+	c.eat.mu.Lock()
+	defer c.eat.mu.Unlock()
+	c.eat.threshold = int32(threshold)
+	c.eat.blacklist = make(map[string]bool, len(blacklist))
+	for _, name := range blacklist {
+		if !strings.Contains(name, ":") {
+			name = "minecraft:" + name
+		}
+		c.eat.blacklist[name] = true
+	}
+}
+
+// Eat 吃掉背包中最合适的食物，阻塞到食用完成并切回原来的快捷栏槽位
+func (c *Client) Eat() error {
+	if !c.eat.begin() {
+		return fmt.Errorf("正在进食")
+	}
+	err := c.eatBest()
+	c.eat.end(nil)
+	return err
+}
+
+// autoEatTick 在游戏刻中调用，饱食度低于阈值时在后台进食
+func (c *Client) autoEatTick() {
+	c.eat.mu.Lock()
+	enabled, threshold, retryAt := c.eat.auto, c.eat.threshold, c.eat.retryAt
+	c.eat.mu.Unlock()
+	if !enabled || time.Now().Before(retryAt) {
+		return
+	}
+	if mode := c.Player.GetGameMode(); mode == player.GameModeCreative || mode == player.GameModeSpectator {
+		return
+	}
+	if _, _, food, _ := c.Player.GetHealth(); food >= threshold {
+		return
+	}
+	if !c.eat.begin() {
+		return
+	}
+
+	go func() {
+		err := c.eatBest()
+		if err != nil {
+			logx.Debugf("自动进食失败: %v", err)
+		}
+		c.eat.end(err)
+	}()
+}
+
+func (c *Client) eatBest() error {
+	_, _, food, _ := c.Player.GetHealth()
+	c.eat.mu.Lock()
+	blacklist := c.eat.blacklist
+	c.eat.mu.Unlock()
+
+	// 打开其他容器时槽位编号不同，只从快捷栏取食物
+	inventoryOpen := c.Player.GetActiveContainer().WindowID == 0
+	slot, info, ok := pickFood(c.Player.Inventory.GetAll(), food, blacklist, inventoryOpen)
+	if !ok {
+		return fmt.Errorf("背包中没有可食用的物品")
+	}
+
+	prev := c.Player.GetHeldSlot()
+	hotbar := prev
+	if slot >= player.SlotHotbarStart {
+		hotbar = slot - player.SlotHotbarStart
+	} else {
+		// 与当前手持槽位交换，吃完再换回去
+		if err := c.ClickContainer(int16(slot), prev, player.ClickSwap); err != nil {
+			return err
+		}
+		defer func() {
+			if err := c.ClickContainer(int16(slot), prev, player.ClickSwap); err != nil {
+				logx.Warnf("进食后放回物品失败: %v", err)
+			}
+		}()
+	}
+	if hotbar != prev {
+		if err := c.SendSetCarriedItem(int16(hotbar)); err != nil {
+			return err
+		}
+		defer func() {
+			if err := c.SendSetCarriedItem(int16(prev)); err != nil {
+				logx.Warnf("进食后切回快捷栏 %d 失败: %v", prev+1, err)
+			}
+		}()
+	}
+
+	held := player.SlotHotbarStart + hotbar
+	before := c.Player.Inventory.GetSlot(held)
+	if err := c.SendUseItem(protocol.HandMainHand); err != nil {
+		return err
+	}
+	time.Sleep(time.Duration(float64(info.ConsumeSeconds)*float64(time.Second)) + eatConfirmDelay)
+
+	// 吃完后物品数量减少，或变成碗、瓶子等容器
+	after := c.Player.Inventory.GetSlot(held)
+	if !before.IsEmpty() && !after.IsEmpty() && after.ID == before.ID && after.Count == before.Count {
+		_ = c.SendReleaseUseItem()
+		return fmt.Errorf("进食未完成: %s", before.Name())
+	}
+	return nil
+}
+
+// pickFood 选出最合适的食物槽位。跳过有害和黑名单中的食物，
+// 优先补足饥饿值且不浪费，其次看饱和度，同分时优先快捷栏。
+func pickFood(slots map[int8]*item.ItemStack, food int32, blacklist map[string]bool, includeMain bool) (int8, item.FoodInfo, bool) {
+	var (
+		bestSlot  int8 = -1
+		bestInfo  item.FoodInfo
+		bestScore float64
+	)
+	check := func(slot int8) {
+		stack := slots[slot]
+		info, ok := stack.Food()
+		if !ok || info.Harmful || blacklist[stack.Name()] {
+			return
+		}
+		if food >= maxFoodLevel && !info.CanAlwaysEat {
+			return
+		}
+		if score := foodScore(info, food); bestSlot < 0 || score > bestScore {
+			bestSlot, bestInfo, bestScore = slot, info, score
+		}
+	}
+
+	for slot := int8(player.SlotHotbarStart); slot < player.SlotOffhand; slot++ {
+		check(slot)
+	}
+	if includeMain {
+		for slot := int8(player.SlotMainStart); slot < player.SlotHotbarStart; slot++ {
+			check(slot)
+		}
+	}
+	return bestSlot, bestInfo, bestSlot >= 0
+}
+
+// foodScore 有效的饥饿值按两倍计，溢出的饥饿值扣分，饱和度不会超过吃完后的饥饿值
+func foodScore(info item.FoodInfo, food int32) float64 {
+	gain := min(info.Nutrition, max(0, maxFoodLevel-food))
+	waste := info.Nutrition - gain
+	saturation := min(float64(info.Saturation), float64(food+gain))
+	return float64(gain)*2 + saturation - float64(waste)
+}
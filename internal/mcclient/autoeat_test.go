@@ -0,0 +1,52 @@
+package mcclient
+
+import (
+	"testing"
+
+	"gmcc/internal/item"
+	"gmcc/internal/player"
+	"gmcc/internal/registry"
+)
+
+func TestPickFood(t *testing.T) {
+	reg := registry.GetItemRegistry()
+	stack := func(name string) *item.ItemStack {
+		return &item.ItemStack{ID: reg.NameToID(name), Count: 4}
+	}
+	slots := map[int8]*item.ItemStack{
+		player.SlotHotbarStart:     stack("diamond_sword"),
+		player.SlotHotbarStart + 1: stack("bread"),
+		player.SlotHotbarStart + 2: stack("rotten_flesh"),
+		player.SlotHotbarStart + 3: stack("golden_apple"),
+		player.SlotMainStart:       stack("cooked_beef"),
+	}
+	blacklist := map[string]bool{"minecraft:golden_apple": true}
+
+	tests := []struct {
+		name        string
+		food        int32
+		includeMain bool
+		want        int8
+	}{
+		{"饿时选营养最高的", 6, true, player.SlotMainStart},
+		{"只看快捷栏", 6, false, player.SlotHotbarStart + 1},
+		{"饱食度满", 20, true, -1},
+	}
+	for _, tt := range tests {
+		slot, _, ok := pickFood(slots, tt.food, blacklist, tt.includeMain)
+		if slot != tt.want || ok != (tt.want >= 0) {
+			t.Errorf("%s: pickFood() = %d, %v, want %d", tt.name, slot, ok, tt.want)
+		}
+	}
+
+	// 同分时优先快捷栏，不用交换物品
+	slots[player.SlotMainStart] = stack("bread")
+	if slot, _, _ := pickFood(slots, 6, blacklist, true); slot != player.SlotHotbarStart+1 {
+		t.Errorf("pickFood() = %d, want %d", slot, player.SlotHotbarStart+1)
+	}
+
+	// 金苹果不在黑名单时，满饱食度也能吃
+	if slot, _, _ := pickFood(slots, 20, nil, true); slot != player.SlotHotbarStart+3 {
+		t.Errorf("pickFood() = %d, want %d", slot, player.SlotHotbarStart+3)
+	}
+}
@@ -0,0 +1,116 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"gmcc/internal/item"
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/mcclient/protocol"
+)
+
+// edit_book 中副手的槽位编号 (原版 Inventory.SLOT_OFFHAND)
+const editBookOffhandSlot = 40
+
+// BookOpenEvent 服务端要求打开手中的成书 (如 /rules 等插件命令)
+type BookOpenEvent struct {
+	Hand  int32
+	Book  *item.ItemStack
+	Pages []string
+}
+
+// SetBookOpenHandler 设置打开成书回调
+func (c *Client) SetBookOpenHandler(handler func(BookOpenEvent)) {
+	c.bookHandler = handler
+}
+
+// EditBook 将内容写入手中的书与笔 (主手优先)
+func (c *Client) EditBook(pages []string) error {
+	return c.sendEditBook(pages, nil)
+}
+
+// SignBook 写入内容并署名，书与笔会变为成书
+func (c *Client) SignBook(title string, pages []string) error {
+	return c.sendEditBook(pages, &title)
+}
+
+func (c *Client) sendEditBook(pages []string, title *string) error {
+	if c.state != protocol.StatePlay {
+		return fmt.Errorf("当前状态不是 Play，无法发送编辑书数据包")
+	}
+	if c.conn == nil {
+		return fmt.Errorf("连接未初始化")
+	}
+	slot, err := c.heldWritableBookSlot()
+	if err != nil {
+		return err
+	}
+	payload, err := encodeEditBook(slot, pages, title)
+	if err != nil {
+		return err
+	}
+	return c.conn.WritePacket(protocol.PlayServerEditBook, payload)
+}
+
+// heldWritableBookSlot 返回手持书与笔在 edit_book 中的槽位编号
+func (c *Client) heldWritableBookSlot() (int32, error) {
+	if c.Player.GetHeldItem().Name() == "minecraft:writable_book" {
+		return int32(c.Player.GetHeldSlot()), nil
+	}
+	if c.Player.Inventory.GetOffhand().Name() == "minecraft:writable_book" {
+		return editBookOffhandSlot, nil
+	}
+	return 0, fmt.Errorf("手中没有书与笔")
+}
+
+// encodeEditBook 检查原版限制并编码 edit_book: 槽位 + 页面列表 + 可选书名
+func encodeEditBook(slot int32, pages []string, title *string) ([]byte, error) {
+	if len(pages) > item.MaxBookPages {
+		return nil, fmt.Errorf("页数 %d 超过上限 %d", len(pages), item.MaxBookPages)
+	}
+	for i, page := range pages {
+		if n := len(utf16.Encode([]rune(page))); n > item.MaxBookPageLength {
+			return nil, fmt.Errorf("第 %d 页长度 %d 超过上限 %d", i+1, n, item.MaxBookPageLength)
+		}
+	}
+
+	payload := make([]byte, 0, 64)
+	payload = append(payload, packet.EncodeVarInt(slot)...)
+	payload = append(payload, packet.EncodeVarInt(int32(len(pages)))...)
+	for _, page := range pages {
+		payload = append(payload, packet.EncodeString(page)...)
+	}
+	payload = append(payload, packet.EncodeBool(title != nil)...)
+	if title != nil {
+		t := strings.TrimSpace(*title)
+		if t == "" {
+			return nil, fmt.Errorf("书名不能为空")
+		}
+		if n := len(utf16.Encode([]rune(t))); n > item.MaxBookTitleLen {
+			return nil, fmt.Errorf("书名长度 %d 超过上限 %d", n, item.MaxBookTitleLen)
+		}
+		payload = append(payload, packet.EncodeString(t)...)
+	}
+	return payload, nil
+}
+
+func (c *Client) handleOpenBookPacket(data []byte) error {
+	hand, err := packet.ReadVarIntFromReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("读取 open_book 手失败: %w", err)
+	}
+
+	book := c.Player.GetHeldItem()
+	if hand == protocol.HandOffHand {
+		book = c.Player.Inventory.GetOffhand()
+	}
+	pages := book.BookPages()
+	logx.Debugf("[书] 服务端打开 %s，共 %d 页", book.Name(), len(pages))
+	if c.bookHandler != nil {
+		c.bookHandler(BookOpenEvent{Hand: hand, Book: book, Pages: pages})
+	}
+	return nil
+}
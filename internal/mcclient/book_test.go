@@ -0,0 +1,64 @@
+package mcclient
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gmcc/internal/mcclient/packet"
+)
+
+func TestEncodeEditBook(t *testing.T) {
+	title := " Reply "
+	payload, err := encodeEditBook(3, []string{"hello", "world"}, &title)
+	if err != nil {
+		t.Fatalf("encodeEditBook() error = %v", err)
+	}
+
+	r := bytes.NewReader(payload)
+	slot := packet.MustReadVarInt(r, "slot")
+	count := packet.MustReadVarInt(r, "count")
+	first := packet.MustReadString(r, "page")
+	second := packet.MustReadString(r, "page")
+	hasTitle := packet.MustReadBool(r, "has title")
+	got := packet.MustReadString(r, "title")
+	if slot != 3 || count != 2 || first != "hello" || second != "world" || !hasTitle || got != "Reply" {
+		t.Errorf("payload = %d %d %q %q %v %q", slot, count, first, second, hasTitle, got)
+	}
+	if r.Len() != 0 {
+		t.Errorf("剩余 %d 字节", r.Len())
+	}
+
+	// 不署名时只有 false 标记
+	payload, err = encodeEditBook(editBookOffhandSlot, nil, nil)
+	if err != nil || !bytes.Equal(payload, []byte{40, 0, 0}) {
+		t.Errorf("encodeEditBook(nil) = %v, %v", payload, err)
+	}
+}
+
+func TestEncodeEditBookLimits(t *testing.T) {
+	empty, long := "  ", strings.Repeat("a", 33)
+	tests := []struct {
+		name  string
+		pages []string
+		title *string
+	}{
+		{"too_many_pages", make([]string, 101), nil},
+		{"page_too_long", []string{strings.Repeat("字", 1025)}, nil},
+		{"empty_title", []string{"x"}, &empty},
+		{"title_too_long", []string{"x"}, &long},
+	}
+	for _, tt := range tests {
+		if _, err := encodeEditBook(0, tt.pages, tt.title); err == nil {
+			t.Errorf("%s: 应返回错误", tt.name)
+		}
+	}
+
+	// 补充平面字符占两个 UTF-16 码元
+	if _, err := encodeEditBook(0, []string{strings.Repeat("😀", 513)}, nil); err == nil {
+		t.Error("超过 1024 个 UTF-16 码元应返回错误")
+	}
+	if _, err := encodeEditBook(0, []string{strings.Repeat("😀", 512)}, nil); err != nil {
+		t.Errorf("1024 个 UTF-16 码元应允许: %v", err)
+	}
+}
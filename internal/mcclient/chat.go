@@ -17,10 +17,10 @@ import (
 	"gmcc/internal/constants"
 
 	mcauth "gmcc/internal/auth/minecraft"
+	"gmcc/internal/item"
 	"gmcc/internal/logx"
 	"gmcc/internal/mcclient/packet"
 	"gmcc/internal/mcclient/protocol"
-	"gmcc/internal/player"
 )
 
 // ChatMessage 是统一的聊天事件结构，方便后续接入机器人/插件解析。
@@ -31,7 +31,15 @@ type ChatMessage struct {
 	IsActionBar bool
 	SenderUUID  string
 	SenderName  string
-	ReceivedAt  time.Time
+
+	// 以下字段仅对 player_chat 有意义，Sender/Target/Content 为 JSON 文本组件，
+	// PlainText 和 RawJSON 为按聊天类型装饰后的完整消息
+	ChatType     string // 聊天类型注册名，如 minecraft:chat，内联定义时为空
+	Sender       string
+	Target       string // 私聊、队伍消息的目标，没有时为空
+	Content      string // 已应用服务端改写和过滤掩码的消息内容
+	Verification ChatVerification
+	ReceivedAt   time.Time
 }
 
 type secureChatSession struct {
@@ -144,7 +152,12 @@ func (c *Client) SendMessage(message string) error {
 	}
 	timestamp := time.Now().UnixMilli()
 
-	signature, signErr := c.signChatBody(msg, timestamp, salt, nil)
+	// 签名、last seen 更新和发送必须按顺序完成，否则服务端会因消息序号或 offset 不一致而踢出
+	c.chatSignMu.Lock()
+	defer c.chatSignMu.Unlock()
+
+	lastSeen := c.lastSeen.update()
+	signature, signErr := c.signChatBody(msg, timestamp, salt, lastSeen.signatures)
 	hasSignature := signErr == nil && len(signature) == 256
 	if signErr != nil {
 		logx.Debugf("聊天消息签名不可用，按无签名发送: %v", signErr)
@@ -158,9 +171,7 @@ func (c *Client) SendMessage(message string) error {
 	if hasSignature {
 		payload = append(payload, signature...)
 	}
-	payload = append(payload, packet.EncodeVarInt(0)...)
-	payload = append(payload, 0x00, 0x00, 0x00)
-	payload = append(payload, 0x01)
+	payload = append(payload, lastSeen.encode()...)
 
 	if err := c.conn.WritePacket(protocol.PlayServerChatMessage, payload); err != nil {
 		return fmt.Errorf("发送聊天消息失败: %w", err)
@@ -254,7 +265,7 @@ func (c *Client) logPlayerInfo(info map[string]any) {
 	logx.Infof("在线时长: %v", info["duration"])
 }
 
-func (c *Client) logInventory(inventory map[int8]*player.Item) {
+func (c *Client) logInventory(inventory map[int8]*item.ItemStack) {
 	logx.Infof("=== 背包内容 ===")
 	if len(inventory) == 0 {
 		logx.Infof("背包为空（等待3秒后重试...）")
@@ -266,9 +277,9 @@ func (c *Client) logInventory(inventory map[int8]*player.Item) {
 		}
 	}
 
-	for slot, item := range inventory {
-		if item != nil {
-			logx.Infof("槽位 %d: %s x%d", slot, item.ID, item.Count)
+	for slot, stack := range inventory {
+		if stack != nil {
+			logx.Infof("槽位 %d: %s x%d", slot, stack.DisplayName(), stack.Count)
 		}
 	}
 	logx.Infof("==================")
@@ -361,7 +372,9 @@ func decodeCertificateSignature(cert *mcauth.PlayerCertificatesResponse) ([]byte
 }
 
 func (c *Client) emitChat(chat ChatMessage) {
-	if strings.TrimSpace(chat.RawJSON) != "" {
+	if chat.Type == "player_chat" {
+		logx.Infof("[聊天] %s", chat.PlainText)
+	} else if strings.TrimSpace(chat.RawJSON) != "" {
 		logx.Infof("[聊天] %s", chat.RawJSON)
 	} else if strings.TrimSpace(chat.PlainText) != "" {
 		sender := chat.SenderName
@@ -407,7 +420,15 @@ func (c *Client) sendSignedCommand(cmd string) error {
 		return fmt.Errorf("生成命令签名 salt 失败: %w", err)
 	}
 
-	argSignatures, err := c.buildCommandArgumentSignatures(cmd, timestamp, salt)
+	c.chatSignMu.Lock()
+	defer c.chatSignMu.Unlock()
+
+	argName, arg, err := c.commandSignable(cmd)
+	if err != nil {
+		return err
+	}
+	lastSeen := c.lastSeen.update()
+	argSignatures, err := c.buildCommandArgumentSignatures(argName, arg, timestamp, salt, lastSeen.signatures)
 	if err != nil {
 		return err
 	}
@@ -424,48 +445,51 @@ func (c *Client) sendSignedCommand(cmd string) error {
 		payload = append(payload, packet.EncodeString(sig.name)...)
 		payload = append(payload, sig.signature...)
 	}
-	payload = append(payload, packet.EncodeVarInt(0)...) // messageCount
-	payload = append(payload, 0x00, 0x00, 0x00)          // acknowledged (3 bytes)
-	payload = append(payload, 0x01)                      // checksum (u8, 1 when no seen signatures)
+	payload = append(payload, lastSeen.encode()...)
 
 	return c.conn.WritePacket(protocol.PlayServerChatCommandSign, payload)
 }
 
-func (c *Client) buildCommandArgumentSignatures(cmd string, timestampMillis int64, salt int64) ([]commandArgumentSignature, error) {
+// commandSignable 返回命令中需要签名的参数名和内容，没有时 arg 为空。在生成 last seen 更新前检查签名会话，
+// 这样回退为无签名命令时不会丢失未发送的确认。调用方需持有 chatSignMu
+func (c *Client) commandSignable(cmd string) (name, arg string, err error) {
 	verb, _ := splitCommand(cmd)
-	target, ok := c.commandSignTarget(verb)
+	target, ok := c.commandSign[verb]
 	if !ok {
-		return nil, nil
+		return "", "", nil
 	}
 
 	parts := strings.Split(cmd, " ")
 	if len(parts) <= target.SliceIndex {
-		return nil, nil
+		return "", "", nil
 	}
-	arg := strings.Join(parts[target.SliceIndex:], " ")
+	arg = strings.Join(parts[target.SliceIndex:], " ")
 	if strings.TrimSpace(arg) == "" {
-		return nil, nil
+		return "", "", nil
 	}
+	if c.chatSession == nil || c.chatSession.privateKey == nil {
+		return "", "", fmt.Errorf("生成命令参数签名失败: secure chat 会话未初始化")
+	}
+	return target.ArgumentName, arg, nil
+}
 
-	sig, err := c.signChatBody(arg, timestampMillis, salt, nil)
+// buildCommandArgumentSignatures 对 commandSignable 返回的参数签名，调用方需持有 chatSignMu
+func (c *Client) buildCommandArgumentSignatures(name, arg string, timestampMillis int64, salt int64, acknowledgements [][]byte) ([]commandArgumentSignature, error) {
+	if arg == "" {
+		return nil, nil
+	}
+	sig, err := c.signChatBody(arg, timestampMillis, salt, acknowledgements)
 	if err != nil {
 		return nil, fmt.Errorf("生成命令参数签名失败: %w", err)
 	}
 	return []commandArgumentSignature{
 		{
-			name:      target.ArgumentName,
+			name:      name,
 			signature: sig,
 		},
 	}, nil
 }
 
-func (c *Client) commandSignTarget(verb string) (signableCommandTarget, bool) {
-	c.chatSignMu.Lock()
-	defer c.chatSignMu.Unlock()
-	target, ok := c.commandSign[verb]
-	return target, ok
-}
-
 func splitCommand(cmd string) (verb string, arg string) {
 	parts := strings.SplitN(strings.TrimSpace(cmd), " ", 2)
 	if len(parts) == 0 {
@@ -478,10 +502,8 @@ func splitCommand(cmd string) (verb string, arg string) {
 	return verb, strings.TrimSpace(parts[1])
 }
 
+// signChatBody 签名消息体并递增消息序号，调用方需持有 chatSignMu
 func (c *Client) signChatBody(content string, timestampMillis int64, salt int64, acknowledgements [][]byte) ([]byte, error) {
-	c.chatSignMu.Lock()
-	defer c.chatSignMu.Unlock()
-
 	if c.chatSession == nil || c.chatSession.privateKey == nil {
 		return nil, fmt.Errorf("secure chat 会话未初始化")
 	}
@@ -521,7 +543,8 @@ func buildChatSignableBody(
 	signable = append(signable, sessionID[:]...)
 	signable = append(signable, packet.EncodeInt32(messageIndex)...)
 	signable = append(signable, packet.EncodeInt64(salt)...)
-	signable = append(signable, packet.EncodeInt64(timestampMillis)...)
+	// 原版 SignedMessageBody 签名的是秒级时间戳，数据包中的才是毫秒
+	signable = append(signable, packet.EncodeInt64(timestampMillis/1000)...)
 	signable = append(signable, packet.EncodeInt32(int32(len(contentBytes)))...)
 	// 签名体这里是 Int32 length + 原始 UTF-8 内容，不是协议 String(VarInt length)。
 	signable = append(signable, contentBytes...)
@@ -0,0 +1,279 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// 装饰参数，player_chat 中内联的聊天类型以 0/1/2 表示
+const (
+	ParamSender  = "sender"
+	ParamTarget  = "target"
+	ParamContent = "content"
+)
+
+var decorationParams = []string{ParamSender, ParamTarget, ParamContent}
+
+// DecorationParam 返回网络编号对应的装饰参数，未知编号返回空字符串
+func DecorationParam(id int32) string {
+	if id < 0 || int(id) >= len(decorationParams) {
+		return ""
+	}
+	return decorationParams[id]
+}
+
+// Decoration 聊天类型的装饰: 翻译键、依次填入的参数和外层样式
+type Decoration struct {
+	TranslationKey string
+	Parameters     []string
+	Style          map[string]any
+}
+
+// ChatType minecraft:chat_type 注册表条目
+type ChatType struct {
+	Chat      Decoration
+	Narration Decoration
+}
+
+// defaultChatTypes 原版内置的聊天类型，服务端按已知数据包省略条目数据时使用
+var defaultChatTypes = map[string]*ChatType{
+	"minecraft:chat": {
+		Chat:      Decoration{TranslationKey: "chat.type.text", Parameters: []string{ParamSender, ParamContent}},
+		Narration: Decoration{TranslationKey: "chat.type.text.narrate", Parameters: []string{ParamSender, ParamContent}},
+	},
+	"minecraft:say_command": {
+		Chat:      Decoration{TranslationKey: "chat.type.announcement", Parameters: []string{ParamSender, ParamContent}},
+		Narration: Decoration{TranslationKey: "chat.type.text.narrate", Parameters: []string{ParamSender, ParamContent}},
+	},
+	"minecraft:msg_command_incoming": {
+		Chat: Decoration{
+			TranslationKey: "commands.message.display.incoming",
+			Parameters:     []string{ParamSender, ParamContent},
+			Style:          map[string]any{"color": "gray", "italic": true},
+		},
+		Narration: Decoration{TranslationKey: "chat.type.text.narrate", Parameters: []string{ParamSender, ParamContent}},
+	},
+	"minecraft:msg_command_outgoing": {
+		Chat: Decoration{
+			TranslationKey: "commands.message.display.outgoing",
+			Parameters:     []string{ParamTarget, ParamContent},
+			Style:          map[string]any{"color": "gray", "italic": true},
+		},
+		Narration: Decoration{TranslationKey: "chat.type.text.narrate", Parameters: []string{ParamSender, ParamContent}},
+	},
+	"minecraft:team_msg_command_incoming": {
+		Chat:      Decoration{TranslationKey: "chat.type.team.text", Parameters: []string{ParamTarget, ParamSender, ParamContent}},
+		Narration: Decoration{TranslationKey: "chat.type.text.narrate", Parameters: []string{ParamSender, ParamContent}},
+	},
+	"minecraft:team_msg_command_outgoing": {
+		Chat:      Decoration{TranslationKey: "chat.type.team.sent", Parameters: []string{ParamTarget, ParamSender, ParamContent}},
+		Narration: Decoration{TranslationKey: "chat.type.text.narrate", Parameters: []string{ParamSender, ParamContent}},
+	},
+	"minecraft:emote_command": {
+		Chat:      Decoration{TranslationKey: "chat.type.emote", Parameters: []string{ParamSender, ParamContent}},
+		Narration: Decoration{TranslationKey: "chat.type.emote", Parameters: []string{ParamSender, ParamContent}},
+	},
+}
+
+// DefaultChatType 返回原版内置的聊天类型
+func DefaultChatType(name string) (*ChatType, bool) {
+	t, ok := defaultChatTypes[name]
+	return t, ok
+}
+
+// ParseChatType 解析 registry_data 中的 chat_type 条目
+func ParseChatType(tag map[string]any) (*ChatType, error) {
+	chatTag, ok := tag["chat"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("缺少 chat 装饰")
+	}
+	t := &ChatType{}
+	var err error
+	if t.Chat, err = parseDecoration(chatTag); err != nil {
+		return nil, fmt.Errorf("chat: %w", err)
+	}
+	if narration, ok := tag["narration"].(map[string]any); ok {
+		if t.Narration, err = parseDecoration(narration); err != nil {
+			return nil, fmt.Errorf("narration: %w", err)
+		}
+	}
+	return t, nil
+}
+
+func parseDecoration(tag map[string]any) (Decoration, error) {
+	key, ok := tag["translation_key"].(string)
+	if !ok {
+		return Decoration{}, fmt.Errorf("缺少 translation_key")
+	}
+	d := Decoration{TranslationKey: key}
+	switch params := tag["parameters"].(type) {
+	case []any:
+		for _, p := range params {
+			name, ok := p.(string)
+			if !ok {
+				return Decoration{}, fmt.Errorf("参数类型无效: %T", p)
+			}
+			d.Parameters = append(d.Parameters, name)
+		}
+	case []string:
+		d.Parameters = append(d.Parameters, params...)
+	case string:
+		d.Parameters = []string{params}
+	}
+	if style, ok := tag["style"].(map[string]any); ok {
+		d.Style = normalizeStyle(style)
+	}
+	return d, nil
+}
+
+// normalizeStyle 把 NBT 中以字节表示的布尔样式转换为 JSON 布尔值
+func normalizeStyle(style map[string]any) map[string]any {
+	out := make(map[string]any, len(style))
+	for k, v := range style {
+		switch k {
+		case "bold", "italic", "underlined", "strikethrough", "obfuscated":
+			switch n := v.(type) {
+			case int8:
+				v = n != 0
+			case uint8:
+				v = n != 0
+			case int32:
+				v = n != 0
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// Decorate 与原版 ChatTypeDecoration.decorate 一致，用参数填充翻译键，
+// sender/target/content 为 JSON 文本组件，target 为空时填入空文本
+func (d *Decoration) Decorate(sender, target, content string) string {
+	with := make([]any, 0, len(d.Parameters))
+	for _, p := range d.Parameters {
+		var raw string
+		switch p {
+		case ParamSender:
+			raw = sender
+		case ParamTarget:
+			raw = target
+		case ParamContent:
+			raw = content
+		}
+		with = append(with, componentValue(raw))
+	}
+
+	out := make(map[string]any, len(d.Style)+2)
+	for k, v := range d.Style {
+		out[k] = v
+	}
+	out["translate"] = d.TranslationKey
+	out["with"] = with
+	data, err := json.Marshal(out)
+	if err != nil {
+		return TextJSON(d.TranslationKey)
+	}
+	return string(data)
+}
+
+// TextJSON 返回纯文本组件的 JSON
+func TextJSON(text string) string {
+	data, _ := json.Marshal(map[string]string{"text": text})
+	return string(data)
+}
+
+// componentValue 解析 JSON 文本组件，字符串和数组形式统一转换为对象形式
+func componentValue(raw string) any {
+	if strings.TrimSpace(raw) == "" {
+		return map[string]any{"text": ""}
+	}
+	var v any
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return map[string]any{"text": raw}
+	}
+	return normalizeComponent(v)
+}
+
+func normalizeComponent(v any) any {
+	switch c := v.(type) {
+	case string:
+		return map[string]any{"text": c}
+	case []any:
+		if len(c) == 0 {
+			return map[string]any{"text": ""}
+		}
+		root, ok := normalizeComponent(c[0]).(map[string]any)
+		if !ok {
+			root = map[string]any{"text": ""}
+		}
+		extra, _ := root["extra"].([]any)
+		for _, e := range c[1:] {
+			extra = append(extra, e)
+		}
+		if len(extra) > 0 {
+			root["extra"] = extra
+		}
+		return normalizeComponent(root)
+	case map[string]any:
+		for _, key := range []string{"extra", "with"} {
+			if list, ok := c[key].([]any); ok {
+				for i := range list {
+					list[i] = normalizeComponent(list[i])
+				}
+			}
+		}
+		return c
+	default:
+		return map[string]any{"text": fmt.Sprint(c)}
+	}
+}
+
+// 过滤掩码类型
+const (
+	FilterPassThrough       int32 = 0
+	FilterFullyFiltered     int32 = 1
+	FilterPartiallyFiltered int32 = 2
+)
+
+// ApplyFilterMask 与原版 FilterMask.applyWithFormatting 一致: 被过滤的字符替换为深灰色的 #，
+// mask 按 UTF-16 字符位置标记，完全过滤时返回 false
+func ApplyFilterMask(content string, filterType int32, mask []int64) (string, bool) {
+	switch filterType {
+	case FilterFullyFiltered:
+		return "", false
+	case FilterPartiallyFiltered:
+	default:
+		return TextJSON(content), true
+	}
+
+	units := utf16.Encode([]rune(content))
+	filtered := func(i int) bool {
+		word := i / 64
+		return word < len(mask) && mask[word]&(1<<(i%64)) != 0
+	}
+	extra := make([]any, 0, 4)
+	for i := 0; i < len(units); {
+		j := i
+		hidden := filtered(i)
+		for j < len(units) && filtered(j) == hidden {
+			j++
+		}
+		if hidden {
+			extra = append(extra, map[string]any{
+				"text":       strings.Repeat("#", j-i),
+				"color":      "dark_gray",
+				"hoverEvent": map[string]any{"action": "show_text", "value": map[string]any{"translate": "chat.filtered"}},
+			})
+		} else {
+			extra = append(extra, map[string]any{"text": string(utf16.Decode(units[i:j]))})
+		}
+		i = j
+	}
+	data, err := json.Marshal(map[string]any{"text": "", "extra": extra})
+	if err != nil {
+		return TextJSON(content), true
+	}
+	return string(data), true
+}
@@ -0,0 +1,95 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+)
+
+func plainOf(t *testing.T, rawJSON string) string {
+	t.Helper()
+	comp, err := ParseTextComponent(rawJSON)
+	if err != nil {
+		t.Fatalf("ParseTextComponent(%s) error = %v", rawJSON, err)
+	}
+	return comp.ToPlain()
+}
+
+func TestParseChatType(t *testing.T) {
+	ct, err := ParseChatType(map[string]any{
+		"chat": map[string]any{
+			"translation_key": "chat.type.team.text",
+			"parameters":      []any{"target", "sender", "content"},
+			"style":           map[string]any{"color": "gray", "italic": int8(1)},
+		},
+		"narration": map[string]any{
+			"translation_key": "chat.type.text.narrate",
+			"parameters":      []any{"sender", "content"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ParseChatType() error = %v", err)
+	}
+	if ct.Chat.Style["italic"] != true || len(ct.Narration.Parameters) != 2 {
+		t.Errorf("ChatType = %+v", ct)
+	}
+
+	// 名字以 NBT 字符串下发时也能作为参数
+	raw := ct.Chat.Decorate(`"Steve"`, `{"text":"Red"}`, TextJSON("hi"))
+	if got := plainOf(t, raw); got != "Red <Steve> hi" {
+		t.Errorf("Decorate() plain = %q", got)
+	}
+	if !strings.Contains(raw, `"color":"gray"`) || !strings.Contains(raw, `"italic":true`) {
+		t.Errorf("Decorate() 未保留样式: %s", raw)
+	}
+
+	if _, err := ParseChatType(map[string]any{"narration": map[string]any{}}); err == nil {
+		t.Error("缺少 chat 装饰时应返回错误")
+	}
+}
+
+func TestDefaultChatType(t *testing.T) {
+	ct, ok := DefaultChatType("minecraft:msg_command_outgoing")
+	if !ok {
+		t.Fatal("缺少原版 msg_command_outgoing")
+	}
+	// 私聊发出的消息显示目标而不是发送者，目标缺失时为空文本
+	if got := plainOf(t, ct.Chat.Decorate(TextJSON("Me"), TextJSON("Alex"), TextJSON("yo"))); got != "You whisper to Alex: yo" {
+		t.Errorf("Decorate() plain = %q", got)
+	}
+	if got := plainOf(t, ct.Chat.Decorate(TextJSON("Me"), "", TextJSON("yo"))); got != "You whisper to : yo" {
+		t.Errorf("无目标 Decorate() plain = %q", got)
+	}
+}
+
+func TestApplyFilterMask(t *testing.T) {
+	if _, shown := ApplyFilterMask("bad", FilterFullyFiltered, nil); shown {
+		t.Error("完全过滤的消息不应显示")
+	}
+	if raw, _ := ApplyFilterMask("ok", FilterPassThrough, nil); plainOf(t, raw) != "ok" {
+		t.Errorf("不过滤 = %s", raw)
+	}
+
+	// 过滤第 3-5 个字符，emoji 占两个 UTF-16 单位
+	raw, shown := ApplyFilterMask("hi darn 😀!", FilterPartiallyFiltered, []int64{0b111 << 3})
+	if !shown {
+		t.Fatal("部分过滤的消息应显示")
+	}
+	if got := plainOf(t, raw); got != "hi ###n 😀!" {
+		t.Errorf("部分过滤 plain = %q", got)
+	}
+	if !strings.Contains(raw, "dark_gray") {
+		t.Errorf("被过滤部分应为深灰色: %s", raw)
+	}
+	if got := plainOf(t, mustFilter(t, "😀ab", []int64{0b100})); got != "😀#b" {
+		t.Errorf("emoji 后过滤 plain = %q", got)
+	}
+}
+
+func mustFilter(t *testing.T, content string, mask []int64) string {
+	t.Helper()
+	raw, shown := ApplyFilterMask(content, FilterPartiallyFiltered, mask)
+	if !shown {
+		t.Fatal("部分过滤的消息应显示")
+	}
+	return raw
+}
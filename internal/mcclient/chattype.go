@@ -0,0 +1,95 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/chat"
+	"gmcc/internal/mcclient/packet"
+)
+
+// setChatTypeRegistry 保存 registry_data 中的 minecraft:chat_type，没有数据的条目使用原版内置定义
+func (c *Client) setChatTypeRegistry(names []string, tags []map[string]any) {
+	types := make([]*chat.ChatType, len(names))
+	for i, tag := range tags {
+		if tag == nil {
+			types[i], _ = chat.DefaultChatType(names[i])
+			continue
+		}
+		t, err := chat.ParseChatType(tag)
+		if err != nil {
+			logx.Debugf("解析聊天类型 %s 失败: %v", names[i], err)
+			continue
+		}
+		types[i] = t
+	}
+
+	c.chatTypeMu.Lock()
+	defer c.chatTypeMu.Unlock()
+	c.chatTypeNames = names
+	c.chatTypes = types
+}
+
+// chatType 根据网络编号返回聊天类型及其注册名
+func (c *Client) chatType(id int32) (*chat.ChatType, string, bool) {
+	c.chatTypeMu.Lock()
+	defer c.chatTypeMu.Unlock()
+	if id < 0 || int(id) >= len(c.chatTypes) || c.chatTypes[id] == nil {
+		return nil, "", false
+	}
+	return c.chatTypes[id], c.chatTypeNames[id], true
+}
+
+// readChatTypeHolder 读取 player_chat 中的聊天类型: 0 表示内联定义，否则为注册表编号+1。
+// 未知编号返回 nil
+func (c *Client) readChatTypeHolder(r *bytes.Reader) (*chat.ChatType, string, error) {
+	id, err := packet.ReadVarInt(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("编号: %w", err)
+	}
+	if id != 0 {
+		t, name, ok := c.chatType(id - 1)
+		if !ok {
+			// 注册表缺失时只显示消息内容
+			logx.Debugf("未知的聊天类型编号: %d", id-1)
+		}
+		return t, name, nil
+	}
+
+	t := &chat.ChatType{}
+	if t.Chat, err = readInlineDecoration(r); err != nil {
+		return nil, "", fmt.Errorf("chat.%w", err)
+	}
+	if t.Narration, err = readInlineDecoration(r); err != nil {
+		return nil, "", fmt.Errorf("narration.%w", err)
+	}
+	return t, "", nil
+}
+
+// readInlineDecoration 读取内联装饰: 翻译键、参数编号列表、样式
+func readInlineDecoration(r *bytes.Reader) (chat.Decoration, error) {
+	var d chat.Decoration
+	var err error
+	if d.TranslationKey, err = packet.ReadString(r, r); err != nil {
+		return d, fmt.Errorf("translationKey: %w", err)
+	}
+	n, err := packet.ReadVarInt(r)
+	if err != nil {
+		return d, fmt.Errorf("parameters 数量: %w", err)
+	}
+	if n < 0 || int(n) > r.Len() {
+		return d, fmt.Errorf("parameters 数量无效: %d", n)
+	}
+	for i := int32(0); i < n; i++ {
+		p, err := packet.ReadVarInt(r)
+		if err != nil {
+			return d, fmt.Errorf("parameters[%d]: %w", i, err)
+		}
+		d.Parameters = append(d.Parameters, chat.DecorationParam(p))
+	}
+	if d.Style, err = readNBT(r); err != nil {
+		return d, fmt.Errorf("style: %w", err)
+	}
+	return d, nil
+}
@@ -0,0 +1,97 @@
+package mcclient
+
+import (
+	"testing"
+
+	"gmcc/internal/config"
+	"gmcc/internal/mcclient/packet"
+)
+
+// unsignedPlayerChat 编码不带签名的 player_chat，tail 从过滤掩码开始
+func unsignedPlayerChat(t *testing.T, content string, unsigned map[string]any, tail ...[]byte) []byte {
+	t.Helper()
+	data := packet.EncodeVarInt(0)
+	data = append(data, make([]byte, 16)...)
+	data = append(data, packet.EncodeVarInt(0)...)
+	data = append(data, packet.EncodeBool(false)...)
+	data = append(data, packet.EncodeString(content)...)
+	data = append(data, packet.EncodeInt64(0)...)
+	data = append(data, packet.EncodeInt64(0)...)
+	data = append(data, packet.EncodeVarInt(0)...)
+	data = append(data, packet.EncodeBool(unsigned != nil)...)
+	if unsigned != nil {
+		data = append(data, nbtComponent(t, unsigned)...)
+	}
+	for _, b := range tail {
+		data = append(data, b...)
+	}
+	return data
+}
+
+func nbtComponent(t *testing.T, v map[string]any) []byte {
+	t.Helper()
+	data, err := packet.EncodeAnonymousNBT(v)
+	if err != nil {
+		t.Fatalf("EncodeAnonymousNBT() error = %v", err)
+	}
+	return data
+}
+
+func TestHandlePlayerChatDecoration(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	var got []ChatMessage
+	c.SetChatHandler(func(m ChatMessage) { got = append(got, m) })
+
+	// 第一项使用服务端数据，第二项没有数据时使用原版定义
+	c.setChatTypeRegistry(
+		[]string{"minecraft:chat", "minecraft:msg_command_incoming"},
+		[]map[string]any{{
+			"chat": map[string]any{"translation_key": "chat.type.text", "parameters": []any{"sender", "content"}},
+		}, nil},
+	)
+	steve := nbtComponent(t, map[string]any{"text": "Steve"})
+	alex := nbtComponent(t, map[string]any{"text": "Alex"})
+	noTarget := packet.EncodeBool(false)
+	withTarget := append(packet.EncodeBool(true), alex...)
+	passThrough := packet.EncodeVarInt(0)
+
+	packets := [][]byte{
+		unsignedPlayerChat(t, "hello", nil, passThrough, packet.EncodeVarInt(1), steve, noTarget),
+		// 服务端改写后的内容优先显示
+		unsignedPlayerChat(t, "psst", map[string]any{"text": "[psst]"}, passThrough, packet.EncodeVarInt(2), steve, withTarget),
+		// 内联聊天类型: 参数编号 0=sender 2=content
+		unsignedPlayerChat(t, "waves", nil, passThrough, packet.EncodeVarInt(0),
+			packet.EncodeString("chat.type.emote"), packet.EncodeVarInt(2), packet.EncodeVarInt(0), packet.EncodeVarInt(2), nbtComponent(t, map[string]any{}),
+			packet.EncodeString("chat.type.emote"), packet.EncodeVarInt(0), nbtComponent(t, map[string]any{}),
+			steve, noTarget),
+		// 完全过滤的消息不显示
+		unsignedPlayerChat(t, "bad", nil, packet.EncodeVarInt(1), packet.EncodeVarInt(1), steve, noTarget),
+		// 部分过滤
+		unsignedPlayerChat(t, "oh no", nil, packet.EncodeVarInt(2), packet.EncodeVarInt(1), packet.EncodeInt64(0b11000), packet.EncodeVarInt(1), steve, noTarget),
+	}
+	for i, data := range packets {
+		if err := c.handlePlayerChatPacket(data); err != nil {
+			t.Fatalf("packet %d: handlePlayerChatPacket() error = %v", i, err)
+		}
+	}
+
+	want := []struct {
+		chatType, plain string
+		target          bool
+	}{
+		{"minecraft:chat", "<Steve> hello", false},
+		{"minecraft:msg_command_incoming", "Steve whispers to you: [psst]", true},
+		{"", "* Steve waves", false},
+		{"minecraft:chat", "<Steve> oh ##", false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("收到 %d 条消息, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		m := got[i]
+		if m.ChatType != w.chatType || m.PlainText != w.plain || (m.Target != "") != w.target || m.SenderName != "Steve" || m.Content == "" {
+			t.Errorf("消息 %d = %+v, want %+v", i, m, w)
+		}
+	}
+}
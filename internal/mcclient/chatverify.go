@@ -0,0 +1,172 @@
+package mcclient
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ChatVerification 玩家聊天消息的签名验证结果
+type ChatVerification int
+
+const (
+	ChatUnsigned ChatVerification = iota // 没有签名 (离线服务器或关闭了 secure chat)
+	ChatVerified                         // 签名有效且内容未被服务端改动
+	ChatModified                         // 签名有效，但服务端替换了显示内容或过滤了部分文字
+	ChatInvalid                          // 签名无效、会话缺失或消息链断开
+)
+
+func (v ChatVerification) String() string {
+	switch v {
+	case ChatVerified:
+		return "verified"
+	case ChatModified:
+		return "modified"
+	case ChatInvalid:
+		return "invalid"
+	default:
+		return "unsigned"
+	}
+}
+
+// messageSignatureCacheSize 与原版 MessageSignatureCache 一致，player_chat 中的历史签名以该缓存的下标引用
+const messageSignatureCacheSize = 128
+
+// messageSignatureCache 对应原版 MessageSignatureCache，必须与服务端为本连接维护的缓存保持同步
+type messageSignatureCache struct {
+	mu      sync.Mutex
+	entries [messageSignatureCacheSize][]byte
+}
+
+func (m *messageSignatureCache) get(id int32) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if id < 0 || id >= messageSignatureCacheSize || m.entries[id] == nil {
+		return nil, false
+	}
+	return m.entries[id], true
+}
+
+// push 把消息引用的历史签名和消息自身的签名移到缓存最前面，其余签名依次后移
+func (m *messageSignatureCache) push(lastSeen [][]byte, signature []byte) {
+	queue := slices.Clone(lastSeen)
+	if signature != nil {
+		queue = append(queue, signature)
+	}
+	pushed := make(map[string]bool, len(queue))
+	for _, sig := range queue {
+		pushed[string(sig)] = true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := 0; len(queue) > 0 && i < messageSignatureCacheSize; i++ {
+		old := m.entries[i]
+		m.entries[i] = queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if old != nil && !pushed[string(old)] {
+			queue = append([][]byte{old}, queue...)
+		}
+	}
+}
+
+func (m *messageSignatureCache) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = [messageSignatureCacheSize][]byte{}
+}
+
+// chatChain 一个玩家签名会话的消息链，消息序号必须递增，验证失败后整条链都不再可信
+type chatChain struct {
+	sessionID [16]byte
+	index     int32
+	started   bool
+	broken    bool
+}
+
+// signedPlayerChat player_chat 中参与签名验证的字段
+type signedPlayerChat struct {
+	sender    [16]byte
+	index     int32
+	signature []byte
+	content   string
+	timestamp int64
+	salt      int64
+	lastSeen  [][]byte
+	unpacked  bool // 历史签名是否都能从缓存中还原
+	modified  bool // 带有 unsignedContent 或过滤掩码
+}
+
+// verifyPlayerChat 用发送者的聊天会话公钥验证签名和消息链，返回验证结果和失败原因
+func (c *Client) verifyPlayerChat(msg *signedPlayerChat) (ChatVerification, error) {
+	if msg.signature == nil {
+		return ChatUnsigned, nil
+	}
+	entry, ok := c.TabList.Get(msg.sender)
+	if !ok || entry.ChatSession == nil {
+		return ChatInvalid, fmt.Errorf("发送者没有聊天签名会话")
+	}
+	session := entry.ChatSession
+	if time.Now().After(session.ExpiresAt) {
+		return ChatInvalid, fmt.Errorf("发送者的聊天公钥已过期")
+	}
+
+	c.chatChainMu.Lock()
+	defer c.chatChainMu.Unlock()
+	if c.chatChains == nil {
+		c.chatChains = make(map[[16]byte]*chatChain)
+	}
+	chain := c.chatChains[msg.sender]
+	if chain == nil || chain.sessionID != session.SessionID {
+		chain = &chatChain{sessionID: session.SessionID}
+		c.chatChains[msg.sender] = chain
+	}
+	if chain.broken {
+		return ChatInvalid, fmt.Errorf("消息链已断开")
+	}
+
+	fail := func(err error) (ChatVerification, error) {
+		chain.broken = true
+		return ChatInvalid, err
+	}
+	if chain.started && msg.index <= chain.index {
+		return fail(fmt.Errorf("消息序号 %d 不大于上一条 %d", msg.index, chain.index))
+	}
+	if !msg.unpacked {
+		return fail(fmt.Errorf("无法还原消息引用的历史签名"))
+	}
+	key, err := x509.ParsePKIXPublicKey(session.PublicKey)
+	if err != nil {
+		return ChatInvalid, fmt.Errorf("解析发送者公钥失败: %w", err)
+	}
+	pub, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return ChatInvalid, fmt.Errorf("发送者公钥不是 RSA 公钥")
+	}
+
+	body := buildChatSignableBody(msg.sender, session.SessionID, msg.index, msg.content, msg.timestamp, msg.salt, msg.lastSeen)
+	sum := sha256.Sum256(body)
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, sum[:], msg.signature); err != nil {
+		return fail(fmt.Errorf("签名不匹配: %w", err))
+	}
+	chain.started = true
+	chain.index = msg.index
+
+	if msg.modified {
+		return ChatModified, nil
+	}
+	return ChatVerified, nil
+}
+
+// resetChatVerification 切换服务器或重连后，服务端会重新建立签名缓存和消息链
+func (c *Client) resetChatVerification() {
+	c.signatureCache.reset()
+	c.chatChainMu.Lock()
+	c.chatChains = nil
+	c.chatChainMu.Unlock()
+}
@@ -0,0 +1,231 @@
+package mcclient
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"gmcc/internal/config"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/player"
+)
+
+type testChatSender struct {
+	uuid    [16]byte
+	session [16]byte
+	key     *rsa.PrivateKey
+}
+
+func newTestChatSender(t *testing.T, c *Client, uuid byte) *testChatSender {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	s := &testChatSender{uuid: [16]byte{uuid}, session: [16]byte{0xAA, uuid}, key: key}
+	s.join(t, c)
+	return s
+}
+
+// join 通过 player_info_update 的 initialize_chat 下发会话公钥
+func (s *testChatSender) join(t *testing.T, c *Client) {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(&s.key.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey() error = %v", err)
+	}
+	c.TabList.Update(player.TabAddPlayer|player.TabInitializeChat, player.TabEntry{
+		UUID: s.uuid,
+		Name: "Steve",
+		ChatSession: &player.ChatSession{
+			SessionID: s.session,
+			ExpiresAt: time.Now().Add(time.Hour),
+			PublicKey: der,
+		},
+	})
+}
+
+// sign 用 buildChatSignableBody 签名，只用于测试消息链状态；
+// 签名体布局本身由 TestVerifyPlayerChatFixedVector 的固定向量校验
+func (s *testChatSender) sign(t *testing.T, index int32, content string, lastSeen [][]byte) *signedPlayerChat {
+	t.Helper()
+	msg := &signedPlayerChat{
+		sender: s.uuid, index: index, content: content,
+		timestamp: 1700000000000, salt: 42, lastSeen: lastSeen, unpacked: true,
+	}
+	sum := sha256.Sum256(buildChatSignableBody(s.uuid, s.session, index, content, msg.timestamp, msg.salt, lastSeen))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, sum[:])
+	if err != nil {
+		t.Fatalf("SignPKCS1v15() error = %v", err)
+	}
+	msg.signature = sig
+	return msg
+}
+
+func TestVerifyPlayerChat(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	s := newTestChatSender(t, c, 1)
+
+	check := func(name string, msg *signedPlayerChat, want ChatVerification) {
+		t.Helper()
+		if got, err := c.verifyPlayerChat(msg); got != want {
+			t.Errorf("%s: verifyPlayerChat() = %v (%v), want %v", name, got, err, want)
+		}
+	}
+
+	check("无签名", &signedPlayerChat{sender: s.uuid, content: "hi"}, ChatUnsigned)
+	first := s.sign(t, 0, "hello", nil)
+	check("有效签名", first, ChatVerified)
+
+	modified := s.sign(t, 1, "hello again", [][]byte{first.signature})
+	modified.modified = true
+	check("服务端改动显示内容", modified, ChatModified)
+
+	unknown := s.sign(t, 2, "who", nil)
+	unknown.sender = [16]byte{9}
+	check("未知发送者", unknown, ChatInvalid)
+
+	// 重放旧序号会断开消息链，之后的消息即使签名正确也不可信
+	check("序号回退", s.sign(t, 1, "replay", nil), ChatInvalid)
+	check("链断开后", s.sign(t, 5, "later", nil), ChatInvalid)
+
+	// 重新加入后会话更换，消息链重新开始
+	s.session = [16]byte{0xBB}
+	s.join(t, c)
+	check("新会话", s.sign(t, 0, "back", nil), ChatVerified)
+
+	tampered := s.sign(t, 1, "original", nil)
+	tampered.content = "forged"
+	check("内容被篡改", tampered, ChatInvalid)
+}
+
+// 固定向量: 签名体按原版 SignedMessageBody 布局独立构造 (时间戳为秒)，由 openssl 签名，
+// 不经过 buildChatSignableBody
+const (
+	vectorPublicKey = "" +
+		"30820122300d06092a864886f70d01010105000382010f003082010a02820101008f7154235ca64dd4d00c806e9b51d1" +
+		"33808e08317d47786c128d9821e4aa1c1db62e34ddf0669764ba77e86b401dc3267e45b71facaf5e1bcdbfd70ff04792" +
+		"3d1b9fbbd130af49681903abdd48945a0079caf0a89917857000bf91b857421738c6842273403a0ec55aa4dc35698736" +
+		"ba16c336b789aea0e8b8a28d3663807761ee60c1c5b93e1edbc4c5d0569e925ab6b8216cd4e96c4e84e0cd2063a1f69f" +
+		"30d2e40ec7493ec7442766829724a1371fecaac616dcd61d529714c95885009e93c4e506e1afcbad5873d5bdb7a14696" +
+		"e15ebfdac3cf1a433e003a22e76e473f4866199ad35ef40678f73e7a25e99a9b2cf00de16e629f101b21ee0b011837c8" +
+		"7d0203010001"
+	vectorSignature = "" +
+		"7b1d64168ed7b6e1c0cde21830ad79d780c1d8a74fd992cdfb7efea2cade06daf9e1ea73a4f4337c4722ec8a3a0c0bdf" +
+		"0b63f55e73dd1f11bf2b5aeacfa7adfdfcf54785351305034c40b719356a81751ec1d4e92f2ed14353496c0477b2b09c" +
+		"ceaa10af10567d4a670606075fd224fdc6f2cd37d1973e06a101fdd50693e9d20ccc476a7dd27d26d0892fabed86dfdc" +
+		"7ffa610ddd88878ac8c6c958758620c2660242b4e8453118f8070418af0fcb336ed5cab1f3cb6bb89ae461545fc3f47b" +
+		"bd1f68785a2f43e5d9ac32ac09460bfefafd6bf085b5c8ad9a58458f53c4be3722ce0790895e5757e5880bdeefbe84ba" +
+		"2ff9f2d3c3e48e8683629efec6f06681"
+)
+
+func TestVerifyPlayerChatFixedVector(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	der, _ := hex.DecodeString(vectorPublicKey)
+	sig, _ := hex.DecodeString(vectorSignature)
+	sender := [16]byte{0x85, 0x3c, 0x80, 0xef, 0x3c, 0x37, 0x49, 0xfd, 0xaa, 0x49, 0x93, 0x8b, 0x67, 0x4a, 0xda, 0xe6}
+	session := [16]byte{0x0b, 0x1c, 0x2d, 0x3e, 0x4f, 0x50, 0x46, 0x17, 0x82, 0x93, 0xa4, 0xb5, 0xc6, 0xd7, 0xe8, 0xf9}
+	c.TabList.Update(player.TabAddPlayer|player.TabInitializeChat, player.TabEntry{
+		UUID:        sender,
+		Name:        "Steve",
+		ChatSession: &player.ChatSession{SessionID: session, ExpiresAt: time.Now().Add(time.Hour), PublicKey: der},
+	})
+
+	msg := &signedPlayerChat{
+		sender: sender, index: 3, signature: sig, content: "hello, 世界",
+		timestamp: 1718000000123, salt: -6182396104219813632, unpacked: true,
+	}
+	if got, err := c.verifyPlayerChat(msg); got != ChatVerified {
+		t.Fatalf("verifyPlayerChat() = %v (%v), want verified", got, err)
+	}
+	// 时间戳落在另一秒时签名体不同
+	msg.index, msg.timestamp = 4, 1718000001000
+	if got, _ := c.verifyPlayerChat(msg); got != ChatInvalid {
+		t.Errorf("另一秒 verifyPlayerChat() = %v, want invalid", got)
+	}
+}
+
+func TestMessageSignatureCachePush(t *testing.T) {
+	var m messageSignatureCache
+	a, b, c := testSignature(1), testSignature(2), testSignature(3)
+
+	m.push(nil, a)
+	m.push([][]byte{a}, b)
+	for i, want := range [][]byte{b, a} {
+		if got, ok := m.get(int32(i)); !ok || got[0] != want[0] {
+			t.Errorf("get(%d) = %v, want %d", i, ok, want[0])
+		}
+	}
+	// 已在缓存中的签名被移到前面，不会重复
+	m.push([][]byte{a}, c)
+	for i, want := range [][]byte{c, a, b} {
+		if got, ok := m.get(int32(i)); !ok || got[0] != want[0] {
+			t.Errorf("get(%d) = %v, want %d", i, ok, want[0])
+		}
+	}
+	if _, ok := m.get(3); ok {
+		t.Error("get(3) 应不存在")
+	}
+}
+
+func encodePlayerChat(msg *signedPlayerChat, packedIDs []int32) []byte {
+	data := packet.EncodeVarInt(0)
+	data = append(data, msg.sender[:]...)
+	data = append(data, packet.EncodeVarInt(msg.index)...)
+	data = append(data, packet.EncodeBool(true)...)
+	data = append(data, msg.signature...)
+	data = append(data, packet.EncodeString(msg.content)...)
+	data = append(data, packet.EncodeInt64(msg.timestamp)...)
+	data = append(data, packet.EncodeInt64(msg.salt)...)
+	data = append(data, packet.EncodeVarInt(int32(len(packedIDs)))...)
+	for _, id := range packedIDs {
+		data = append(data, packet.EncodeVarInt(id)...)
+	}
+	data = append(data, packet.EncodeBool(false)...) // unsignedContent
+	data = append(data, packet.EncodeVarInt(0)...)   // filterMask: pass through
+	data = append(data, packet.EncodeVarInt(1)...)   // chatType 注册表引用
+	name, _ := packet.EncodeAnonymousNBT(map[string]any{"text": "Steve"})
+	data = append(data, name...)
+	data = append(data, packet.EncodeBool(false)...) // targetName
+	return data
+}
+
+func TestHandlePlayerChatVerification(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	s := newTestChatSender(t, c, 1)
+	var got []ChatMessage
+	c.SetChatHandler(func(m ChatMessage) { got = append(got, m) })
+
+	first := s.sign(t, 0, "hello", nil)
+	if err := c.handlePlayerChatPacket(encodePlayerChat(first, nil)); err != nil {
+		t.Fatalf("handlePlayerChatPacket() error = %v", err)
+	}
+	// 第二条消息以缓存下标 0 (id=1) 引用第一条消息的签名
+	second := s.sign(t, 1, "world", [][]byte{first.signature})
+	if err := c.handlePlayerChatPacket(encodePlayerChat(second, []int32{1})); err != nil {
+		t.Fatalf("handlePlayerChatPacket() error = %v", err)
+	}
+	// 引用不存在的缓存项时无法还原签名体
+	third := s.sign(t, 2, "!", nil)
+	if err := c.handlePlayerChatPacket(encodePlayerChat(third, []int32{50})); err != nil {
+		t.Fatalf("handlePlayerChatPacket() error = %v", err)
+	}
+
+	want := []ChatVerification{ChatVerified, ChatVerified, ChatInvalid}
+	if len(got) != len(want) {
+		t.Fatalf("收到 %d 条消息, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Verification != want[i] {
+			t.Errorf("消息 %d (%s): Verification = %v, want %v", i, got[i].PlainText, got[i].Verification, want[i])
+		}
+	}
+}
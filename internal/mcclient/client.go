@@ -10,18 +10,24 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gmcc/internal/auth/microsoft"
 	mcauth "gmcc/internal/auth/minecraft"
 	"gmcc/internal/config"
 	"gmcc/internal/constants"
+	"gmcc/internal/dialog"
 	"gmcc/internal/entity"
 	"gmcc/internal/logx"
+	"gmcc/internal/mapdata"
+	"gmcc/internal/mcclient/chat"
 	"gmcc/internal/mcclient/packet"
 	"gmcc/internal/mcclient/protocol"
 	"gmcc/internal/player"
+	"gmcc/internal/scoreboard"
 	"gmcc/internal/session"
+	"gmcc/internal/world"
 )
 
 var errOnlineAuthRequired = errors.New("online auth required")
@@ -53,23 +59,65 @@ type Client struct {
 	lastAFKPacket time.Time
 	ticker        *time.Ticker
 	tickerDone    chan struct{}
+	tickerExited  chan struct{}
 	chatHandler   func(ChatMessage)
 	chatSessionOK bool
 	chatSession   *secureChatSession
 	commandSign   map[string]signableCommandTarget
 	chatSignMu    sync.Mutex
+	lastSeen      lastSeenTracker
 
-	Player    *player.Player
-	players   map[string]playerInfo
-	playersMu sync.RWMutex
+	signatureCache messageSignatureCache
+	chatChainMu    sync.Mutex
+	chatChains     map[[16]byte]*chatChain
+
+	chatTypeMu    sync.Mutex
+	chatTypeNames []string
+	chatTypes     []*chat.ChatType
+
+	Player  *player.Player
+	clickMu sync.Mutex
+	TabList *player.TabList
+
+	Scoreboard *scoreboard.Scoreboard
+	BossBars   *player.BossBars
+	World      *world.State
+	Maps       *mapdata.Store
+
+	lastMapSave time.Time
+
+	title        titleState
+	titleHandler func(TitleMessage)
 
 	// 实体跟踪
 	entityTracker *entity.Tracker
 	NearbyPlayers *player.NearbyTracker
-}
 
-type playerInfo struct {
-	uuid [16]byte
+	combat combatState
+	eat    eatState
+
+	death          deathState
+	deathHandler   func(DeathEvent)
+	respawnHandler func()
+
+	dimensionHandler func(DimensionChangeEvent)
+	bookHandler      func(BookOpenEvent)
+
+	signMu      sync.Mutex
+	signEditor  *SignEditorEvent
+	signHandler func(SignEditorEvent)
+
+	dialogMu      sync.Mutex
+	openDialog    *dialog.Dialog
+	dialogs       []*dialog.Dialog // minecraft:dialog 注册表，按编号
+	dialogHandler func(DialogEvent)
+
+	merchantMu      sync.Mutex
+	merchant        *player.Merchant
+	merchantHandler func(MerchantEvent)
+
+	// use_item 等方块交互的确认序号
+	sequence atomic.Int32
 }
 
 func New(cfg *config.Config) *Client {
@@ -82,8 +130,18 @@ func New(cfg *config.Config) *Client {
 		uuid:        packet.OfflineUUID(name),
 		commandSign: map[string]signableCommandTarget{},
 		Player:      player.NewPlayer(),
-		players:     make(map[string]playerInfo),
-	}
+		TabList:     player.NewTabList(),
+		Scoreboard:  scoreboard.New(),
+		BossBars:    player.NewBossBars(),
+		World:       world.NewState(),
+		Maps:        mapdata.NewStore(),
+	}
+	client.combat.auto = cfg.Combat.AutoAttack
+	client.combat.autoRange = cfg.Combat.Range
+	client.combat.targets = cfg.Combat.Targets
+	client.eat.auto = cfg.AutoEat.Enabled
+	client.title.resetTimes()
+	client.SetAutoEatOptions(cfg.AutoEat.Threshold, cfg.AutoEat.Blacklist)
 
 	return client
 }
@@ -134,9 +192,18 @@ func (c *Client) connectAndLoop(ctx context.Context, host string, port uint16, u
 	c.inPlay = false
 	c.chatSessionOK = false
 	c.chatSession = nil
+	c.lastSeen.reset()
+	c.resetChatVerification()
 	c.commandSign = map[string]signableCommandTarget{}
 	c.lastAFKPacket = time.Now()
-	c.tickerDone = make(chan struct{})
+	c.TabList.Clear()
+	c.Scoreboard.Clear()
+	c.BossBars.Clear()
+	c.resetTitles()
+	c.World.Reset()
+	c.closeSignEditor()
+	c.resetDialogs()
+	c.Maps.Clear()
 
 	addr := net.JoinHostPort(host, strconv.Itoa(int(port)))
 	dialer := net.Dialer{Timeout: constants.DialTimeout}
@@ -339,6 +406,9 @@ func tokenExpiresAt(expiresIn int) time.Time {
 
 // initializeTrackers 初始化实体和玩家跟踪器
 func (c *Client) initializeTrackers() {
+	if c.entityTracker != nil {
+		c.entityTracker.Stop()
+	}
 	// 创建实体跟踪器
 	c.entityTracker = entity.NewTracker()
 
@@ -346,15 +416,35 @@ func (c *Client) initializeTrackers() {
 	c.NearbyPlayers = player.NewNearbyTracker(c.entityTracker, c.getPlayerInfoByUUID)
 }
 
+// Entities 返回实体跟踪器 (进入游戏前为 nil)
+func (c *Client) Entities() *entity.Tracker {
+	return c.entityTracker
+}
+
+// Vehicle 返回自身正在骑乘的载具实体 ID
+func (c *Client) Vehicle() (int32, bool) {
+	if c.entityTracker == nil || c.Player == nil {
+		return 0, false
+	}
+	return c.entityTracker.VehicleOf(c.Player.GetEntityID())
+}
+
 // startTicker 启动游戏刻循环，发送位置更新
 func (c *Client) startTicker() {
-	c.ticker = time.NewTicker(constants.TickInterval)
+	// 重新配置后会再次启动，每次使用新的 ticker 和 done 通道
+	ticker := time.NewTicker(constants.TickInterval)
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	c.ticker = ticker
+	c.tickerDone = done
+	c.tickerExited = exited
 	go func() {
+		defer close(exited)
 		for {
 			select {
-			case <-c.tickerDone:
+			case <-done:
 				return
-			case <-c.ticker.C:
+			case <-ticker.C:
 				c.tick()
 			}
 		}
@@ -362,7 +452,8 @@ func (c *Client) startTicker() {
 	logx.Debugf("已启动游戏刻循环 (20Hz)")
 }
 
-// stopTicker 停止游戏刻循环
+// stopTicker 停止游戏刻循环，并等待正在执行的 tick 结束，
+// 之后不会再发送位置等 Play 阶段数据包 (例如在 configuration_acknowledged 之后)
 func (c *Client) stopTicker() {
 	if c.ticker != nil {
 		c.ticker.Stop()
@@ -372,6 +463,10 @@ func (c *Client) stopTicker() {
 		close(c.tickerDone)
 		c.tickerDone = nil
 	}
+	if c.tickerExited != nil {
+		<-c.tickerExited
+		c.tickerExited = nil
+	}
 }
 
 // tick 每个游戏刻执行一次
@@ -384,21 +479,18 @@ func (c *Client) tick() {
 	// 使用完整的位置+旋转包确保服务器收到所有状态
 	_ = c.SendPlayerPositionAndRotation(x, y, z, yaw, pitch, onGround)
 
+	c.autoAttackTick()
+	c.autoEatTick()
+	c.autoSaveMapsTick()
+
 	// 发送 ClientTickEnd
 	_ = c.conn.WritePacket(protocol.PlayServerClientTickEnd, nil)
 }
 
 // getPlayerInfoByUUID 通过UUID查找玩家用户名
 func (c *Client) getPlayerInfoByUUID(uuid [16]byte) (username string, found bool) {
-	c.playersMu.RLock()
-	defer c.playersMu.RUnlock()
-
-	for name, info := range c.players {
-		if info.uuid == uuid {
-			return name, true
-		}
-	}
-	return "", false
+	entry, ok := c.TabList.Get(uuid)
+	return entry.Name, ok
 }
 
 func (c *Client) handlePacket(pkt packet.Packet) error {
@@ -500,7 +592,11 @@ func (c *Client) SendSetCarriedItem(slot int16) error {
 	binary.BigEndian.PutUint16(buf, uint16(slot))
 	payload = append(payload, buf...)
 
-	return c.conn.WritePacket(protocol.PlayServerSetCarriedItem, payload)
+	if err := c.conn.WritePacket(protocol.PlayServerSetCarriedItem, payload); err != nil {
+		return err
+	}
+	c.Player.SetHeldSlot(int8(slot))
+	return nil
 }
 
 // SendInteract 发送实体交互包 (右键点击实体)
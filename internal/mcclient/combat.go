@@ -0,0 +1,193 @@
+package mcclient
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"gmcc/internal/constants"
+	"gmcc/internal/entity"
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/mcclient/protocol"
+	"gmcc/internal/player"
+	"gmcc/internal/registry"
+)
+
+const (
+	// 玩家眼睛相对脚底的高度 (站立)
+	playerEyeHeight = 1.62
+	// entity_interaction_range 属性默认值，创造模式额外 +2
+	survivalAttackReach = 3.0
+	creativeAttackReach = 5.0
+)
+
+// combatState 记录攻击冷却与自动攻击设置
+type combatState struct {
+	mu         sync.Mutex
+	lastAttack time.Time
+	auto       bool
+	autoRange  float64
+	targets    []string // 实体类型，为空时攻击所有敌对生物
+}
+
+// SendSwing 发送挥手包 (swing)
+func (c *Client) SendSwing(hand int32) error {
+	if c.state != protocol.StatePlay {
+		return fmt.Errorf("当前状态不是 Play，无法发送挥手数据包")
+	}
+	if c.conn == nil {
+		return fmt.Errorf("连接未初始化")
+	}
+	return c.conn.WritePacket(protocol.PlayServerSwing, packet.EncodeVarInt(hand))
+}
+
+// Attack 转向并攻击实体，目标必须在攻击距离内。
+// 不检查冷却，未满蓄力时伤害会按原版规则降低，需要时先调用 AttackStrength。
+func (c *Client) Attack(entityID int32) error {
+	if c.entityTracker == nil {
+		return fmt.Errorf("实体跟踪器未初始化")
+	}
+	e, ok := c.entityTracker.Get(entityID)
+	if !ok {
+		return fmt.Errorf("实体 %d 不存在", entityID)
+	}
+	box := e.BoundingBox()
+
+	eye := c.eyePosition()
+	reach := c.attackReach()
+	if d := box.DistanceSqTo(eye); d > reach*reach {
+		return fmt.Errorf("目标超出攻击距离 (%.1f > %.1f 格)", math.Sqrt(d), reach)
+	}
+
+	yaw, pitch := lookAngles(eye, box.Center())
+	c.Player.SetRotation(yaw, pitch)
+	_, _, _, _, _, onGround := c.Player.GetMovementState()
+	if err := c.SendPlayerRotation(yaw, pitch, onGround); err != nil {
+		return err
+	}
+	if err := c.SendInteract(entityID, protocol.InteractActionAttack, protocol.HandMainHand, false); err != nil {
+		return err
+	}
+	if err := c.SendSwing(protocol.HandMainHand); err != nil {
+		return err
+	}
+
+	c.combat.mu.Lock()
+	c.combat.lastAttack = time.Now()
+	c.combat.mu.Unlock()
+	return nil
+}
+
+// AttackStrength 返回当前攻击蓄力 (0-1)，按主手物品的攻击速度计算
+func (c *Client) AttackStrength() float32 {
+	c.combat.mu.Lock()
+	last := c.combat.lastAttack
+	c.combat.mu.Unlock()
+	if last.IsZero() {
+		return 1
+	}
+	return attackStrength(time.Since(last), c.Player.GetHeldItem().AttackCooldownTicks())
+}
+
+// attackStrength 与原版 getAttackStrengthScale(0.5) 一致
+func attackStrength(elapsed time.Duration, cooldownTicks float64) float32 {
+	if cooldownTicks <= 0 {
+		return 1
+	}
+	ticks := float64(elapsed) / float64(constants.TickInterval)
+	return float32(math.Min(1, math.Max(0, (ticks+0.5)/cooldownTicks)))
+}
+
+// SetAutoAttack 开启或关闭自动攻击
+func (c *Client) SetAutoAttack(enabled bool) {
+	c.combat.mu.Lock()
+	defer c.combat.mu.Unlock()
+	c.combat.auto = enabled
+}
+
+// AutoAttackEnabled 返回自动攻击是否开启
+func (c *Client) AutoAttackEnabled() bool {
+	c.combat.mu.Lock()
+	defer c.combat.mu.Unlock()
+	return c.combat.auto
+}
+
+// SetAutoAttackTargets 设置自动攻击的搜索距离和实体类型，types 为空时攻击所有敌对生物
+func (c *Client) SetAutoAttackTargets(distance float64, types []string) {
+	c.combat.mu.Lock()
+	defer c.combat.mu.Unlock()
+	c.combat.autoRange = distance
+	c.combat.targets = append([]string(nil), types...)
+}
+
+// autoAttackTick 在游戏刻中调用，蓄力满后攻击范围内最近的目标
+func (c *Client) autoAttackTick() {
+	c.combat.mu.Lock()
+	enabled, distance := c.combat.auto, c.combat.autoRange
+	filter := autoAttackFilter(c.combat.targets)
+	c.combat.mu.Unlock()
+
+	// 攻击不会打断进食，但挥手时转向会让进食看起来很怪，吃完再打
+	if !enabled || c.entityTracker == nil || c.eat.isEating() || c.AttackStrength() < 1 {
+		return
+	}
+
+	eye := c.eyePosition()
+	target, ok := c.entityTracker.Nearest(eye, filter)
+	if !ok {
+		return
+	}
+	distance = math.Min(distance, c.attackReach())
+	if target.BoundingBox().DistanceSqTo(eye) > distance*distance {
+		return
+	}
+	if err := c.Attack(target.ID); err != nil {
+		logx.Debugf("自动攻击 %s(%d) 失败: %v", target.Type, target.ID, err)
+	}
+}
+
+// autoAttackFilter 选出存活的目标，types 为空时选择敌对生物
+func autoAttackFilter(types []string) entity.Filter {
+	filters := make([]entity.Filter, 0, len(types))
+	for _, t := range types {
+		filters = append(filters, entity.OfType(t))
+	}
+	hostile := entity.OfCategory(registry.CategoryMonster)
+
+	return func(e *entity.Entity) bool {
+		if e.IsDead() {
+			return false
+		}
+		if len(filters) == 0 {
+			return hostile(e)
+		}
+		for _, f := range filters {
+			if f(e) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (c *Client) eyePosition() entity.Position {
+	x, y, z := c.Player.GetPosition()
+	return entity.Position{X: x, Y: y + playerEyeHeight, Z: z}
+}
+
+func (c *Client) attackReach() float64 {
+	if c.Player.GetGameMode() == player.GameModeCreative {
+		return creativeAttackReach
+	}
+	return survivalAttackReach
+}
+
+// lookAngles 计算从 from 看向 to 的 yaw/pitch (度)
+func lookAngles(from, to entity.Position) (yaw, pitch float32) {
+	dx, dy, dz := to.X-from.X, to.Y-from.Y, to.Z-from.Z
+	yaw = float32(math.Atan2(-dx, dz) * 180 / math.Pi)
+	pitch = float32(math.Atan2(-dy, math.Sqrt(dx*dx+dz*dz)) * 180 / math.Pi)
+	return yaw, pitch
+}
@@ -0,0 +1,53 @@
+package mcclient
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"gmcc/internal/entity"
+)
+
+func TestAttackStrength(t *testing.T) {
+	// 剑的冷却为 12.5 tick
+	tests := []struct {
+		elapsed time.Duration
+		want    float32
+	}{
+		{0, 0.04},
+		{300 * time.Millisecond, 0.52},
+		{time.Second, 1},
+	}
+	for _, tt := range tests {
+		if got := attackStrength(tt.elapsed, 12.5); math.Abs(float64(got-tt.want)) > 1e-6 {
+			t.Errorf("attackStrength(%v) = %v, want %v", tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+func TestAutoAttackFilter(t *testing.T) {
+	tracker := entity.NewTracker()
+	defer tracker.Stop()
+	zombie := tracker.SpawnEntity(1, "minecraft:zombie", [16]byte{1}, entity.Position{}, entity.Vector3{})
+	cow := tracker.SpawnEntity(2, "minecraft:cow", [16]byte{2}, entity.Position{}, entity.Vector3{})
+
+	hostile := autoAttackFilter(nil)
+	if !hostile(zombie) || hostile(cow) {
+		t.Error("默认应只攻击敌对生物")
+	}
+	if cows := autoAttackFilter([]string{"cow"}); !cows(cow) || cows(zombie) {
+		t.Error("指定类型后应只攻击该类型")
+	}
+
+	tracker.UpdateMetadata(1, []entity.MetadataEntry{{Index: 9, Type: entity.MetaFloat, Value: float32(0)}})
+	if hostile(zombie) {
+		t.Error("不应攻击已死亡的实体")
+	}
+}
+
+func TestLookAngles(t *testing.T) {
+	yaw, pitch := lookAngles(entity.Position{}, entity.Position{X: -1, Y: 1, Z: 0})
+	if yaw != 90 || math.Abs(float64(pitch)+45) > 1e-4 {
+		t.Errorf("lookAngles() = (%v, %v), want (90, -45)", yaw, pitch)
+	}
+}
@@ -0,0 +1,298 @@
+package mcclient
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"gmcc/internal/item"
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/mcclient/protocol"
+	"gmcc/internal/player"
+)
+
+// ItemFilter 用于批量存取时筛选物品
+type ItemFilter func(stack *item.ItemStack) bool
+
+// ClickContainer 在当前窗口 (已打开的容器或玩家背包) 发送一次点击。
+// 变化的槽位由客户端预测，服务端不一致时会通过 container_set_content 纠正。
+func (c *Client) ClickContainer(slot int16, button int8, mode player.ClickMode) error {
+	c.clickMu.Lock()
+	defer c.clickMu.Unlock()
+	return c.clickLocked(slot, button, mode)
+}
+
+// QuickCraft 拖拽分配光标上的物品到多个槽位 (kind 见 player.QuickCraft*)
+func (c *Client) QuickCraft(slots []int16, kind int8) error {
+	c.clickMu.Lock()
+	defer c.clickMu.Unlock()
+
+	if err := c.checkClickReady(); err != nil {
+		return err
+	}
+	state := c.Player.GetActiveContainer()
+	carried := c.Player.GetCarried()
+	result, err := player.SimulateQuickCraft(state, carried, slots, kind, c.isCreative())
+	if err != nil {
+		return err
+	}
+
+	// 开始 -> 逐格添加 -> 结束，只有结束包携带槽位变化
+	empty := &player.ClickResult{Carried: carried}
+	if err := c.sendContainerClick(state, player.SlotOutside, kind<<2, player.ClickQuickCraft, empty); err != nil {
+		return err
+	}
+	for _, s := range slots {
+		if err := c.sendContainerClick(state, s, kind<<2|1, player.ClickQuickCraft, empty); err != nil {
+			return err
+		}
+	}
+	if err := c.sendContainerClick(state, player.SlotOutside, kind<<2|2, player.ClickQuickCraft, result); err != nil {
+		return err
+	}
+	c.Player.ApplyClick(state.WindowID, result)
+	return nil
+}
+
+// MoveItem 将 from 槽位的 count 个物品移动到 to 槽位 (count<=0 表示整组)
+func (c *Client) MoveItem(from, to int16, count int32) error {
+	c.clickMu.Lock()
+	defer c.clickMu.Unlock()
+
+	if !c.Player.GetCarried().IsEmpty() {
+		return fmt.Errorf("光标上已有物品，无法移动")
+	}
+	state := c.Player.GetActiveContainer()
+	if state == nil {
+		return fmt.Errorf("没有可操作的容器")
+	}
+	src := state.Slot(int(from))
+	if src.IsEmpty() {
+		return fmt.Errorf("槽位 %d 为空", from)
+	}
+	if count <= 0 || count > src.Count {
+		count = src.Count
+	}
+	// 右键放到不同物品上会交换整组而不是放下一个
+	if dst := state.Slot(int(to)); count < src.Count && !dst.IsEmpty() && !dst.SameItem(src) {
+		return fmt.Errorf("槽位 %d 已有其他物品，无法部分移动", to)
+	}
+
+	if err := c.clickLocked(from, 0, player.ClickPickup); err != nil {
+		return err
+	}
+	if count == src.Count {
+		if err := c.clickLocked(to, 0, player.ClickPickup); err != nil {
+			return err
+		}
+	} else {
+		for i := int32(0); i < count; i++ {
+			if err := c.clickLocked(to, 1, player.ClickPickup); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 剩余物品 (或交换回来的物品) 放回原槽位
+	if !c.Player.GetCarried().IsEmpty() {
+		return c.clickLocked(from, 0, player.ClickPickup)
+	}
+	return nil
+}
+
+// DepositAll 将玩家背包中符合条件的物品 Shift 点击存入已打开的容器，返回移动的槽位数
+func (c *Client) DepositAll(filter ItemFilter) (int, error) {
+	c.clickMu.Lock()
+	defer c.clickMu.Unlock()
+
+	state, err := c.openContainerState()
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for i := state.PlayerSlotOffset(); i < len(state.Slots); i++ {
+		stack := state.Slot(i)
+		if stack.IsEmpty() || (filter != nil && !filter(stack)) {
+			continue
+		}
+		if err := c.clickLocked(int16(i), 0, player.ClickQuickMove); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+	return moved, nil
+}
+
+// WithdrawMatching 从已打开的容器取出最多 count 个符合条件的物品 (count<=0 表示全部)，返回实际取出数量
+func (c *Client) WithdrawMatching(filter ItemFilter, count int32) (int32, error) {
+	c.clickMu.Lock()
+	defer c.clickMu.Unlock()
+
+	state, err := c.openContainerState()
+	if err != nil {
+		return 0, err
+	}
+
+	var taken int32
+	for i := 0; i < state.ContainerSlots(); i++ {
+		if count > 0 && taken >= count {
+			break
+		}
+		stack := state.Slot(i)
+		if stack.IsEmpty() || (filter != nil && !filter(stack)) {
+			continue
+		}
+
+		if count <= 0 || stack.Count <= count-taken {
+			if err := c.clickLocked(int16(i), 0, player.ClickQuickMove); err != nil {
+				return taken, err
+			}
+			after := c.Player.GetActiveContainer().Slot(i)
+			if after.IsEmpty() {
+				taken += stack.Count
+			} else {
+				taken += stack.Count - after.Count
+			}
+			continue
+		}
+
+		// 只需要部分物品: 拿起整组，逐个放入背包空位，再把剩余放回
+		need := count - taken
+		target := c.findEmptyPlayerSlot()
+		if target < 0 {
+			return taken, fmt.Errorf("背包已满")
+		}
+		if err := c.clickLocked(int16(i), 0, player.ClickPickup); err != nil {
+			return taken, err
+		}
+		for j := int32(0); j < need; j++ {
+			if err := c.clickLocked(int16(target), 1, player.ClickPickup); err != nil {
+				return taken, err
+			}
+		}
+		if err := c.clickLocked(int16(i), 0, player.ClickPickup); err != nil {
+			return taken, err
+		}
+		taken += need
+	}
+	return taken, nil
+}
+
+func (c *Client) clickLocked(slot int16, button int8, mode player.ClickMode) error {
+	if err := c.checkClickReady(); err != nil {
+		return err
+	}
+	state := c.Player.GetActiveContainer()
+	result, err := player.SimulateClick(state, c.Player.GetCarried(), slot, button, mode, c.isCreative())
+	if err != nil {
+		return err
+	}
+	if err := c.sendContainerClick(state, slot, button, mode, result); err != nil {
+		return err
+	}
+	c.Player.ApplyClick(state.WindowID, result)
+	return nil
+}
+
+func (c *Client) checkClickReady() error {
+	if c.state != protocol.StatePlay {
+		return fmt.Errorf("当前状态不是 Play，无法发送容器点击数据包")
+	}
+	if c.conn == nil {
+		return fmt.Errorf("连接未初始化")
+	}
+	return nil
+}
+
+func (c *Client) openContainerState() (*player.ContainerState, error) {
+	state := c.Player.GetActiveContainer()
+	if state == nil || state.WindowID == 0 {
+		return nil, fmt.Errorf("没有打开的容器")
+	}
+	if !c.Player.GetCarried().IsEmpty() {
+		return nil, fmt.Errorf("光标上已有物品，无法存取")
+	}
+	return state, nil
+}
+
+func (c *Client) findEmptyPlayerSlot() int {
+	state := c.Player.GetActiveContainer()
+	// 优先快捷栏，其次主背包
+	for n := 0; n < 9; n++ {
+		if i := state.HotbarSlot(n); state.Slot(i).IsEmpty() {
+			return i
+		}
+	}
+	for i := state.PlayerSlotOffset(); i < state.PlayerSlotOffset()+27; i++ {
+		if state.Slot(i).IsEmpty() {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *Client) isCreative() bool {
+	return c.Player.GetGameMode() == player.GameModeCreative
+}
+
+// sendContainerClick 编码并发送 container_click:
+// windowID, stateID, slot (Short), button (Byte), mode, 变化槽位数组, 光标物品
+func (c *Client) sendContainerClick(state *player.ContainerState, slot int16, button int8, mode player.ClickMode, result *player.ClickResult) error {
+	payload := make([]byte, 0, 32)
+	payload = append(payload, packet.EncodeVarInt(state.WindowID)...)
+	payload = append(payload, packet.EncodeVarInt(state.StateID)...)
+	payload = binary.BigEndian.AppendUint16(payload, uint16(slot))
+	payload = append(payload, byte(button))
+	payload = append(payload, packet.EncodeVarInt(int32(mode))...)
+
+	slots := make([]int16, 0, len(result.Changed))
+	for s := range result.Changed {
+		slots = append(slots, s)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+
+	payload = append(payload, packet.EncodeVarInt(int32(len(slots)))...)
+	for _, s := range slots {
+		payload = binary.BigEndian.AppendUint16(payload, uint16(s))
+		payload = append(payload, encodeHashedSlot(result.Changed[s])...)
+	}
+	payload = append(payload, encodeHashedSlot(result.Carried)...)
+
+	logx.Debugf("container_click: window=%d, state=%d, slot=%d, button=%d, mode=%d, changed=%d",
+		state.WindowID, state.StateID, slot, button, mode, len(slots))
+	return c.conn.WritePacket(protocol.PlayServerContainerClick, payload)
+}
+
+// encodeHashedSlot 编码 HashedStack: present, itemID, count, 新增组件 (类型+CRC32C 哈希), 移除组件。
+// 无法计算哈希的组件会被省略，服务端发现不一致时会重新同步该槽位。
+func encodeHashedSlot(stack *item.ItemStack) []byte {
+	if stack.IsEmpty() {
+		return packet.EncodeBool(false)
+	}
+	buf := packet.EncodeBool(true)
+	buf = append(buf, packet.EncodeVarInt(stack.ID)...)
+	buf = append(buf, packet.EncodeVarInt(stack.Count)...)
+
+	hashes, err := stack.ComponentHashes()
+	if err != nil {
+		logx.Debugf("物品 %s 的部分组件无法计算哈希: %v", stack, err)
+	}
+	typeIDs := make([]int32, 0, len(hashes))
+	for typeID := range hashes {
+		typeIDs = append(typeIDs, typeID)
+	}
+	sort.Slice(typeIDs, func(i, j int) bool { return typeIDs[i] < typeIDs[j] })
+
+	buf = append(buf, packet.EncodeVarInt(int32(len(typeIDs)))...)
+	for _, typeID := range typeIDs {
+		buf = append(buf, packet.EncodeVarInt(typeID)...)
+		buf = append(buf, packet.EncodeInt32(hashes[typeID])...)
+	}
+	buf = append(buf, packet.EncodeVarInt(int32(len(stack.Removed)))...)
+	for _, typeID := range stack.Removed {
+		buf = append(buf, packet.EncodeVarInt(typeID)...)
+	}
+	return buf
+}
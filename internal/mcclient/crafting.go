@@ -0,0 +1,159 @@
+package mcclient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gmcc/internal/logx"
+	"gmcc/internal/player"
+	"gmcc/internal/recipe"
+	"gmcc/internal/registry"
+)
+
+const (
+	craftSyncTimeout  = 2 * time.Second
+	craftPollInterval = 50 * time.Millisecond
+)
+
+// Craft 通过配方书合成 itemName (如 minecraft:iron_block 或 iron_block)，count<=0 表示尽可能多。
+// 已打开工作台时使用 3x3 合成格，否则使用背包的 2x2 合成格。返回实际得到的物品数量。
+func (c *Client) Craft(itemName string, count int) (int, error) {
+	if !c.cfg.Packets.HandleContainer {
+		return 0, fmt.Errorf("未启用容器数据包处理，无法合成")
+	}
+	name := strings.TrimPrefix(itemName, "minecraft:")
+	itemID := registry.GetItemRegistry().NameToID(name)
+	if itemID < 0 {
+		return 0, fmt.Errorf("未知物品: %s", itemName)
+	}
+
+	c.clickMu.Lock()
+	defer c.clickMu.Unlock()
+
+	if err := c.checkClickReady(); err != nil {
+		return 0, err
+	}
+	if !c.Player.GetCarried().IsEmpty() {
+		return 0, fmt.Errorf("光标上已有物品，无法合成")
+	}
+	state := c.Player.GetActiveContainer()
+	gridSize := int32(2)
+	if state.WindowID != 0 {
+		if state.WindowType != ContainerTypeCrafting {
+			return 0, fmt.Errorf("当前打开的容器不是工作台")
+		}
+		gridSize = 3
+	}
+
+	entry, available := c.pickRecipe(itemID, gridSize)
+	if entry == nil {
+		return 0, fmt.Errorf("没有可用的 %s 配方或材料不足", name)
+	}
+	_, perCraft := entry.Display.Result.ResultItem()
+	perCraft = max(perCraft, 1)
+	logx.Infof("合成 %s: 配方 %d, 每次 %d 个, 材料可合成 %d 次", name, entry.ID, perCraft, available)
+
+	crafted := 0
+	for count <= 0 || crafted < count {
+		available = recipe.MaxCrafts(entry.Ingredients(), c.Player.Inventory.ItemCounts(), 64)
+		if available == 0 {
+			break
+		}
+		// 需要的次数不少于材料上限时一次摆满，否则逐次摆放避免多合成
+		need := (count - crafted + int(perCraft) - 1) / int(perCraft)
+		useMax := count <= 0 || need >= available
+
+		before := c.Player.Inventory.CountItem("minecraft:" + name)
+		if err := c.craftOnce(state.WindowID, entry.ID, itemID, gridSize, useMax); err != nil {
+			c.clearCraftGrid(gridSize)
+			return crafted, err
+		}
+		gained := int(c.Player.Inventory.CountItem("minecraft:"+name) - before)
+		if gained <= 0 {
+			break
+		}
+		crafted += gained
+	}
+
+	c.clearCraftGrid(gridSize)
+	if crafted == 0 {
+		return 0, fmt.Errorf("合成 %s 失败: 服务端未产出物品", name)
+	}
+	return crafted, nil
+}
+
+// pickRecipe 选择能放入合成格且材料最充足的配方
+func (c *Client) pickRecipe(itemID, gridSize int32) (*recipe.Entry, int) {
+	counts := c.Player.Inventory.ItemCounts()
+	var best *recipe.Entry
+	bestN := 0
+	for _, e := range c.Player.Recipes.FindByResult(itemID) {
+		if !e.Display.IsCrafting() || !e.Display.FitsGrid(gridSize) {
+			continue
+		}
+		if n := recipe.MaxCrafts(e.Ingredients(), counts, 64); n > bestN {
+			best, bestN = e, n
+		}
+	}
+	return best, bestN
+}
+
+// craftOnce 摆放配方并 Shift 点击结果槽，等待服务端同步合成格
+func (c *Client) craftOnce(windowID, recipeID, itemID, gridSize int32, useMax bool) error {
+	if err := c.SendPlaceRecipe(windowID, recipeID, useMax); err != nil {
+		return err
+	}
+	ok := c.waitContainer(func(s *player.ContainerState) bool {
+		result := s.Slot(player.SlotCraftResult)
+		return !result.IsEmpty() && result.ID == itemID
+	})
+	if !ok {
+		return fmt.Errorf("等待合成结果超时")
+	}
+	if err := c.clickLocked(player.SlotCraftResult, 0, player.ClickQuickMove); err != nil {
+		return err
+	}
+	// 客户端不预测材料消耗，等待服务端清空合成格
+	c.waitContainer(func(s *player.ContainerState) bool {
+		return craftGridEmpty(s, gridSize)
+	})
+	return nil
+}
+
+// clearCraftGrid 将合成格中剩余的材料移回背包
+func (c *Client) clearCraftGrid(gridSize int32) {
+	state := c.Player.GetActiveContainer()
+	for i := 1; i <= int(gridSize*gridSize); i++ {
+		if state.Slot(i).IsEmpty() {
+			continue
+		}
+		if err := c.clickLocked(int16(i), 0, player.ClickQuickMove); err != nil {
+			logx.Warnf("取回合成格物品失败: slot=%d, err=%v", i, err)
+			return
+		}
+	}
+}
+
+func craftGridEmpty(s *player.ContainerState, gridSize int32) bool {
+	for i := 1; i <= int(gridSize*gridSize); i++ {
+		if !s.Slot(i).IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// waitContainer 轮询当前窗口直到 cond 成立或超时
+func (c *Client) waitContainer(cond func(*player.ContainerState) bool) bool {
+	deadline := time.Now().Add(craftSyncTimeout)
+	for {
+		if cond(c.Player.GetActiveContainer()) {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(craftPollInterval)
+	}
+}
@@ -0,0 +1,167 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/chat"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/player"
+)
+
+// DeathEvent 玩家死亡事件
+type DeathEvent struct {
+	Message  string // 死亡消息纯文本，服务器关闭死亡消息或未收到 player_combat_kill 时为空
+	RawJSON  string
+	Location player.GlobalPos
+	Time     time.Time
+}
+
+// deathState 记录是否停留在死亡界面
+type deathState struct {
+	mu   sync.Mutex
+	dead bool
+}
+
+// SetDeathHandler 设置死亡回调
+func (c *Client) SetDeathHandler(handler func(DeathEvent)) {
+	c.deathHandler = handler
+}
+
+// SetRespawnHandler 设置重生回调，重生后血量恢复时调用
+func (c *Client) SetRespawnHandler(handler func()) {
+	c.respawnHandler = handler
+}
+
+// IsDead 返回玩家是否处于死亡界面
+func (c *Client) IsDead() bool {
+	c.death.mu.Lock()
+	defer c.death.mu.Unlock()
+	return c.death.dead
+}
+
+// Respawn 在死亡界面点击重生
+func (c *Client) Respawn() error {
+	if !c.IsDead() {
+		return fmt.Errorf("玩家没有死亡")
+	}
+	return c.SendClientCommand(ClientCommandActionPerformRespawn)
+}
+
+func (c *Client) handlePlayerCombatKillPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	playerID, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 player_combat_kill 玩家ID失败: %w", err)
+	}
+	rawJSON, err := c.readAnonymousNBTJSON(r)
+	if err != nil {
+		return fmt.Errorf("解析 player_combat_kill 死亡消息失败: %w", err)
+	}
+	if playerID != c.Player.GetEntityID() {
+		return nil
+	}
+	c.markDead(rawJSON, c.currentBlockPos())
+	return nil
+}
+
+// markDead 进入死亡状态，触发死亡事件并安排自动重生。已死亡时忽略。
+func (c *Client) markDead(rawJSON string, location player.GlobalPos) {
+	c.death.mu.Lock()
+	if c.death.dead {
+		c.death.mu.Unlock()
+		return
+	}
+	c.death.dead = true
+	c.death.mu.Unlock()
+
+	event := DeathEvent{
+		RawJSON:  rawJSON,
+		Location: location,
+		Time:     time.Now(),
+	}
+	if rawJSON != "" {
+		event.Message = chat.ExtractPlainTextFromChatJSON(rawJSON)
+	}
+	logx.Infof("玩家死亡 (%s %d, %d, %d): %s", location.Dimension, location.X, location.Y, location.Z, event.Message)
+
+	if c.deathHandler != nil {
+		c.deathHandler(event)
+	}
+	if c.cfg.Respawn.Manual {
+		return
+	}
+
+	delay := time.Duration(c.cfg.Respawn.DelayMs) * time.Millisecond
+	go func() {
+		time.Sleep(delay)
+		if !c.IsDead() {
+			return
+		}
+		logx.Infof("自动发送重生数据包")
+		if err := c.Respawn(); err != nil {
+			logx.Warnf("发送重生数据包失败: %v", err)
+		}
+	}()
+}
+
+// markAlive 死亡后血量恢复，视为已重生
+func (c *Client) markAlive() {
+	c.death.mu.Lock()
+	if !c.death.dead {
+		c.death.mu.Unlock()
+		return
+	}
+	c.death.dead = false
+	c.death.mu.Unlock()
+
+	logx.Infof("玩家已重生")
+	if c.respawnHandler != nil {
+		c.respawnHandler()
+	}
+	if c.cfg.Respawn.RerunJoinActions {
+		c.runOnJoinActions()
+	}
+}
+
+// resetDeath 新的登录不继承上次连接的死亡状态
+func (c *Client) resetDeath() {
+	c.death.mu.Lock()
+	defer c.death.mu.Unlock()
+	c.death.dead = false
+}
+
+func (c *Client) currentBlockPos() player.GlobalPos {
+	x, y, z := c.Player.GetPosition()
+	return player.GlobalPos{
+		Dimension: c.Player.GetDimension(),
+		X:         int32(math.Floor(x)),
+		Y:         int32(math.Floor(y)),
+		Z:         int32(math.Floor(z)),
+	}
+}
+
+// readDeathLocation 读取 login/respawn 中可选的上次死亡位置
+func readDeathLocation(r *bytes.Reader) (*player.GlobalPos, error) {
+	hasDeathLocation, err := packet.ReadBoolFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取 has_death_location 失败: %w", err)
+	}
+	if !hasDeathLocation {
+		return nil, nil
+	}
+	dimension, err := packet.ReadStringFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取 death_dimension 失败: %w", err)
+	}
+	packed, err := packet.ReadInt64(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取 death_location 失败: %w", err)
+	}
+	x, y, z := packet.DecodeBlockPos(packed)
+	return &player.GlobalPos{Dimension: dimension, X: x, Y: y, Z: z}, nil
+}
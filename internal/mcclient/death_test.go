@@ -0,0 +1,55 @@
+package mcclient
+
+import (
+	"bytes"
+	"testing"
+
+	"gmcc/internal/config"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/player"
+)
+
+func TestReadDeathLocation(t *testing.T) {
+	// (-100, -60, 2000) 按 x<<38 | z<<12 | y 压缩
+	packed := int64(-100)<<38 | int64(2000)<<12 | int64(-60)&0xFFF
+	var buf []byte
+	buf = append(buf, packet.EncodeBool(true)...)
+	buf = append(buf, packet.EncodeString("minecraft:the_nether")...)
+	buf = append(buf, packet.EncodeInt64(packed)...)
+
+	got, err := readDeathLocation(bytes.NewReader(buf))
+	want := player.GlobalPos{Dimension: "minecraft:the_nether", X: -100, Y: -60, Z: 2000}
+	if err != nil || got == nil || *got != want {
+		t.Fatalf("readDeathLocation() = %+v, %v, want %+v", got, err, want)
+	}
+
+	if got, err := readDeathLocation(bytes.NewReader(packet.EncodeBool(false))); err != nil || got != nil {
+		t.Errorf("无死亡位置时 readDeathLocation() = %+v, %v", got, err)
+	}
+}
+
+func TestDeathAndRespawn(t *testing.T) {
+	cfg := config.Default()
+	cfg.Respawn.Manual = true
+	c := New(&cfg)
+
+	var deaths []DeathEvent
+	respawns := 0
+	c.SetDeathHandler(func(e DeathEvent) { deaths = append(deaths, e) })
+	c.SetRespawnHandler(func() { respawns++ })
+
+	c.markDead(`{"text":"bot fell from a high place"}`, player.GlobalPos{Dimension: "minecraft:overworld", Y: 64})
+	c.markDead("", player.GlobalPos{})
+	if !c.IsDead() || len(deaths) != 1 {
+		t.Fatalf("IsDead()=%v deaths=%d, want true 1", c.IsDead(), len(deaths))
+	}
+	if deaths[0].Message != "bot fell from a high place" || deaths[0].Location.Y != 64 {
+		t.Errorf("死亡事件 = %+v", deaths[0])
+	}
+
+	c.markAlive()
+	c.markAlive()
+	if c.IsDead() || respawns != 1 {
+		t.Errorf("IsDead()=%v respawns=%d, want false 1", c.IsDead(), respawns)
+	}
+}
@@ -0,0 +1,228 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gmcc/internal/dialog"
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/mcclient/protocol"
+)
+
+// DialogEvent 服务端显示或关闭对话框，Dialog 为 nil 表示关闭
+type DialogEvent struct {
+	Dialog *dialog.Dialog
+}
+
+// SetDialogHandler 设置对话框回调，配置规则自动回应的对话框不会触发
+func (c *Client) SetDialogHandler(handler func(DialogEvent)) {
+	c.dialogHandler = handler
+}
+
+// CurrentDialog 返回当前显示的对话框
+func (c *Client) CurrentDialog() (*dialog.Dialog, bool) {
+	c.dialogMu.Lock()
+	defer c.dialogMu.Unlock()
+	return c.openDialog, c.openDialog != nil
+}
+
+// ClickDialog 点击当前对话框的按钮。button 为按钮文字或序号 (从 1 开始)，
+// inputs 按输入项 key 给出值，未给出的使用初始值。
+func (c *Client) ClickDialog(button string, inputs map[string]string) error {
+	d, ok := c.CurrentDialog()
+	if !ok {
+		return fmt.Errorf("当前没有对话框")
+	}
+	b, err := d.Button(button)
+	if err != nil {
+		return err
+	}
+	return c.clickDialogButton(d, b, inputs)
+}
+
+// CloseDialog 按 Esc 关闭当前对话框，有退出按钮时执行其动作
+func (c *Client) CloseDialog() error {
+	d, ok := c.CurrentDialog()
+	if !ok {
+		return fmt.Errorf("当前没有对话框")
+	}
+	if !d.CanCloseWithEscape {
+		return fmt.Errorf("该对话框不能直接关闭，请点击按钮")
+	}
+	if d.Exit != nil {
+		return c.clickDialogButton(d, d.Exit, nil)
+	}
+	c.setDialog(nil)
+	return nil
+}
+
+func (c *Client) clickDialogButton(d *dialog.Dialog, b *dialog.Button, inputs map[string]string) error {
+	result, err := d.Click(b, inputs)
+	if err != nil {
+		return err
+	}
+
+	switch result.Kind {
+	case dialog.ResultCommand:
+		err = c.SendCommand(result.Command)
+	case dialog.ResultCustom:
+		err = c.SendCustomClickAction(result.ID, result.Payload)
+	}
+	if err != nil {
+		return err
+	}
+	if d.AfterAction != dialog.AfterNone {
+		c.setDialog(nil)
+	}
+	return nil
+}
+
+// SendCustomClickAction 发送 custom_click_action，配置阶段和 Play 阶段均可使用
+func (c *Client) SendCustomClickAction(id string, payload any) error {
+	var packetID int32
+	switch c.state {
+	case protocol.StateConfiguration:
+		packetID = protocol.CfgServerCustomClick
+	case protocol.StatePlay:
+		packetID = protocol.PlayServerCustomClick
+	default:
+		return fmt.Errorf("当前状态无法发送 custom_click_action 数据包")
+	}
+	if c.conn == nil {
+		return fmt.Errorf("连接未初始化")
+	}
+	data, err := encodeCustomClickAction(id, payload)
+	if err != nil {
+		return err
+	}
+	return c.conn.WritePacket(packetID, data)
+}
+
+// encodeCustomClickAction 编码 ID + 带长度前缀的可选 NBT
+func encodeCustomClickAction(id string, payload any) ([]byte, error) {
+	tag, err := packet.EncodeAnonymousNBT(payload)
+	if err != nil {
+		return nil, fmt.Errorf("编码 custom_click_action 数据失败: %w", err)
+	}
+	data := packet.EncodeString(id)
+	data = append(data, packet.EncodeByteArray(tag)...)
+	return data, nil
+}
+
+// handleShowDialogPacket 处理 show_dialog。Play 阶段可以引用注册表中的对话框，配置阶段只有内联对话框。
+// 无法解析的对话框只记录日志，不断开连接。
+func (c *Client) handleShowDialogPacket(data []byte, holder bool) error {
+	r := bytes.NewReader(data)
+	var d *dialog.Dialog
+	if holder {
+		id, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			logx.PacketError("show_dialog", data, err)
+			return nil
+		}
+		if id > 0 {
+			c.dialogMu.Lock()
+			if int(id) <= len(c.dialogs) {
+				d = c.dialogs[id-1]
+			}
+			c.dialogMu.Unlock()
+			if d == nil {
+				logx.Warnf("忽略未知的注册表对话框: %d", id-1)
+				return nil
+			}
+		}
+	}
+	if d == nil {
+		tag, err := readNBT(r)
+		if err == nil {
+			d, err = dialog.Parse(tag)
+		}
+		if err != nil {
+			logx.PacketError("show_dialog", data, fmt.Errorf("解析对话框失败: %w", err))
+			return nil
+		}
+	}
+
+	logx.Infof("服务端显示对话框: %s", d.Title.Plain)
+	c.setDialog(d)
+	if c.autoRespondDialog(d) {
+		return nil
+	}
+	if c.dialogHandler != nil {
+		c.dialogHandler(DialogEvent{Dialog: d})
+	}
+	return nil
+}
+
+func (c *Client) handleClearDialogPacket() error {
+	if _, ok := c.CurrentDialog(); !ok {
+		return nil
+	}
+	c.setDialog(nil)
+	if c.dialogHandler != nil {
+		c.dialogHandler(DialogEvent{})
+	}
+	return nil
+}
+
+// autoRespondDialog 按配置规则回应对话框，返回是否已处理
+func (c *Client) autoRespondDialog(d *dialog.Dialog) bool {
+	for _, rule := range c.cfg.Dialogs.Rules {
+		if !strings.Contains(d.Title.Plain, rule.Title) {
+			continue
+		}
+		var err error
+		if rule.Button == "" {
+			err = c.CloseDialog()
+		} else {
+			err = c.ClickDialog(rule.Button, rule.Inputs)
+		}
+		if err != nil {
+			logx.Warnf("自动回应对话框 %q 失败: %v", d.Title.Plain, err)
+			return false
+		}
+		logx.Infof("已自动回应对话框 %q", d.Title.Plain)
+		return true
+	}
+	return false
+}
+
+func (c *Client) setDialog(d *dialog.Dialog) {
+	c.dialogMu.Lock()
+	defer c.dialogMu.Unlock()
+	c.openDialog = d
+}
+
+// setDialogRegistry 保存 registry_data 中的 minecraft:dialog。来自已知数据包 (没有数据) 或
+// 解析失败的条目保留为只有 ID 的占位对话框，只能关闭
+func (c *Client) setDialogRegistry(names []string, tags []map[string]any) {
+	dialogs := make([]*dialog.Dialog, len(names))
+	for i, tag := range tags {
+		var d *dialog.Dialog
+		if tag != nil {
+			var err error
+			if d, err = dialog.Parse(tag); err != nil {
+				logx.Debugf("解析注册表对话框 %s 失败: %v", names[i], err)
+			}
+		}
+		if d == nil {
+			d = &dialog.Dialog{Title: dialog.Text{Plain: names[i]}, CanCloseWithEscape: true}
+		}
+		d.ID = names[i]
+		dialogs[i] = d
+	}
+
+	c.dialogMu.Lock()
+	defer c.dialogMu.Unlock()
+	c.dialogs = dialogs
+}
+
+// resetDialogs 切换服务器时清空对话框与注册表
+func (c *Client) resetDialogs() {
+	c.dialogMu.Lock()
+	defer c.dialogMu.Unlock()
+	c.openDialog = nil
+	c.dialogs = nil
+}
@@ -0,0 +1,136 @@
+package mcclient
+
+import (
+	"bytes"
+	"testing"
+
+	"gmcc/internal/config"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/nbt"
+)
+
+func dialogNBT(t *testing.T, title string) []byte {
+	t.Helper()
+	data, err := packet.EncodeAnonymousNBT(map[string]any{
+		"type":  "minecraft:confirmation",
+		"title": title,
+		"yes":   map[string]any{"label": "Accept", "action": map[string]any{"type": "custom", "id": "srv:accept"}},
+		"no":    map[string]any{"label": "Decline"},
+	})
+	if err != nil {
+		t.Fatalf("EncodeAnonymousNBT() error = %v", err)
+	}
+	return data
+}
+
+func TestHandleShowDialogPacket(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	var events []DialogEvent
+	c.SetDialogHandler(func(e DialogEvent) { events = append(events, e) })
+
+	// Play 阶段的内联对话框以编号 0 开头
+	data := append(packet.EncodeVarInt(0), dialogNBT(t, "Rules")...)
+	if err := c.handleShowDialogPacket(data, true); err != nil {
+		t.Fatalf("handleShowDialogPacket() error = %v", err)
+	}
+	d, ok := c.CurrentDialog()
+	if !ok || d.Title.Plain != "Rules" || len(d.Buttons) != 2 || d.Buttons[0].Action.ID != "srv:accept" {
+		t.Fatalf("CurrentDialog() = %+v, %v", d, ok)
+	}
+	if len(events) != 1 || events[0].Dialog != d {
+		t.Errorf("events = %+v", events)
+	}
+
+	// 没有连接时无法发送 custom_click_action，对话框保持打开
+	if err := c.ClickDialog("Accept", nil); err == nil {
+		t.Error("未连接时 ClickDialog() 应返回错误")
+	}
+	if _, ok := c.CurrentDialog(); !ok {
+		t.Error("发送失败后对话框不应关闭")
+	}
+	// 没有动作的按钮只关闭对话框
+	if err := c.ClickDialog("2", nil); err != nil {
+		t.Fatalf("ClickDialog() error = %v", err)
+	}
+	if _, ok := c.CurrentDialog(); ok {
+		t.Error("点击后对话框应关闭")
+	}
+
+	// 注册表中的对话框
+	c.setDialogRegistry([]string{"srv:a", "srv:b"}, []map[string]any{nil, {"type": "notice", "title": "Welcome"}})
+	if err := c.handleShowDialogPacket(packet.EncodeVarInt(2), true); err != nil {
+		t.Fatalf("handleShowDialogPacket() error = %v", err)
+	}
+	if d, ok := c.CurrentDialog(); !ok || d.ID != "srv:b" || d.Title.Plain != "Welcome" {
+		t.Errorf("CurrentDialog() = %+v, %v", d, ok)
+	}
+	// 没有数据的注册表对话框 (如已知数据包中的 minecraft:server_links) 显示为占位对话框
+	if err := c.handleShowDialogPacket(packet.EncodeVarInt(1), true); err != nil {
+		t.Fatalf("handleShowDialogPacket() error = %v", err)
+	}
+	if d, ok := c.CurrentDialog(); !ok || d.ID != "srv:a" || d.Title.Plain != "srv:a" {
+		t.Errorf("CurrentDialog() = %+v, %v", d, ok)
+	}
+	// 未知编号和无法解析的内联对话框只记录日志，不断开连接
+	if err := c.handleShowDialogPacket(packet.EncodeVarInt(9), true); err != nil {
+		t.Errorf("未知编号 handleShowDialogPacket() error = %v", err)
+	}
+	if err := c.handleShowDialogPacket(append(packet.EncodeVarInt(0), 0xff), true); err != nil {
+		t.Errorf("无效数据 handleShowDialogPacket() error = %v", err)
+	}
+
+	if err := c.handleClearDialogPacket(); err != nil {
+		t.Fatalf("handleClearDialogPacket() error = %v", err)
+	}
+	if _, ok := c.CurrentDialog(); ok || len(events) != 4 || events[3].Dialog != nil {
+		t.Errorf("清除后 events = %+v", events)
+	}
+}
+
+func TestAutoRespondDialog(t *testing.T) {
+	cfg := config.Default()
+	cfg.Dialogs.Rules = []config.DialogRule{{Title: "Rules", Button: "Decline"}}
+	c := New(&cfg)
+	called := false
+	c.SetDialogHandler(func(DialogEvent) { called = true })
+
+	// 配置阶段只有内联对话框
+	if err := c.handleShowDialogPacket(dialogNBT(t, "Server Rules"), false); err != nil {
+		t.Fatalf("handleShowDialogPacket() error = %v", err)
+	}
+	if _, ok := c.CurrentDialog(); ok || called {
+		t.Errorf("匹配规则的对话框应被自动回应, called = %v", called)
+	}
+
+	if err := c.handleShowDialogPacket(dialogNBT(t, "Menu"), false); err != nil {
+		t.Fatalf("handleShowDialogPacket() error = %v", err)
+	}
+	if _, ok := c.CurrentDialog(); !ok || !called {
+		t.Errorf("不匹配规则的对话框应交给回调, called = %v", called)
+	}
+}
+
+func TestEncodeCustomClickAction(t *testing.T) {
+	data, err := encodeCustomClickAction("srv:register", map[string]any{"name": "Steve"})
+	if err != nil {
+		t.Fatalf("encodeCustomClickAction() error = %v", err)
+	}
+	r := bytes.NewReader(data)
+	if id := packet.MustReadString(r, "id"); id != "srv:register" {
+		t.Errorf("id = %q", id)
+	}
+	n, _ := packet.ReadVarIntFromReader(r)
+	if int(n) != r.Len() {
+		t.Fatalf("长度前缀 %d, 剩余 %d 字节", n, r.Len())
+	}
+	tag, err := readNBT(r)
+	if err != nil || tag["name"] != "Steve" {
+		t.Errorf("payload = %v, %v", tag, err)
+	}
+
+	data, _ = encodeCustomClickAction("srv:ping", nil)
+	if want := append(packet.EncodeString("srv:ping"), 1, nbt.TagEnd); !bytes.Equal(data, want) {
+		t.Errorf("空 payload = %v, want %v", data, want)
+	}
+}
@@ -0,0 +1,107 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/chat"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/player"
+)
+
+// boss_event 的操作类型
+const (
+	bossEventAdd = iota
+	bossEventRemove
+	bossEventUpdateProgress
+	bossEventUpdateName
+	bossEventUpdateStyle
+	bossEventUpdateProperties
+)
+
+func (c *Client) handleBossEventPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	uuid, err := packet.ReadUUID(r)
+	if err != nil {
+		return fmt.Errorf("读取 boss_event UUID 失败: %w", err)
+	}
+	operation, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 boss_event 操作失败: %w", err)
+	}
+
+	switch operation {
+	case bossEventAdd:
+		bar := player.BossBar{UUID: uuid}
+		if bar.Title, err = c.readAnonymousNBTJSON(r); err != nil {
+			return fmt.Errorf("读取 boss_event 标题失败: %w", err)
+		}
+		if bar.Progress, err = packet.ReadFloat32(r); err != nil {
+			return fmt.Errorf("读取 boss_event 进度失败: %w", err)
+		}
+		if bar.Color, bar.Overlay, err = readBossBarStyle(r); err != nil {
+			return err
+		}
+		if bar.Flags, err = packet.ReadU8(r); err != nil {
+			return fmt.Errorf("读取 boss_event 标记失败: %w", err)
+		}
+		c.BossBars.Add(bar)
+		logx.Debugf("添加 Boss 栏 %s: %s", formatUUIDShort(uuid), chat.ExtractPlainTextFromChatJSON(bar.Title))
+		return nil
+
+	case bossEventRemove:
+		c.BossBars.Remove(uuid)
+		return nil
+
+	case bossEventUpdateProgress:
+		progress, err := packet.ReadFloat32(r)
+		if err != nil {
+			return fmt.Errorf("读取 boss_event 进度失败: %w", err)
+		}
+		c.updateBossBar(uuid, func(bar *player.BossBar) { bar.Progress = progress })
+
+	case bossEventUpdateName:
+		title, err := c.readAnonymousNBTJSON(r)
+		if err != nil {
+			return fmt.Errorf("读取 boss_event 标题失败: %w", err)
+		}
+		c.updateBossBar(uuid, func(bar *player.BossBar) { bar.Title = title })
+
+	case bossEventUpdateStyle:
+		color, overlay, err := readBossBarStyle(r)
+		if err != nil {
+			return err
+		}
+		c.updateBossBar(uuid, func(bar *player.BossBar) { bar.Color, bar.Overlay = color, overlay })
+
+	case bossEventUpdateProperties:
+		flags, err := packet.ReadU8(r)
+		if err != nil {
+			return fmt.Errorf("读取 boss_event 标记失败: %w", err)
+		}
+		c.updateBossBar(uuid, func(bar *player.BossBar) { bar.Flags = flags })
+
+	default:
+		return fmt.Errorf("未知的 boss_event 操作: %d", operation)
+	}
+	return nil
+}
+
+func (c *Client) updateBossBar(uuid [16]byte, apply func(*player.BossBar)) {
+	if !c.BossBars.Update(uuid, apply) {
+		logx.Debugf("boss_event: 未知 Boss 栏 %s", formatUUIDShort(uuid))
+	}
+}
+
+func readBossBarStyle(r *bytes.Reader) (player.BossBarColor, player.BossBarOverlay, error) {
+	color, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return 0, 0, fmt.Errorf("读取 boss_event 颜色失败: %w", err)
+	}
+	overlay, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return 0, 0, fmt.Errorf("读取 boss_event 样式失败: %w", err)
+	}
+	return player.BossBarColor(color), player.BossBarOverlay(overlay), nil
+}
@@ -82,7 +82,8 @@ func (c *Client) handlePlayerChatPacket(data []byte) error {
 		return fmt.Errorf("解析 player_chat sender 失败: %w", err)
 	}
 
-	if _, err := packet.ReadVarInt(r); err != nil {
+	msg := signedPlayerChat{sender: sender, unpacked: true}
+	if msg.index, err = packet.ReadVarInt(r); err != nil {
 		return fmt.Errorf("解析 player_chat index 失败: %w", err)
 	}
 
@@ -91,21 +92,20 @@ func (c *Client) handlePlayerChatPacket(data []byte) error {
 		return fmt.Errorf("解析 player_chat signature 标记失败: %w", err)
 	}
 	if hasSignature {
-		if err := packet.DiscardN(r, 256); err != nil {
-			return fmt.Errorf("跳过 player_chat signature 失败: %w", err)
+		if msg.signature, err = packet.ReadBytes(r, messageSignatureSize); err != nil {
+			return fmt.Errorf("解析 player_chat signature 失败: %w", err)
 		}
 	}
 
-	plain, err := packet.ReadString(r, r)
-	if err != nil {
+	if msg.content, err = packet.ReadString(r, r); err != nil {
 		return fmt.Errorf("解析 player_chat content 失败: %w", err)
 	}
 
-	if _, err := packet.ReadInt64(r); err != nil {
+	if msg.timestamp, err = packet.ReadInt64(r); err != nil {
 		return fmt.Errorf("解析 player_chat timeStamp 失败: %w", err)
 	}
 
-	if _, err := packet.ReadInt64(r); err != nil {
+	if msg.salt, err = packet.ReadInt64(r); err != nil {
 		return fmt.Errorf("解析 player_chat salt 失败: %w", err)
 	}
 
@@ -118,10 +118,17 @@ func (c *Client) handlePlayerChatPacket(data []byte) error {
 		if err != nil {
 			return fmt.Errorf("解析 player_chat body[%d].id 失败: %w", i, err)
 		}
+		// id 为 0 时后跟完整签名，否则引用签名缓存中的第 id-1 项
 		if id == 0 {
-			if err := packet.DiscardN(r, 256); err != nil {
-				return fmt.Errorf("跳过 player_chat body[%d].lastSeen.fullSignature 失败: %w", i, err)
+			sig, err := packet.ReadBytes(r, messageSignatureSize)
+			if err != nil {
+				return fmt.Errorf("解析 player_chat body[%d].lastSeen.fullSignature 失败: %w", i, err)
 			}
+			msg.lastSeen = append(msg.lastSeen, sig)
+		} else if sig, ok := c.signatureCache.get(id - 1); ok {
+			msg.lastSeen = append(msg.lastSeen, sig)
+		} else {
+			msg.unpacked = false
 		}
 	}
 
@@ -129,9 +136,10 @@ func (c *Client) handlePlayerChatPacket(data []byte) error {
 	if err != nil {
 		return fmt.Errorf("解析 player_chat unsignedContent 标记失败: %w", err)
 	}
-	var rawJSON string
+	var unsignedJSON string
 	if hasUnsignedContent {
-		rawJSON, err = c.readAnonymousNBTJSON(r)
+		msg.modified = true
+		unsignedJSON, err = c.readAnonymousNBTJSON(r)
 		if err != nil {
 			return fmt.Errorf("解析 player_chat unsignedContent 失败: %w", err)
 		}
@@ -141,99 +149,93 @@ func (c *Client) handlePlayerChatPacket(data []byte) error {
 	if err != nil {
 		return fmt.Errorf("解析 player_chat filterMask.type 失败: %w", err)
 	}
-	if filterType == 2 {
+	if filterType != chat.FilterPassThrough {
+		msg.modified = true
+	}
+	var mask []int64
+	if filterType == chat.FilterPartiallyFiltered {
 		maskCount, err := packet.ReadVarInt(r)
 		if err != nil {
 			return fmt.Errorf("解析 player_chat filterMask.mask 数量失败: %w", err)
 		}
-		if err := packet.DiscardN(r, int(maskCount)*8); err != nil {
-			return fmt.Errorf("跳过 player_chat filterMask.mask 失败: %w", err)
+		if maskCount < 0 || int(maskCount)*8 > r.Len() {
+			return fmt.Errorf("player_chat filterMask.mask 数量无效: %d", maskCount)
 		}
-	}
-
-	chatTypeIdx, err := packet.ReadVarInt(r)
-	if err != nil {
-		return fmt.Errorf("解析 player_chat chatType.chat 失败: %w", err)
-	}
-	if chatTypeIdx == 0 {
-		if _, err := packet.ReadString(r, r); err != nil {
-			return fmt.Errorf("解析 player_chat chatType.chat.translationKey 失败: %w", err)
-		}
-		chatParamsCount, err := packet.ReadVarInt(r)
-		if err != nil {
-			return fmt.Errorf("解析 player_chat chatType.chat.parameters 数量失败: %w", err)
-		}
-		for i := int32(0); i < chatParamsCount; i++ {
-			if _, err := packet.ReadVarInt(r); err != nil {
-				return fmt.Errorf("解析 player_chat chatType.chat.parameters[%d] 失败: %w", i, err)
+		mask = make([]int64, maskCount)
+		for i := range mask {
+			if mask[i], err = packet.ReadInt64(r); err != nil {
+				return fmt.Errorf("解析 player_chat filterMask.mask 失败: %w", err)
 			}
 		}
-		if _, err := c.readAnonymousNBTJSON(r); err != nil {
-			return fmt.Errorf("解析 player_chat chatType.chat.style 失败: %w", err)
-		}
 	}
 
-	narrationIdx, err := packet.ReadVarInt(r)
+	chatType, chatTypeName, err := c.readChatTypeHolder(r)
 	if err != nil {
-		return fmt.Errorf("解析 player_chat chatType.narration 失败: %w", err)
+		return fmt.Errorf("解析 player_chat chatType 失败: %w", err)
 	}
-	if narrationIdx == 0 {
-		if _, err := packet.ReadString(r, r); err != nil {
-			return fmt.Errorf("解析 player_chat chatType.narration.translationKey 失败: %w", err)
-		}
-		narrParamsCount, err := packet.ReadVarInt(r)
-		if err != nil {
-			return fmt.Errorf("解析 player_chat chatType.narration.parameters 数量失败: %w", err)
-		}
-		for i := int32(0); i < narrParamsCount; i++ {
-			if _, err := packet.ReadVarInt(r); err != nil {
-				return fmt.Errorf("解析 player_chat chatType.narration.parameters[%d] 失败: %w", i, err)
-			}
-		}
-		if _, err := c.readAnonymousNBTJSON(r); err != nil {
-			return fmt.Errorf("解析 player_chat chatType.narration.style 失败: %w", err)
-		}
+	senderJSON, err := c.readAnonymousNBTJSON(r)
+	if err != nil {
+		return fmt.Errorf("解析 player_chat name 失败: %w", err)
 	}
-
-	hasName, err := packet.ReadBool(r)
+	hasTargetName, err := packet.ReadBool(r)
 	if err != nil {
-		return fmt.Errorf("解析 player_chat name 标记失败: %w", err)
+		return fmt.Errorf("解析 player_chat targetName 标记失败: %w", err)
 	}
-	logx.Debugf("[DEBUG] player_chat hasName: %v", hasName)
-	var senderName string
-	if hasName {
-		nameJSON, err := c.readAnonymousNBTJSON(r)
-		if err != nil {
-			logx.Debugf("[DEBUG] player_chat name NBT 解析失败: %v, 尝试作为字符串读取", err)
-			nameStr, strErr := packet.ReadString(r, r)
-			if strErr != nil {
-				logx.Debugf("[DEBUG] player_chat name 字符串读取也失败: %v", strErr)
-				senderName = ""
-			} else {
-				senderName = nameStr
-			}
-		} else {
-			logx.Debugf("[DEBUG] player_chat name JSON: %s", nameJSON)
-			senderName = chat.ExtractPlainTextFromChatJSON(nameJSON)
+	var targetJSON string
+	if hasTargetName {
+		if targetJSON, err = c.readAnonymousNBTJSON(r); err != nil {
+			return fmt.Errorf("解析 player_chat targetName 失败: %w", err)
 		}
 	}
 
-	hasTargetName, err := packet.ReadBool(r)
-	if err == nil && hasTargetName {
-		_, _ = c.readAnonymousNBTJSON(r)
+	verification, verifyErr := c.verifyPlayerChat(&msg)
+	senderName := chat.ExtractPlainTextFromChatJSON(senderJSON)
+	if verifyErr != nil {
+		logx.Warnf("player_chat 签名验证失败 (%s): %v", senderName, verifyErr)
+	}
+	if msg.unpacked {
+		c.signatureCache.push(msg.lastSeen, msg.signature)
 	}
 
-	logx.Debugf("[DEBUG] player_chat senderName: %s", senderName)
+	// 与原版一致: 有过滤掩码时显示带 # 的签名内容，否则优先显示服务端改写后的内容
+	var contentJSON string
+	switch {
+	case filterType != chat.FilterPassThrough:
+		var shown bool
+		if contentJSON, shown = chat.ApplyFilterMask(msg.content, filterType, mask); !shown {
+			logx.Debugf("player_chat 已被服务端完全过滤 (%s)", senderName)
+			c.markMessageSeen(msg.signature, false)
+			return nil
+		}
+	case hasUnsignedContent:
+		contentJSON = unsignedJSON
+	default:
+		contentJSON = chat.TextJSON(msg.content)
+	}
+	rawJSON := chat.TextJSON(msg.content)
+	plain := msg.content
+	if chatType != nil {
+		rawJSON = chatType.Chat.Decorate(senderJSON, targetJSON, contentJSON)
+		if comp, err := chat.ParseTextComponent(rawJSON); err == nil {
+			plain = comp.ToPlain()
+		}
+	}
 	logx.Debugf("[RAW JSON] player_chat: %s", rawJSON)
 
 	c.emitChat(ChatMessage{
-		Type:       "player_chat",
-		PlainText:  plain,
-		RawJSON:    rawJSON,
-		SenderUUID: packet.FormatUUID(sender),
-		SenderName: senderName,
-		ReceivedAt: time.Now(),
+		Type:         "player_chat",
+		PlainText:    plain,
+		RawJSON:      rawJSON,
+		SenderUUID:   packet.FormatUUID(sender),
+		SenderName:   senderName,
+		ChatType:     chatTypeName,
+		Sender:       senderJSON,
+		Target:       targetJSON,
+		Content:      contentJSON,
+		Verification: verification,
+		ReceivedAt:   time.Now(),
 	})
+	c.markMessageSeen(msg.signature, true)
 	return nil
 }
 
@@ -8,38 +8,71 @@ import (
 	"path/filepath"
 	"time"
 
+	"gmcc/internal/item"
 	"gmcc/internal/logx"
 	"gmcc/internal/mcclient/packet"
 	"gmcc/internal/mcclient/protocol"
 	"gmcc/internal/player"
-	"gmcc/internal/registry"
 )
 
 // DEBUG_MODE: 临时关闭背包解析，将原始包dump到文件
 const DEBUG_DUMP_CONTAINER_PACKETS = false
 
+// ContainerType 对应 open_screen 中的 minecraft:menu 注册表 ID
 const (
-	ContainerTypePlayer    int32 = 0
-	ContainerTypeChest     int32 = 1
-	ContainerTypeCrafting  int32 = 2
-	ContainerTypeFurnace   int32 = 3
-	ContainerTypeDispenser int32 = 4
-	ContainerTypeHopper    int32 = 5
-	ContainerTypeAnvil     int32 = 6
-	ContainerTypeBeacon    int32 = 7
-	ContainerTypeBrewing   int32 = 8
+	ContainerTypeGeneric9x1   int32 = 0
+	ContainerTypeGeneric9x2   int32 = 1
+	ContainerTypeGeneric9x3   int32 = 2
+	ContainerTypeGeneric9x4   int32 = 3
+	ContainerTypeGeneric9x5   int32 = 4
+	ContainerTypeGeneric9x6   int32 = 5
+	ContainerTypeGeneric3x3   int32 = 6
+	ContainerTypeCrafter      int32 = 7
+	ContainerTypeAnvil        int32 = 8
+	ContainerTypeBeacon       int32 = 9
+	ContainerTypeBlastFurnace int32 = 10
+	ContainerTypeBrewing      int32 = 11
+	ContainerTypeCrafting     int32 = 12
+	ContainerTypeEnchantment  int32 = 13
+	ContainerTypeFurnace      int32 = 14
+	ContainerTypeGrindstone   int32 = 15
+	ContainerTypeHopper       int32 = 16
+	ContainerTypeLectern      int32 = 17
+	ContainerTypeLoom         int32 = 18
+	ContainerTypeMerchant     int32 = 19
+	ContainerTypeShulkerBox   int32 = 20
+	ContainerTypeSmithing     int32 = 21
+	ContainerTypeSmoker       int32 = 22
+	ContainerTypeCartography  int32 = 23
+	ContainerTypeStonecutter  int32 = 24
 )
 
 var containerTypeNames = map[int32]string{
-	ContainerTypePlayer:    "player_inventory",
-	ContainerTypeChest:     "chest",
-	ContainerTypeCrafting:  "crafting_table",
-	ContainerTypeFurnace:   "furnace",
-	ContainerTypeDispenser: "dispenser",
-	ContainerTypeHopper:    "hopper",
-	ContainerTypeAnvil:     "anvil",
-	ContainerTypeBeacon:    "beacon",
-	ContainerTypeBrewing:   "brewing_stand",
+	ContainerTypeGeneric9x1:   "generic_9x1",
+	ContainerTypeGeneric9x2:   "generic_9x2",
+	ContainerTypeGeneric9x3:   "generic_9x3",
+	ContainerTypeGeneric9x4:   "generic_9x4",
+	ContainerTypeGeneric9x5:   "generic_9x5",
+	ContainerTypeGeneric9x6:   "generic_9x6",
+	ContainerTypeGeneric3x3:   "generic_3x3",
+	ContainerTypeCrafter:      "crafter_3x3",
+	ContainerTypeAnvil:        "anvil",
+	ContainerTypeBeacon:       "beacon",
+	ContainerTypeBlastFurnace: "blast_furnace",
+	ContainerTypeBrewing:      "brewing_stand",
+	ContainerTypeCrafting:     "crafting_table",
+	ContainerTypeEnchantment:  "enchantment",
+	ContainerTypeFurnace:      "furnace",
+	ContainerTypeGrindstone:   "grindstone",
+	ContainerTypeHopper:       "hopper",
+	ContainerTypeLectern:      "lectern",
+	ContainerTypeLoom:         "loom",
+	ContainerTypeMerchant:     "merchant",
+	ContainerTypeShulkerBox:   "shulker_box",
+	ContainerTypeSmithing:     "smithing",
+	ContainerTypeSmoker:       "smoker",
+	ContainerTypeCartography:  "cartography_table",
+	ContainerTypeStonecutter:  "stonecutter",
 }
 
 func containerTypeName(t int32) string {
@@ -70,7 +103,7 @@ func (c *Client) handleOpenScreenPacket(data []byte) error {
 		Open:       true,
 	})
 
-	logx.Infof("open_screen: containerId=%d, screenHandlerId=%d, name=%s", containerId, screenHandlerId, name)
+	logx.Infof("open_screen: containerId=%d, type=%s, name=%s", containerId, containerTypeName(screenHandlerId), name)
 	return nil
 }
 
@@ -131,7 +164,7 @@ func (c *Client) handleContainerContentPacket(data []byte) error {
 		return nil
 	}
 
-	c.Player.UpdateContainerStateID(stateId)
+	c.Player.UpdateContainerStateID(containerId, stateId)
 
 	logx.Infof("container_content: containerId=%d, stateId=%d, numItems=%d, remaining=%d bytes", containerId, stateId, numItems, r.Len())
 
@@ -140,36 +173,33 @@ func (c *Client) handleContainerContentPacket(data []byte) error {
 		numItems = 1000
 	}
 
-	reg := registry.GetItemRegistry()
-	items := make([]*player.SlotData, numItems)
+	items := make([]*item.ItemStack, numItems)
 	for i := int32(0); i < numItems; i++ {
-		slot, err := packet.ReadSlotData(r)
+		stack, err := item.ReadItemStack(r)
 		if err != nil {
+			// 组件没有长度前缀，解析失败后无法定位后续槽位: 应用已解析的槽位，
+			// 坏槽位记为空，其余槽位保持原样等待服务端下一次同步
 			logx.PacketError("container_content", data, fmt.Errorf("slot %d: %w", i, err))
-			// 设置为空槽位并继续处理
-			items[i] = &player.SlotData{ID: 0, Count: 0}
-			continue
-		}
-		if slot != nil {
-			items[i] = &player.SlotData{ID: slot.ID, Count: slot.Count}
-			if i < 10 || slot.ID != 0 {
-				itemName := reg.IDToName(slot.ID)
-				localizedName := reg.LocalizedName(slot.ID)
-				logx.Debugf("  slot[%d]: id=%d (%s), name=%s, count=%d", i, slot.ID, itemName, localizedName, slot.Count)
+			for j := int32(0); j < i; j++ {
+				c.Player.UpdateSlot(containerId, j, items[j])
 			}
+			c.Player.UpdateSlot(containerId, i, nil)
+			return nil
+		}
+		items[i] = stack
+		if stack != nil {
+			logx.Debugf("  slot[%d]: %s (%s)", i, stack, stack.DisplayName())
 		}
 	}
 
-	carriedItem, err := packet.ReadSlotData(r)
+	carried, err := item.ReadItemStack(r)
 	if err != nil {
+		// 槽位已完整解析，保留原来的光标物品
 		logx.PacketErrorWithContext("container_content", data, err, "carriedItem")
-		return nil
+		carried = c.Player.GetCarried()
 	}
-	var carried *player.SlotData
-	if carriedItem != nil {
-		carried = &player.SlotData{ID: carriedItem.ID, Count: carriedItem.Count}
-		itemName := reg.IDToName(carriedItem.ID)
-		logx.Infof("container_content: carried item: id=%d (%s), count=%d", carriedItem.ID, itemName, carriedItem.Count)
+	if carried != nil {
+		logx.Infof("container_content: carried item: %s", carried)
 	}
 
 	c.Player.UpdateInventory(containerId, items, carried)
@@ -231,21 +261,16 @@ func (c *Client) handleContainerSlotPacket(data []byte) error {
 		return nil
 	}
 
-	c.Player.UpdateContainerStateID(stateId)
+	c.Player.UpdateContainerStateID(containerId, stateId)
 
-	itemStack, err := packet.ReadSlotData(r)
+	slotItem, err := item.ReadItemStack(r)
 	if err != nil {
 		logx.PacketError("container_slot", data, fmt.Errorf("slot %d: %w", slot, err))
 		return nil
 	}
 
-	reg := registry.GetItemRegistry()
-	var slotItem *player.SlotData
-	if itemStack != nil {
-		slotItem = &player.SlotData{ID: itemStack.ID, Count: itemStack.Count}
-		itemName := reg.IDToName(itemStack.ID)
-		localizedName := reg.LocalizedName(itemStack.ID)
-		logx.Infof("container_slot: containerId=%d, stateId=%d, slot=%d, item_id=%d (%s), name=%s, count=%d", containerId, stateId, slot, itemStack.ID, itemName, localizedName, itemStack.Count)
+	if slotItem != nil {
+		logx.Infof("container_slot: containerId=%d, stateId=%d, slot=%d, item=%s, name=%s", containerId, stateId, slot, slotItem, slotItem.DisplayName())
 	} else {
 		logx.Debugf("container_slot: containerId=%d, stateId=%d, slot=%d, item=empty", containerId, stateId, slot)
 	}
@@ -255,6 +280,13 @@ func (c *Client) handleContainerSlotPacket(data []byte) error {
 }
 
 func (c *Client) SendContainerClose(windowID int32) error {
+	if c.state != protocol.StatePlay {
+		return fmt.Errorf("当前状态不是 Play，无法发送关闭容器数据包")
+	}
+	if c.conn == nil {
+		return fmt.Errorf("连接未初始化")
+	}
+
 	container := c.Player.GetOpenContainer()
 	if container != nil && container.WindowID == windowID {
 		c.Player.SetOpenContainer(nil)
@@ -267,3 +299,34 @@ func (c *Client) SendContainerClose(windowID int32) error {
 func (c *Client) GetCurrentContainer() *player.ContainerState {
 	return c.Player.GetOpenContainer()
 }
+
+func (c *Client) handleSetCursorItemPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	carried, err := item.ReadItemStack(r)
+	if err != nil {
+		logx.PacketError("set_cursor_item", data, err)
+		return nil
+	}
+
+	c.Player.SetCarried(carried)
+	logx.Debugf("set_cursor_item: %v", carried)
+	return nil
+}
+
+func (c *Client) handleSetPlayerInventoryPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	slot, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		logx.PacketError("set_player_inventory", data, err)
+		return nil
+	}
+	slotItem, err := item.ReadItemStack(r)
+	if err != nil {
+		logx.PacketError("set_player_inventory", data, fmt.Errorf("slot %d: %w", slot, err))
+		return nil
+	}
+
+	c.Player.UpdatePlayerInventorySlot(slot, slotItem)
+	logx.Debugf("set_player_inventory: slot=%d, item=%v", slot, slotItem)
+	return nil
+}
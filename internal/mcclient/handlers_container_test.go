@@ -0,0 +1,49 @@
+package mcclient
+
+import (
+	"testing"
+
+	"gmcc/internal/config"
+	"gmcc/internal/item"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/registry"
+)
+
+func TestHandleContainerContentBadSlot(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	reg := registry.GetItemRegistry()
+	stone, dirt := reg.NameToID("stone"), reg.NameToID("dirt")
+	for slot := int32(0); slot < 3; slot++ {
+		c.Player.UpdateSlot(0, slot, &item.ItemStack{ID: dirt, Count: 2})
+	}
+
+	data := packet.EncodeVarInt(0) // containerId
+	data = append(data, packet.EncodeVarInt(5)...)
+	data = append(data, packet.EncodeVarInt(3)...)
+	// 槽位 0: 1 个石头，无组件
+	data = append(data, packet.EncodeVarInt(1)...)
+	data = append(data, packet.EncodeVarInt(stone)...)
+	data = append(data, packet.EncodeVarInt(0)...)
+	data = append(data, packet.EncodeVarInt(0)...)
+	// 槽位 1: max_stack_size 组件缺少数据
+	data = append(data, packet.EncodeVarInt(1)...)
+	data = append(data, packet.EncodeVarInt(stone)...)
+	data = append(data, packet.EncodeVarInt(1)...)
+	data = append(data, packet.EncodeVarInt(0)...)
+	data = append(data, packet.EncodeVarInt(1)...)
+
+	if err := c.handleContainerContentPacket(data); err != nil {
+		t.Fatalf("handleContainerContentPacket() error = %v", err)
+	}
+	menu := c.Player.GetActiveContainer()
+	if s := menu.Slot(0); s == nil || s.ID != stone {
+		t.Errorf("槽位 0 = %v, 应应用解析成功的石头", s)
+	}
+	if s := menu.Slot(1); !s.IsEmpty() {
+		t.Errorf("坏槽位 1 = %v, 应记为空", s)
+	}
+	if s := menu.Slot(2); s == nil || s.ID != dirt {
+		t.Errorf("槽位 2 = %v, 无法定位时应保持原样", s)
+	}
+}
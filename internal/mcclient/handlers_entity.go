@@ -8,9 +8,10 @@ import (
 	"math"
 
 	"gmcc/internal/entity"
-	// "gmcc/internal/logx"
+	"gmcc/internal/logx"
 	"gmcc/internal/mcclient/packet"
 	"gmcc/internal/mcclient/protocol"
+	"gmcc/internal/registry"
 )
 
 // handleAddEntity 处理实体生成包 (0x01)
@@ -56,13 +57,16 @@ func (c *Client) handleAddEntity(data []byte) error {
 	}
 
 	// 读取角度
-	if _, err := packet.ReadU8(r); err != nil {
+	pitch, err := readAngle(r)
+	if err != nil {
 		return fmt.Errorf("读取pitch失败: %w", err)
 	}
-	if _, err := packet.ReadU8(r); err != nil {
+	yaw, err := readAngle(r)
+	if err != nil {
 		return fmt.Errorf("读取yaw失败: %w", err)
 	}
-	if _, err := packet.ReadU8(r); err != nil {
+	headYaw, err := readAngle(r)
+	if err != nil {
 		return fmt.Errorf("读取head_yaw失败: %w", err)
 	}
 
@@ -71,19 +75,18 @@ func (c *Client) handleAddEntity(data []byte) error {
 		return fmt.Errorf("读取实体数据失败: %w", err)
 	}
 
-	// 转换为实体类型字符串 (简化处理，实际应该查注册表)
+	// 未知类型保留数字 ID，便于排查注册表版本不一致
 	entityType := fmt.Sprintf("minecraft:entity_%d", entityTypeID)
-
-	// 检查是否为玩家 (玩家类型的注册表ID通常是特定的)
-	// 这里简化处理，实际应该在注册表中查找
-	if entityTypeID == 0 { // 假设0是玩家类型，实际需要查注册表
-		entityType = "minecraft:player"
+	if info := registry.GetEntityRegistry().GetByID(entityTypeID); info != nil {
+		entityType = info.ResourceName()
 	}
 
 	pos := entity.Position{X: x, Y: y, Z: z}
 
 	if c.entityTracker != nil {
 		c.entityTracker.SpawnEntity(int32(entityID), entityType, uuid, pos, velocity)
+		c.entityTracker.UpdateRotation(int32(entityID), yaw, pitch, false)
+		c.entityTracker.UpdateHeadYaw(int32(entityID), headYaw)
 	}
 
 	// logx.Debugf("实体生成: ID=%d, Type=%s, Pos=(%.2f, %.2f, %.2f)", entityID, entityType, x, y, z)
@@ -91,7 +94,27 @@ func (c *Client) handleAddEntity(data []byte) error {
 	return nil
 }
 
-// handleTeleportEntity 处理实体传送包 (0x48)
+// handleEntityDataPacket 处理实体数据包，解码所有实体的数据
+func (c *Client) handleEntityDataPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	entityID, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		logx.PacketError("set_entity_data", data, err)
+		return nil
+	}
+
+	entries, err := entity.ReadMetadata(r)
+	if err != nil {
+		// 保留出错前已读取的条目
+		logx.PacketError("set_entity_data", data, fmt.Errorf("entity %d: %w", entityID, err))
+	}
+	if c.entityTracker != nil && len(entries) > 0 {
+		c.entityTracker.UpdateMetadata(entityID, entries)
+	}
+	return nil
+}
+
+// handleTeleportEntity 处理实体传送包 (0x7B)
 func (c *Client) handleTeleportEntity(data []byte) error {
 	r := bytes.NewReader(data)
 
@@ -101,81 +124,220 @@ func (c *Client) handleTeleportEntity(data []byte) error {
 		return fmt.Errorf("读取实体ID失败: %w", err)
 	}
 
-	// 读取新位置
-	x, err := readFloat64(r)
+	pos, velocity, yaw, pitch, err := readPositionMoveRotation(r)
 	if err != nil {
-		return fmt.Errorf("读取X坐标失败: %w", err)
+		return err
 	}
-	y, err := readFloat64(r)
+
+	// 读取相对标志位
+	relative, err := packet.ReadInt32FromReader(r)
 	if err != nil {
-		return fmt.Errorf("读取Y坐标失败: %w", err)
+		return fmt.Errorf("读取相对标志失败: %w", err)
 	}
-	z, err := readFloat64(r)
+
+	// 读取onGround
+	onGround, err := packet.ReadBool(r)
 	if err != nil {
-		return fmt.Errorf("读取Z坐标失败: %w", err)
+		return fmt.Errorf("读取onGround失败: %w", err)
 	}
 
-	// 读取速度
-	if _, err := readFloat64(r); err != nil {
-		return fmt.Errorf("读取速度X失败: %w", err)
+	if c.entityTracker != nil {
+		c.entityTracker.Teleport(int32(entityID), pos, velocity, yaw, pitch, relative, onGround)
 	}
-	if _, err := readFloat64(r); err != nil {
-		return fmt.Errorf("读取速度Y失败: %w", err)
+
+	return nil
+}
+
+// handleEntityPositionSync 处理实体位置同步包 (0x23)，服务端定期发送的绝对位置
+func (c *Client) handleEntityPositionSync(data []byte) error {
+	r := bytes.NewReader(data)
+
+	entityID, err := packet.ReadVarInt(r)
+	if err != nil {
+		return fmt.Errorf("读取实体ID失败: %w", err)
 	}
-	if _, err := readFloat64(r); err != nil {
-		return fmt.Errorf("读取速度Z失败: %w", err)
+
+	pos, velocity, yaw, pitch, err := readPositionMoveRotation(r)
+	if err != nil {
+		return err
 	}
 
-	// 读取旋转
-	if _, err := readFloat32(r); err != nil {
-		return fmt.Errorf("读取yaw失败: %w", err)
+	onGround, err := packet.ReadBool(r)
+	if err != nil {
+		return fmt.Errorf("读取onGround失败: %w", err)
 	}
-	if _, err := readFloat32(r); err != nil {
-		return fmt.Errorf("读取pitch失败: %w", err)
+
+	if c.entityTracker != nil {
+		c.entityTracker.Teleport(int32(entityID), pos, velocity, yaw, pitch, 0, onGround)
+	}
+
+	return nil
+}
+
+// handleMoveEntityPos 处理实体位置增量更新包 (0x33)
+func (c *Client) handleMoveEntityPos(data []byte) error {
+	r := bytes.NewReader(data)
+
+	// 读取实体ID
+	entityID, err := packet.ReadVarInt(r)
+	if err != nil {
+		return fmt.Errorf("读取实体ID失败: %w", err)
+	}
+
+	deltaX, deltaY, deltaZ, err := readMoveDelta(r)
+	if err != nil {
+		return err
 	}
 
 	// 读取onGround
-	if _, err := packet.ReadBool(r); err != nil {
+	onGround, err := packet.ReadBool(r)
+	if err != nil {
 		return fmt.Errorf("读取onGround失败: %w", err)
 	}
 
 	if c.entityTracker != nil {
-		newPos := entity.Position{X: x, Y: y, Z: z}
-		c.entityTracker.UpdatePosition(int32(entityID), newPos)
+		c.entityTracker.UpdatePositionDelta(int32(entityID), deltaX, deltaY, deltaZ, onGround)
 	}
 
 	return nil
 }
 
-// handleMoveEntityPos 处理实体位置增量更新包 (0x09)
-func (c *Client) handleMoveEntityPos(data []byte) error {
+// handleMoveEntityPosRot 处理实体位置增量和朝向更新包 (0x34)
+func (c *Client) handleMoveEntityPosRot(data []byte) error {
 	r := bytes.NewReader(data)
 
-	// 读取实体ID
 	entityID, err := packet.ReadVarInt(r)
 	if err != nil {
 		return fmt.Errorf("读取实体ID失败: %w", err)
 	}
 
-	// 读取增量 (short类型，需要除以4096)
-	var deltaX, deltaY, deltaZ int16
-	if err := binary.Read(r, binary.BigEndian, &deltaX); err != nil {
-		return fmt.Errorf("读取deltaX失败: %w", err)
+	deltaX, deltaY, deltaZ, err := readMoveDelta(r)
+	if err != nil {
+		return err
 	}
-	if err := binary.Read(r, binary.BigEndian, &deltaY); err != nil {
-		return fmt.Errorf("读取deltaY失败: %w", err)
+
+	yaw, err := readAngle(r)
+	if err != nil {
+		return fmt.Errorf("读取yaw失败: %w", err)
 	}
-	if err := binary.Read(r, binary.BigEndian, &deltaZ); err != nil {
-		return fmt.Errorf("读取deltaZ失败: %w", err)
+	pitch, err := readAngle(r)
+	if err != nil {
+		return fmt.Errorf("读取pitch失败: %w", err)
 	}
 
-	// 读取onGround
-	if _, err := packet.ReadBool(r); err != nil {
+	onGround, err := packet.ReadBool(r)
+	if err != nil {
+		return fmt.Errorf("读取onGround失败: %w", err)
+	}
+
+	if c.entityTracker != nil {
+		c.entityTracker.UpdateRotation(int32(entityID), yaw, pitch, onGround)
+		c.entityTracker.UpdatePositionDelta(int32(entityID), deltaX, deltaY, deltaZ, onGround)
+	}
+
+	return nil
+}
+
+// handleMoveEntityRot 处理实体朝向更新包 (0x36)
+func (c *Client) handleMoveEntityRot(data []byte) error {
+	r := bytes.NewReader(data)
+
+	entityID, err := packet.ReadVarInt(r)
+	if err != nil {
+		return fmt.Errorf("读取实体ID失败: %w", err)
+	}
+
+	yaw, err := readAngle(r)
+	if err != nil {
+		return fmt.Errorf("读取yaw失败: %w", err)
+	}
+	pitch, err := readAngle(r)
+	if err != nil {
+		return fmt.Errorf("读取pitch失败: %w", err)
+	}
+
+	onGround, err := packet.ReadBool(r)
+	if err != nil {
 		return fmt.Errorf("读取onGround失败: %w", err)
 	}
 
 	if c.entityTracker != nil {
-		c.entityTracker.UpdatePositionDelta(int32(entityID), deltaX, deltaY, deltaZ)
+		c.entityTracker.UpdateRotation(int32(entityID), yaw, pitch, onGround)
+	}
+
+	return nil
+}
+
+// handleRotateHead 处理实体头部朝向包 (0x51)
+func (c *Client) handleRotateHead(data []byte) error {
+	r := bytes.NewReader(data)
+
+	entityID, err := packet.ReadVarInt(r)
+	if err != nil {
+		return fmt.Errorf("读取实体ID失败: %w", err)
+	}
+
+	headYaw, err := readAngle(r)
+	if err != nil {
+		return fmt.Errorf("读取head_yaw失败: %w", err)
+	}
+
+	if c.entityTracker != nil {
+		c.entityTracker.UpdateHeadYaw(int32(entityID), headYaw)
+	}
+
+	return nil
+}
+
+// handleSetEntityMotion 处理实体速度包 (0x63)
+func (c *Client) handleSetEntityMotion(data []byte) error {
+	r := bytes.NewReader(data)
+
+	entityID, err := packet.ReadVarInt(r)
+	if err != nil {
+		return fmt.Errorf("读取实体ID失败: %w", err)
+	}
+
+	velocity, err := readLpVec3(r)
+	if err != nil {
+		return fmt.Errorf("读取实体速度失败: %w", err)
+	}
+
+	if c.entityTracker != nil {
+		c.entityTracker.UpdateVelocity(int32(entityID), velocity)
+	}
+
+	return nil
+}
+
+// handleSetPassengers 处理载具乘客包 (0x69)
+func (c *Client) handleSetPassengers(data []byte) error {
+	r := bytes.NewReader(data)
+
+	vehicleID, err := packet.ReadVarInt(r)
+	if err != nil {
+		return fmt.Errorf("读取载具ID失败: %w", err)
+	}
+
+	count, err := packet.ReadVarInt(r)
+	if err != nil {
+		return fmt.Errorf("读取乘客数量失败: %w", err)
+	}
+	if count < 0 || int(count) > r.Len() {
+		return fmt.Errorf("乘客数量无效: %d", count)
+	}
+
+	passengers := make([]int32, 0, count)
+	for i := int32(0); i < count; i++ {
+		id, err := packet.ReadVarInt(r)
+		if err != nil {
+			return fmt.Errorf("读取乘客ID失败: %w", err)
+		}
+		passengers = append(passengers, int32(id))
+	}
+
+	if c.entityTracker != nil {
+		c.entityTracker.SetPassengers(int32(vehicleID), passengers)
 	}
 
 	return nil
@@ -305,6 +467,58 @@ func lpUnpack(value uint64) float64 {
 	return math.Min(raw, lpMaxQuantize)*2.0/lpMaxQuantize - 1.0
 }
 
+// readPositionMoveRotation 读取传送和位置同步共用的位置、速度、朝向
+func readPositionMoveRotation(r *bytes.Reader) (entity.Position, entity.Vector3, float32, float32, error) {
+	var pos entity.Position
+	var velocity entity.Vector3
+	for _, f := range []struct {
+		name string
+		dst  *float64
+	}{
+		{"X坐标", &pos.X}, {"Y坐标", &pos.Y}, {"Z坐标", &pos.Z},
+		{"速度X", &velocity.X}, {"速度Y", &velocity.Y}, {"速度Z", &velocity.Z},
+	} {
+		v, err := readFloat64(r)
+		if err != nil {
+			return pos, velocity, 0, 0, fmt.Errorf("读取%s失败: %w", f.name, err)
+		}
+		*f.dst = v
+	}
+
+	yaw, err := readFloat32(r)
+	if err != nil {
+		return pos, velocity, 0, 0, fmt.Errorf("读取yaw失败: %w", err)
+	}
+	pitch, err := readFloat32(r)
+	if err != nil {
+		return pos, velocity, 0, 0, fmt.Errorf("读取pitch失败: %w", err)
+	}
+	return pos, velocity, yaw, pitch, nil
+}
+
+// readMoveDelta 读取位置增量 (short类型，需要除以4096)
+func readMoveDelta(r *bytes.Reader) (deltaX, deltaY, deltaZ int16, err error) {
+	if err = binary.Read(r, binary.BigEndian, &deltaX); err != nil {
+		return 0, 0, 0, fmt.Errorf("读取deltaX失败: %w", err)
+	}
+	if err = binary.Read(r, binary.BigEndian, &deltaY); err != nil {
+		return 0, 0, 0, fmt.Errorf("读取deltaY失败: %w", err)
+	}
+	if err = binary.Read(r, binary.BigEndian, &deltaZ); err != nil {
+		return 0, 0, 0, fmt.Errorf("读取deltaZ失败: %w", err)
+	}
+	return deltaX, deltaY, deltaZ, nil
+}
+
+// readAngle 读取 1/256 圈精度的角度，返回 [-180, 180) 度
+func readAngle(r io.Reader) (float32, error) {
+	b, err := packet.ReadU8(r)
+	if err != nil {
+		return 0, err
+	}
+	return float32(int8(b)) * 360 / 256, nil
+}
+
 // readFloat64 从reader读取float64
 func readFloat64(r *bytes.Reader) (float64, error) {
 	var v float64
@@ -0,0 +1,134 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"gmcc/internal/logx"
+	"gmcc/internal/mapdata"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/registry"
+)
+
+// 自动保存地图的最小间隔，地图在探索时会频繁更新
+const mapSaveInterval = 2 * time.Second
+
+// SaveMap 将地图保存到配置的目录，返回文件路径
+func (c *Client) SaveMap(id int32) (string, error) {
+	return c.Maps.SavePNG(c.cfg.Maps.SaveDir, id)
+}
+
+func (c *Client) handleMapItemDataPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	id, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 map_item_data 地图ID失败: %w", err)
+	}
+	scale, err := packet.ReadU8(r)
+	if err != nil {
+		return fmt.Errorf("读取 map_item_data 缩放失败: %w", err)
+	}
+	locked, err := packet.ReadBoolFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 map_item_data 锁定标记失败: %w", err)
+	}
+
+	var decorations []mapdata.Decoration
+	hasDecorations, err := packet.ReadBoolFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 map_item_data 标记列表失败: %w", err)
+	}
+	if hasDecorations {
+		if decorations, err = c.readMapDecorations(r); err != nil {
+			return err
+		}
+	}
+
+	patch, err := readMapPatch(r)
+	if err != nil {
+		return err
+	}
+	return c.Maps.Update(id, int8(scale), locked, decorations, patch)
+}
+
+func (c *Client) readMapDecorations(r *bytes.Reader) ([]mapdata.Decoration, error) {
+	count, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取 map_item_data 标记数量失败: %w", err)
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("map_item_data 标记数量无效: %d", count)
+	}
+
+	decorations := make([]mapdata.Decoration, 0, count)
+	for i := int32(0); i < count; i++ {
+		var d mapdata.Decoration
+		if d.TypeID, err = packet.ReadVarIntFromReader(r); err != nil {
+			return nil, fmt.Errorf("读取地图标记[%d]类型失败: %w", i, err)
+		}
+		d.Type = registry.EntryName("minecraft:map_decoration_type", d.TypeID)
+
+		pos, err := packet.ReadBytes(r, 3)
+		if err != nil {
+			return nil, fmt.Errorf("读取地图标记[%d]位置失败: %w", i, err)
+		}
+		d.X, d.Y, d.Rotation = int8(pos[0]), int8(pos[1]), int8(pos[2]&0x0F)
+
+		hasName, err := packet.ReadBoolFromReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("读取地图标记[%d]名称标记失败: %w", i, err)
+		}
+		if hasName {
+			if d.Name, err = c.readAnonymousNBTJSON(r); err != nil {
+				return nil, fmt.Errorf("读取地图标记[%d]名称失败: %w", i, err)
+			}
+		}
+		decorations = append(decorations, d)
+	}
+	return decorations, nil
+}
+
+// readMapPatch 读取可选的颜色更新，宽度为 0 表示没有
+func readMapPatch(r *bytes.Reader) (*mapdata.Patch, error) {
+	width, err := packet.ReadU8(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取 map_item_data 颜色宽度失败: %w", err)
+	}
+	if width == 0 {
+		return nil, nil
+	}
+
+	header, err := packet.ReadBytes(r, 3)
+	if err != nil {
+		return nil, fmt.Errorf("读取 map_item_data 颜色区域失败: %w", err)
+	}
+	colors, err := packet.ReadByteArray(r, r)
+	if err != nil {
+		return nil, fmt.Errorf("读取 map_item_data 颜色数据失败: %w", err)
+	}
+	return &mapdata.Patch{
+		Width:  int(width),
+		Height: int(header[0]),
+		X:      int(header[1]),
+		Y:      int(header[2]),
+		Colors: colors,
+	}, nil
+}
+
+// autoSaveMapsTick 在游戏刻中调用，定期保存有变化的地图
+func (c *Client) autoSaveMapsTick() {
+	if !c.cfg.Maps.AutoSave || time.Since(c.lastMapSave) < mapSaveInterval {
+		return
+	}
+	c.lastMapSave = time.Now()
+
+	for _, id := range c.Maps.TakeDirty() {
+		path, err := c.SaveMap(id)
+		if err != nil {
+			logx.Warnf("保存地图 %d 失败: %v", id, err)
+			continue
+		}
+		logx.Debugf("已保存地图 %d: %s", id, path)
+	}
+}
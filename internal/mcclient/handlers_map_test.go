@@ -0,0 +1,55 @@
+package mcclient
+
+import (
+	"testing"
+
+	"gmcc/internal/config"
+	"gmcc/internal/mcclient/packet"
+)
+
+func TestHandleMapItemDataPacket(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+
+	var data []byte
+	data = append(data, packet.EncodeVarInt(42)...)
+	data = append(data, 1)                           // scale
+	data = append(data, packet.EncodeBool(false)...) // locked
+	data = append(data, packet.EncodeBool(true)...)  // 有标记
+	data = append(data, packet.EncodeVarInt(1)...)
+	data = append(data, packet.EncodeVarInt(1)...) // minecraft:frame
+	data = append(data, 0xF6, 20, 0x13)            // x=-10, y=20, rotation=3
+	data = append(data, packet.EncodeBool(true)...)
+	data = append(data, nbtText("Base")...)
+	data = append(data, 2, 1, 5, 6) // 2x1 区域，从 (5,6) 开始
+	data = append(data, packet.EncodeByteArray([]byte{34, 35})...)
+
+	if err := c.handleMapItemDataPacket(data); err != nil {
+		t.Fatalf("handleMapItemDataPacket() error = %v", err)
+	}
+	m, ok := c.Maps.Get(42)
+	if !ok || m.Scale != 1 || m.Colors[6*128+5] != 34 || m.Colors[6*128+6] != 35 {
+		t.Fatalf("地图 = scale %d, ok %v", m.Scale, ok)
+	}
+	if len(m.Decorations) != 1 {
+		t.Fatalf("Decorations = %+v", m.Decorations)
+	}
+	d := m.Decorations[0]
+	if d.Type != "minecraft:frame" || d.X != -10 || d.Y != 20 || d.Rotation != 3 || d.Name != `"Base"` {
+		t.Errorf("Decoration = %+v", d)
+	}
+
+	// 没有标记和颜色的更新只修改锁定状态
+	var lock []byte
+	lock = append(lock, packet.EncodeVarInt(42)...)
+	lock = append(lock, 1)
+	lock = append(lock, packet.EncodeBool(true)...)
+	lock = append(lock, packet.EncodeBool(false)...)
+	lock = append(lock, 0)
+	if err := c.handleMapItemDataPacket(lock); err != nil {
+		t.Fatalf("handleMapItemDataPacket() error = %v", err)
+	}
+	if m, _ := c.Maps.Get(42); !m.Locked || len(m.Decorations) != 1 || m.Colors[6*128+5] != 34 {
+		t.Errorf("锁定后地图被修改: locked=%v decorations=%d", m.Locked, len(m.Decorations))
+	}
+}
@@ -129,6 +129,15 @@ func (c *Client) handlePlayPacket(pkt packet.Packet) error {
 	case protocol.PlayClientSetHealth:
 		return c.handleSetHealthPacket(pkt.Data)
 
+	case protocol.PlayClientPlayerCombatKill:
+		return c.handlePlayerCombatKillPacket(pkt.Data)
+
+	case protocol.PlayClientRespawn:
+		return c.handleRespawnPacket(pkt.Data)
+
+	case protocol.PlayClientStartConfig:
+		return c.handleStartConfiguration()
+
 	case protocol.PlayClientSetExperience:
 		return c.handleSetExperiencePacket(pkt.Data)
 
@@ -159,9 +168,39 @@ func (c *Client) handlePlayPacket(pkt packet.Packet) error {
 		}
 		return nil
 
+	case protocol.PlayClientSetCursorItem:
+		if c.cfg.Packets.HandleContainer {
+			return c.handleSetCursorItemPacket(pkt.Data)
+		}
+		return nil
+
+	case protocol.PlayClientSetPlayerInv:
+		if c.cfg.Packets.HandleContainer {
+			return c.handleSetPlayerInventoryPacket(pkt.Data)
+		}
+		return nil
+
 	case protocol.PlayClientOpenScreen:
 		return c.handleOpenScreenPacket(pkt.Data)
 
+	case protocol.PlayClientMerchantOffers:
+		return c.handleMerchantOffersPacket(pkt.Data)
+
+	case protocol.PlayClientRecipeBookAdd:
+		return c.handleRecipeBookAddPacket(pkt.Data)
+
+	case protocol.PlayClientRecipeBookRemove:
+		return c.handleRecipeBookRemovePacket(pkt.Data)
+
+	case protocol.PlayClientRecipeBookSet:
+		return c.handleRecipeBookSettingsPacket(pkt.Data)
+
+	case protocol.PlayClientUpdateRecipes:
+		return c.handleUpdateRecipesPacket(pkt.Data)
+
+	case protocol.PlayClientUpdateTags:
+		return c.handleUpdateTagsPacket(pkt.Data)
+
 	case protocol.PlayClientPlayerAbilities:
 		return c.handlePlayerAbilitiesPacket(pkt.Data)
 
@@ -177,6 +216,84 @@ func (c *Client) handlePlayPacket(pkt packet.Packet) error {
 	case protocol.PlayClientPlayerInfoRemove:
 		return c.handlePlayerInfoRemove(pkt.Data)
 
+	case protocol.PlayClientTabList:
+		return c.handleTabListPacket(pkt.Data)
+
+	case protocol.PlayClientSetObjective:
+		return c.handleSetObjectivePacket(pkt.Data)
+
+	case protocol.PlayClientSetDisplayObj:
+		return c.handleSetDisplayObjectivePacket(pkt.Data)
+
+	case protocol.PlayClientSetScore:
+		return c.handleSetScorePacket(pkt.Data)
+
+	case protocol.PlayClientResetScore:
+		return c.handleResetScorePacket(pkt.Data)
+
+	case protocol.PlayClientSetPlayerTeam:
+		return c.handleSetPlayerTeamPacket(pkt.Data)
+
+	case protocol.PlayClientBossEvent:
+		return c.handleBossEventPacket(pkt.Data)
+
+	case protocol.PlayClientSetTitleText:
+		return c.handleSetTitleTextPacket(pkt.Data)
+
+	case protocol.PlayClientSetSubtitleText:
+		return c.handleSetSubtitleTextPacket(pkt.Data)
+
+	case protocol.PlayClientSetTitlesAnim:
+		return c.handleSetTitlesAnimationPacket(pkt.Data)
+
+	case protocol.PlayClientClearTitles:
+		return c.handleClearTitlesPacket(pkt.Data)
+
+	case protocol.PlayClientSetTime:
+		return c.handleSetTimePacket(pkt.Data)
+
+	case protocol.PlayClientInitBorder:
+		return c.handleInitializeBorderPacket(pkt.Data)
+
+	case protocol.PlayClientBorderCenter:
+		return c.handleBorderCenterPacket(pkt.Data)
+
+	case protocol.PlayClientBorderLerpSize:
+		return c.handleBorderLerpSizePacket(pkt.Data)
+
+	case protocol.PlayClientBorderSize:
+		return c.handleBorderSizePacket(pkt.Data)
+
+	case protocol.PlayClientBorderWarnDelay:
+		return c.handleBorderWarningDelayPacket(pkt.Data)
+
+	case protocol.PlayClientBorderWarnDist:
+		return c.handleBorderWarningDistancePacket(pkt.Data)
+
+	case protocol.PlayClientMapItemData:
+		return c.handleMapItemDataPacket(pkt.Data)
+
+	case protocol.PlayClientOpenBook:
+		return c.handleOpenBookPacket(pkt.Data)
+
+	case protocol.PlayClientOpenSignEditor:
+		return c.handleOpenSignEditorPacket(pkt.Data)
+
+	case protocol.PlayClientBlockEntityData:
+		return c.handleBlockEntityDataPacket(pkt.Data)
+
+	case protocol.PlayClientLevelChunk:
+		return c.handleLevelChunkPacket(pkt.Data)
+
+	case protocol.PlayClientForgetChunk:
+		return c.handleForgetLevelChunkPacket(pkt.Data)
+
+	case protocol.PlayClientShowDialog:
+		return c.handleShowDialogPacket(pkt.Data, true)
+
+	case protocol.PlayClientClearDialog:
+		return c.handleClearDialogPacket()
+
 	// 实体跟踪相关包
 	case protocol.PlayClientAddEntity:
 		return c.handleAddEntity(pkt.Data)
@@ -187,6 +304,24 @@ func (c *Client) handlePlayPacket(pkt packet.Packet) error {
 	case protocol.PlayClientMoveEntityPos:
 		return c.handleMoveEntityPos(pkt.Data)
 
+	case protocol.PlayClientMoveEntityPosRot:
+		return c.handleMoveEntityPosRot(pkt.Data)
+
+	case protocol.PlayClientMoveEntityRot:
+		return c.handleMoveEntityRot(pkt.Data)
+
+	case protocol.PlayClientRotateHead:
+		return c.handleRotateHead(pkt.Data)
+
+	case protocol.PlayClientSetEntityMotion:
+		return c.handleSetEntityMotion(pkt.Data)
+
+	case protocol.PlayClientEntityPosSync:
+		return c.handleEntityPositionSync(pkt.Data)
+
+	case protocol.PlayClientSetPassengers:
+		return c.handleSetPassengers(pkt.Data)
+
 	case protocol.PlayClientRemoveEntities:
 		return c.handleRemoveEntities(pkt.Data)
 
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
-	"time"
 
 	"gmcc/internal/logx"
 	"gmcc/internal/mcclient/packet"
@@ -34,15 +33,11 @@ func (c *Client) handleSetHealthPacket(data []byte) error {
 
 	c.Player.UpdateHealth(health, 0, int32(food), saturation)
 
-	// 检测死亡状态并自动重生
+	// player_combat_kill 通常先到，带有死亡消息；这里兜底没有收到的情况
 	if health <= 0 {
-		logx.Infof("检测到玩家死亡，自动发送重生数据包")
-		go func() {
-			time.Sleep(500 * time.Millisecond)
-			if err := c.SendClientCommand(ClientCommandActionPerformRespawn); err != nil {
-				logx.Warnf("发送重生数据包失败: %v", err)
-			}
-		}()
+		c.markDead("", c.currentBlockPos())
+	} else {
+		c.markAlive()
 	}
 
 	return nil
@@ -85,6 +80,15 @@ func (c *Client) handleSetHeldSlotPacket(data []byte) error {
 	return nil
 }
 
+// game_event 事件类型
+const (
+	gameEventStartRaining   = 1
+	gameEventStopRaining    = 2
+	gameEventChangeGameMode = 3
+	gameEventRainLevel      = 7
+	gameEventThunderLevel   = 8
+)
+
 func (c *Client) handleGameEventPacket(data []byte) error {
 	r := bytes.NewReader(data)
 	if r.Len() < 5 {
@@ -95,10 +99,23 @@ func (c *Client) handleGameEventPacket(data []byte) error {
 	var value float32
 	_ = binary.Read(r, binary.BigEndian, &value)
 
-	if eventType == 3 && value >= 0 && value <= 3 {
-		mode := player.GameMode(int(value))
-		c.Player.SetGameMode(mode)
-		logx.Infof("游戏模式变更: %s", mode.String())
+	switch eventType {
+	case gameEventStartRaining:
+		c.World.SetRaining(true)
+		logx.Debugf("开始下雨")
+	case gameEventStopRaining:
+		c.World.SetRaining(false)
+		logx.Debugf("停止下雨")
+	case gameEventChangeGameMode:
+		if value >= 0 && value <= 3 {
+			mode := player.GameMode(int(value))
+			c.Player.SetGameMode(mode)
+			logx.Infof("游戏模式变更: %s", mode.String())
+		}
+	case gameEventRainLevel:
+		c.World.SetRainLevel(value)
+	case gameEventThunderLevel:
+		c.World.SetThunderLevel(value)
 	}
 
 	return nil
@@ -116,32 +133,17 @@ func (c *Client) handlePlayLoginPacket(data []byte) error {
 	if err := c.readLoginWorldSettings(r); err != nil {
 		return err
 	}
-	if err := c.readLoginPlayerState(r); err != nil {
-		return err
-	}
-	if err := c.readLoginDeathLocation(r); err != nil {
-		return err
-	}
-	showDeathScreen, err := packet.ReadBoolFromReader(r)
+	info, err := readSpawnInfo(r)
 	if err != nil {
-		return fmt.Errorf("读取 show_death_screen 失败: %w", err)
-	}
-	if err := c.readLoginMisc(r); err != nil {
 		return err
 	}
+	c.applySpawnInfo(info)
+	_ = packet.MustReadBool(r, "login.enforcesSecureChat")
 
 	logx.Infof("登录Play阶段: EntityID=%d, 维度=%s, 游戏模式=%s", c.Player.EntityID, c.Player.Dimension, c.Player.GameMode.String())
 
-	if showDeathScreen {
-		logx.Infof("检测到死亡状态，自动发送重生数据包")
-		go func() {
-			time.Sleep(500 * time.Millisecond)
-			if err := c.SendClientCommand(ClientCommandActionPerformRespawn); err != nil {
-				logx.Warnf("发送重生数据包失败: %v", err)
-			}
-		}()
-	}
-
+	// 登录时仍处于死亡状态的话，服务器随后会发送血量为 0 的 set_health
+	c.resetDeath()
 	return nil
 }
 
@@ -188,77 +190,6 @@ func (c *Client) readLoginWorldSettings(r *bytes.Reader) error {
 	return nil
 }
 
-func (c *Client) readLoginPlayerState(r *bytes.Reader) error {
-	if _, err := packet.ReadVarIntFromReader(r); err != nil {
-		return fmt.Errorf("读取 dimension_type 失败: %w", err)
-	}
-
-	dimensionName, err := packet.ReadStringFromReader(r)
-	if err != nil {
-		return fmt.Errorf("读取 dimension_name 失败: %w", err)
-	}
-	c.Player.SetDimension(dimensionName)
-
-	var hashedSeed int64
-	binary.Read(r, binary.BigEndian, &hashedSeed)
-
-	gameMode, err := packet.ReadU8(r)
-	if err != nil {
-		return fmt.Errorf("读取 game_mode 失败: %w", err)
-	}
-	c.Player.SetGameMode(player.GameMode(int(gameMode)))
-
-	_, _ = packet.ReadU8(r) // prevGameMode
-	return nil
-}
-
-func (c *Client) readLoginDeathLocation(r *bytes.Reader) error {
-	hasDeathLocation, err := packet.ReadBoolFromReader(r)
-	if err != nil {
-		return fmt.Errorf("读取 has_death_location 失败: %w", err)
-	}
-	if hasDeathLocation {
-		if _, err := packet.ReadStringFromReader(r); err != nil {
-			return fmt.Errorf("读取 death_dimension 失败: %w", err)
-		}
-		var deathPos int64
-		binary.Read(r, binary.BigEndian, &deathPos)
-	}
-	return nil
-}
-
-func (c *Client) readLoginMisc(r *bytes.Reader) error {
-	_ = packet.MustReadVarInt(r, "login.isDebug")          // isDebug
-	_ = packet.MustReadVarInt(r, "login.isFlat")           // isFlat
-	_ = packet.MustReadBool(r, "login.keepAllPlayerData")  // keepAllPlayerData
-	_ = packet.MustReadVarInt(r, "login.portalCooldown")   // portalCooldown
-	_ = packet.MustReadVarInt(r, "login.seaLevel")         // seaLevel
-	_ = packet.MustReadBool(r, "login.secureChatEnforced") // secureChatEnforced
-	return nil
-}
-
-func (c *Client) handleEntityDataPacket(data []byte) error {
-	r := bytes.NewReader(data)
-	entityID, err := packet.ReadVarIntFromReader(r)
-	if err != nil || entityID != c.Player.EntityID {
-		return nil
-	}
-
-	for {
-		index, err := packet.ReadU8(r)
-		if err != nil || index == 0xFF {
-			break
-		}
-		typeID, err := packet.ReadVarIntFromReader(r)
-		if err != nil {
-			break
-		}
-		_ = typeID
-		break
-	}
-	return nil
-}
-
 func (c *Client) handlePlayerAbilitiesPacket(data []byte) error {
 	r := bytes.NewReader(data)
 
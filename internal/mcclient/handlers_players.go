@@ -3,30 +3,22 @@ package mcclient
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"gmcc/internal/logx"
 	"gmcc/internal/mcclient/packet"
-)
-
-const (
-	playerInfoActionAddPlayer         byte = 0x01
-	playerInfoActionInitializeChat    byte = 0x02
-	playerInfoActionUpdateGameMode    byte = 0x04
-	playerInfoActionUpdateListed      byte = 0x08
-	playerInfoActionUpdateLatency     byte = 0x10
-	playerInfoActionUpdateDisplayName byte = 0x20
-	playerInfoActionUpdateListOrder   byte = 0x40
-	playerInfoActionUpdateShowHat     byte = 0x80
+	"gmcc/internal/player"
 )
 
 func (c *Client) handlePlayerInfoUpdate(data []byte) error {
 	r := bytes.NewReader(data)
 
 	// 协议 774: action 是单字节位域，不是 varint
-	action, err := packet.ReadU8(r)
+	rawAction, err := packet.ReadU8(r)
 	if err != nil {
 		return fmt.Errorf("读取 player_info_update action 失败: %w", err)
 	}
+	action := player.TabAction(rawAction)
 
 	count, err := packet.ReadVarInt(r)
 	if err != nil {
@@ -35,88 +27,101 @@ func (c *Client) handlePlayerInfoUpdate(data []byte) error {
 
 	logx.Debugf("player_info_update: action=0x%02x, count=%d", action, count)
 
-	c.playersMu.Lock()
-	defer c.playersMu.Unlock()
-
 	for i := int32(0); i < count; i++ {
-		uuid, err := packet.ReadUUID(r)
+		entry, err := c.readTabEntry(r, action)
 		if err != nil {
-			return fmt.Errorf("读取玩家 UUID 失败: %w", err)
+			return err
 		}
 
-		var playerName string
-		if action&playerInfoActionAddPlayer != 0 {
-			playerName = packet.MustReadString(r, "player_info_update.name")
-			propertiesCount := packet.MustReadVarInt(r, "player_info_update.properties_count")
-
-			for j := int32(0); j < propertiesCount; j++ {
-				_ = packet.MustReadString(r, "player_info_update.property_name")
-				_ = packet.MustReadString(r, "player_info_update.property_value")
-				isSigned := packet.MustReadBool(r, "player_info_update.property_is_signed")
-				if isSigned {
-					_ = packet.MustReadString(r, "player_info_update.property_signature")
-				}
-			}
+		if !c.TabList.Update(action, entry) {
+			logx.Warnf("玩家信息更新: 收到更新但未知玩家 UUID %s (action=0x%02x)", formatUUIDShort(entry.UUID), action)
+			continue
+		}
+		if action&player.TabAddPlayer != 0 {
+			logx.Debugf("列表添加玩家 %s (%s)", entry.Name, formatUUIDShort(entry.UUID))
+		}
+	}
+
+	return nil
+}
+
+// readTabEntry 读取一条 player_info_update，只填充 action 包含的字段
+func (c *Client) readTabEntry(r *bytes.Reader, action player.TabAction) (player.TabEntry, error) {
+	var entry player.TabEntry
+	uuid, err := packet.ReadUUID(r)
+	if err != nil {
+		return entry, fmt.Errorf("读取玩家 UUID 失败: %w", err)
+	}
+	entry.UUID = uuid
 
-			c.players[playerName] = playerInfo{uuid: uuid}
+	if action&player.TabAddPlayer != 0 {
+		entry.Name = packet.MustReadString(r, "player_info_update.name")
+		propertiesCount := packet.MustReadVarInt(r, "player_info_update.properties_count")
 
-			logx.Debugf("列表添加玩家 %s (%s)", playerName, formatUUIDShort(uuid))
-		} else {
-			playerName = c.findPlayerNameByUUIDLocked(uuid)
-			if playerName == "" {
-				logx.Warnf("玩家信息更新: 收到更新但未知玩家 UUID %s (action=0x%02x)", formatUUIDShort(uuid), action)
+		for j := int32(0); j < propertiesCount; j++ {
+			property := player.ProfileProperty{
+				Name:  packet.MustReadString(r, "player_info_update.property_name"),
+				Value: packet.MustReadString(r, "player_info_update.property_value"),
 			}
+			isSigned := packet.MustReadBool(r, "player_info_update.property_is_signed")
+			if isSigned {
+				property.Signature = packet.MustReadString(r, "player_info_update.property_signature")
+			}
+			entry.Properties = append(entry.Properties, property)
 		}
+	}
 
-		if action&playerInfoActionInitializeChat != 0 {
-			hasSession := packet.MustReadBool(r, "player_info_update.has_chat_session")
-			if hasSession {
-				if _, err := packet.ReadUUID(r); err != nil {
-					return fmt.Errorf("读取 player_info_update.chat_session_id 失败: %w", err)
-				}
-				if _, err := packet.ReadInt64(r); err != nil {
-					return fmt.Errorf("读取 player_info_update.public_key_expiry 失败: %w", err)
-				}
-				if _, err := packet.ReadByteArray(r, r); err != nil {
-					return fmt.Errorf("读取 player_info_update.public_key 失败: %w", err)
-				}
-				if _, err := packet.ReadByteArray(r, r); err != nil {
-					return fmt.Errorf("读取 player_info_update.public_key_signature 失败: %w", err)
-				}
+	if action&player.TabInitializeChat != 0 {
+		hasSession := packet.MustReadBool(r, "player_info_update.has_chat_session")
+		if hasSession {
+			session := &player.ChatSession{}
+			if session.SessionID, err = packet.ReadUUID(r); err != nil {
+				return entry, fmt.Errorf("读取 player_info_update.chat_session_id 失败: %w", err)
+			}
+			expiresAt, err := packet.ReadInt64(r)
+			if err != nil {
+				return entry, fmt.Errorf("读取 player_info_update.public_key_expiry 失败: %w", err)
+			}
+			session.ExpiresAt = time.UnixMilli(expiresAt)
+			if session.PublicKey, err = packet.ReadByteArray(r, r); err != nil {
+				return entry, fmt.Errorf("读取 player_info_update.public_key 失败: %w", err)
+			}
+			if session.KeySignature, err = packet.ReadByteArray(r, r); err != nil {
+				return entry, fmt.Errorf("读取 player_info_update.public_key_signature 失败: %w", err)
 			}
+			entry.ChatSession = session
 		}
+	}
 
-		if action&playerInfoActionUpdateGameMode != 0 {
-			_ = packet.MustReadVarInt(r, "player_info_update.gamemode")
-		}
+	if action&player.TabUpdateGameMode != 0 {
+		entry.GameMode = player.GameMode(packet.MustReadVarInt(r, "player_info_update.gamemode"))
+	}
 
-		if action&playerInfoActionUpdateListed != 0 {
-			_ = packet.MustReadBool(r, "player_info_update.listed")
-		}
+	if action&player.TabUpdateListed != 0 {
+		entry.Listed = packet.MustReadBool(r, "player_info_update.listed")
+	}
 
-		if action&playerInfoActionUpdateLatency != 0 {
-			_ = packet.MustReadVarInt(r, "player_info_update.latency")
-		}
+	if action&player.TabUpdateLatency != 0 {
+		entry.Latency = packet.MustReadVarInt(r, "player_info_update.latency")
+	}
 
-		if action&playerInfoActionUpdateDisplayName != 0 {
-			hasDisplayName := packet.MustReadBool(r, "player_info_update.has_display_name")
-			if hasDisplayName {
-				if _, err := c.readAnonymousNBTJSON(r); err != nil {
-					return fmt.Errorf("读取 player_info_update.display_name 失败: %w", err)
-				}
+	if action&player.TabUpdateDisplayName != 0 {
+		hasDisplayName := packet.MustReadBool(r, "player_info_update.has_display_name")
+		if hasDisplayName {
+			if entry.DisplayName, err = c.readAnonymousNBTJSON(r); err != nil {
+				return entry, fmt.Errorf("读取 player_info_update.display_name 失败: %w", err)
 			}
 		}
+	}
 
-		if action&playerInfoActionUpdateListOrder != 0 {
-			_ = packet.MustReadVarInt(r, "player_info_update.list_order")
-		}
-
-		if action&playerInfoActionUpdateShowHat != 0 {
-			_ = packet.MustReadBool(r, "player_info_update.show_hat")
-		}
+	if action&player.TabUpdateListOrder != 0 {
+		entry.ListOrder = packet.MustReadVarInt(r, "player_info_update.list_order")
 	}
 
-	return nil
+	if action&player.TabUpdateShowHat != 0 {
+		entry.ShowHat = packet.MustReadBool(r, "player_info_update.show_hat")
+	}
+	return entry, nil
 }
 
 func (c *Client) handlePlayerInfoRemove(data []byte) error {
@@ -127,37 +132,40 @@ func (c *Client) handlePlayerInfoRemove(data []byte) error {
 		return fmt.Errorf("读取 player_info_remove count 失败: %w", err)
 	}
 
-	c.playersMu.Lock()
-	defer c.playersMu.Unlock()
-
+	if count < 0 || int(count)*16 > r.Len() {
+		return fmt.Errorf("player_info_remove count 无效: %d", count)
+	}
+	uuids := make([][16]byte, 0, count)
 	for i := int32(0); i < count; i++ {
 		uuid, err := packet.ReadUUID(r)
 		if err != nil {
 			return fmt.Errorf("读取玩家 UUID 失败: %w", err)
 		}
-
-		for name, info := range c.players {
-			if info.uuid == uuid {
-				delete(c.players, name)
-				logx.Debugf("玩家移除: %s", name)
-				break
-			}
-		}
+		uuids = append(uuids, uuid)
 	}
+	c.TabList.Remove(uuids)
+	logx.Debugf("player_info_remove: %d 人", len(uuids))
 
 	return nil
 }
 
-func (c *Client) GetOnlinePlayers() []string {
-	c.playersMu.RLock()
-	defer c.playersMu.RUnlock()
-
-	players := make([]string, 0, len(c.players))
-	for name := range c.players {
-		players = append(players, name)
+func (c *Client) handleTabListPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	header, err := c.readAnonymousNBTJSON(r)
+	if err != nil {
+		return fmt.Errorf("解析 tab_list header 失败: %w", err)
+	}
+	footer, err := c.readAnonymousNBTJSON(r)
+	if err != nil {
+		return fmt.Errorf("解析 tab_list footer 失败: %w", err)
 	}
+	c.TabList.SetHeaderFooter(header, footer)
+	return nil
+}
 
-	return players
+// GetOnlinePlayers 返回 Tab 列表中显示的玩家，顺序与客户端一致
+func (c *Client) GetOnlinePlayers() []player.TabEntry {
+	return c.TabList.Listed()
 }
 
 func (c *Client) logOnlinePlayers() {
@@ -167,7 +175,7 @@ func (c *Client) logOnlinePlayers() {
 		return
 	}
 
-	playerList := players[0]
+	playerList := players[0].Name
 	if len(players) > 1 {
 		playerList = fmt.Sprintf("%s 等 %d 人", playerList, len(players))
 	}
@@ -183,19 +191,3 @@ func formatUUIDShort(uuid [16]byte) string {
 	}
 	return string(hex)
 }
-
-func (c *Client) findPlayerNameByUUIDLocked(uuid [16]byte) string {
-	for name, info := range c.players {
-		if info.uuid == uuid {
-			return name
-		}
-	}
-	return ""
-}
-
-func (c *Client) findPlayerNameByUUID(uuid [16]byte) string {
-	c.playersMu.RLock()
-	defer c.playersMu.RUnlock()
-
-	return c.findPlayerNameByUUIDLocked(uuid)
-}
@@ -0,0 +1,59 @@
+package mcclient
+
+import (
+	"testing"
+
+	"gmcc/internal/config"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/player"
+)
+
+func TestHandlePlayerInfoUpdate(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	uuid := [16]byte{0xAB, 0xCD}
+
+	actions := player.TabAddPlayer | player.TabUpdateGameMode | player.TabUpdateListed | player.TabUpdateLatency
+	var data []byte
+	data = append(data, byte(actions))
+	data = append(data, packet.EncodeVarInt(1)...)
+	data = append(data, uuid[:]...)
+	data = append(data, packet.EncodeString("Steve")...)
+	data = append(data, packet.EncodeVarInt(1)...)
+	data = append(data, packet.EncodeString("textures")...)
+	data = append(data, packet.EncodeString("e30=")...)
+	data = append(data, packet.EncodeBool(true)...)
+	data = append(data, packet.EncodeString("c2ln")...)
+	data = append(data, packet.EncodeVarInt(int32(player.GameModeAdventure))...)
+	data = append(data, packet.EncodeBool(true)...)
+	data = append(data, packet.EncodeVarInt(87)...)
+
+	if err := c.handlePlayerInfoUpdate(data); err != nil {
+		t.Fatalf("handlePlayerInfoUpdate() error = %v", err)
+	}
+
+	online := c.GetOnlinePlayers()
+	if len(online) != 1 {
+		t.Fatalf("GetOnlinePlayers() = %+v", online)
+	}
+	e := online[0]
+	if e.Name != "Steve" || e.GameMode != player.GameModeAdventure || e.Latency != 87 {
+		t.Errorf("玩家条目 = %+v", e)
+	}
+	if len(e.Properties) != 1 || e.Properties[0] != (player.ProfileProperty{Name: "textures", Value: "e30=", Signature: "c2ln"}) {
+		t.Errorf("Properties = %+v", e.Properties)
+	}
+	if name, ok := c.getPlayerInfoByUUID(uuid); !ok || name != "Steve" {
+		t.Errorf("getPlayerInfoByUUID() = %q, %v", name, ok)
+	}
+
+	var remove []byte
+	remove = append(remove, packet.EncodeVarInt(1)...)
+	remove = append(remove, uuid[:]...)
+	if err := c.handlePlayerInfoRemove(remove); err != nil {
+		t.Fatalf("handlePlayerInfoRemove() error = %v", err)
+	}
+	if c.TabList.Len() != 0 {
+		t.Errorf("移除后仍有 %d 人", c.TabList.Len())
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"gmcc/internal/logx"
 	"gmcc/internal/mcclient/packet"
 	"gmcc/internal/mcclient/protocol"
+	"gmcc/internal/registry"
 )
 
 func (c *Client) handleLoginPacket(pkt packet.Packet) error {
@@ -128,6 +129,12 @@ func (c *Client) handleConfigurationPacket(pkt packet.Packet) error {
 	case protocol.CfgClientCodeOfConduct:
 		return c.conn.WritePacket(protocol.CfgServerAcceptCode, nil)
 
+	case protocol.CfgClientShowDialog:
+		return c.handleShowDialogPacket(pkt.Data, false)
+
+	case protocol.CfgClientClearDialog:
+		return c.handleClearDialogPacket()
+
 	case protocol.CfgClientFinish:
 		if err := c.conn.WritePacket(protocol.CfgServerFinish, nil); err != nil {
 			return fmt.Errorf("发送 finish_configuration 失败: %w", err)
@@ -139,7 +146,13 @@ func (c *Client) handleConfigurationPacket(pkt packet.Packet) error {
 		logx.Infof("进入 Play 阶段")
 		return nil
 
-	case protocol.CfgClientCustom, protocol.CfgClientRegistry, protocol.CfgClientPackPop:
+	case protocol.CfgClientRegistry:
+		return c.handleRegistryDataPacket(pkt.Data)
+
+	case protocol.CfgClientUpdateTags:
+		return c.handleUpdateTagsPacket(pkt.Data)
+
+	case protocol.CfgClientCustom, protocol.CfgClientPackPop:
 		return nil
 
 	default:
@@ -148,6 +161,40 @@ func (c *Client) handleConfigurationPacket(pkt packet.Packet) error {
 	}
 }
 
+// handleStartConfiguration 从 Play 回到配置阶段 (Velocity 等代理切换后端服务器)。
+// 之后的配置流程与首次进入相同，收到 login 后重新初始化跟踪器与游戏刻循环。
+func (c *Client) handleStartConfiguration() error {
+	c.stopTicker()
+	if err := c.conn.WritePacket(protocol.PlayServerConfigAck, nil); err != nil {
+		return fmt.Errorf("发送 configuration_acknowledged 失败: %w", err)
+	}
+	c.state = protocol.StateConfiguration
+	c.inPlay = false
+
+	// 新的后端服务器会重新下发注册表、标签、配方和命令树，聊天会话也需要重新建立
+	c.chatSessionOK = false
+	c.chatSignMu.Lock()
+	c.chatSession = nil
+	c.chatSignMu.Unlock()
+	c.lastSeen.reset()
+	c.resetChatVerification()
+	c.commandSign = map[string]signableCommandTarget{}
+	registry.ClearDynamicEntries()
+	registry.ClearTags()
+	c.Player.Recipes.Clear()
+	c.TabList.Clear()
+	c.Scoreboard.Clear()
+	c.BossBars.Clear()
+	c.resetTitles()
+	c.World.Reset()
+	c.resetDialogs()
+	c.Maps.Clear()
+	c.resetWorldState()
+
+	logx.Infof("服务器要求重新配置，回到 Configuration 阶段")
+	return nil
+}
+
 func (c *Client) handleEncryptionRequest(data []byte) error {
 	r := bytes.NewReader(data)
 	serverID, err := packet.ReadString(r, r)
@@ -289,3 +336,56 @@ func parseGameProfile(data []byte) ([16]byte, string, error) {
 
 	return uuid, name, nil
 }
+
+// handleRegistryDataPacket 记录动态注册表的条目顺序，条目数据本身不需要解析
+func (c *Client) handleRegistryDataPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	registryID, err := packet.ReadStringFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 registry_data id 失败: %w", err)
+	}
+	count, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 registry_data 条目数量失败: %w", err)
+	}
+
+	// 对话框和聊天类型在 Play 阶段按编号引用，需要保留内容
+	keepData := registryID == "minecraft:dialog" || registryID == "minecraft:chat_type"
+	if count < 0 || int(count) > r.Len() {
+		return fmt.Errorf("registry_data 条目数量无效: %d", count)
+	}
+	names := make([]string, 0, count)
+	var tags []map[string]any
+	for i := int32(0); i < count; i++ {
+		name, err := packet.ReadStringFromReader(r)
+		if err != nil {
+			return fmt.Errorf("读取 registry_data 条目 %d 失败: %w", i, err)
+		}
+		hasData, err := packet.ReadBoolFromReader(r)
+		if err != nil {
+			return fmt.Errorf("读取 registry_data 条目 %d 失败: %w", i, err)
+		}
+		var tag map[string]any
+		if hasData && keepData {
+			if tag, err = readNBT(r); err != nil {
+				return fmt.Errorf("读取 registry_data 条目 %s 失败: %w", name, err)
+			}
+		} else if hasData {
+			if err := packet.SkipNBT(r); err != nil {
+				return fmt.Errorf("跳过 registry_data 条目 %s 失败: %w", name, err)
+			}
+		}
+		names = append(names, name)
+		tags = append(tags, tag)
+	}
+
+	switch registryID {
+	case "minecraft:dialog":
+		c.setDialogRegistry(names, tags)
+	case "minecraft:chat_type":
+		c.setChatTypeRegistry(names, tags)
+	}
+	registry.SetDynamicEntries(registryID, names)
+	logx.Debugf("registry_data: %s (%d 条)", registryID, len(names))
+	return nil
+}
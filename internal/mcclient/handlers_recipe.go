@@ -0,0 +1,193 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/mcclient/protocol"
+	"gmcc/internal/recipe"
+	"gmcc/internal/registry"
+)
+
+func (c *Client) handleRecipeBookAddPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	n, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		logx.PacketError("recipe_book_add", data, err)
+		return nil
+	}
+
+	if n < 0 || int(n) > r.Len() {
+		logx.PacketError("recipe_book_add", data, fmt.Errorf("配方数量无效: %d", n))
+		return nil
+	}
+	entries := make([]*recipe.Entry, 0, n)
+	for i := int32(0); i < n; i++ {
+		e, err := recipe.ReadEntry(r)
+		if err != nil {
+			// 条目长度不定，解析失败后无法继续，保留已读取的部分
+			logx.PacketError("recipe_book_add", data, fmt.Errorf("entry %d: %w", i, err))
+			c.Player.Recipes.Add(entries, false)
+			return nil
+		}
+		entries = append(entries, e)
+	}
+	replace, err := packet.ReadBoolFromReader(r)
+	if err != nil {
+		logx.PacketError("recipe_book_add", data, err)
+		return nil
+	}
+
+	c.Player.Recipes.Add(entries, replace)
+	logx.Debugf("recipe_book_add: %d 个配方, replace=%v, 共 %d", len(entries), replace, c.Player.Recipes.Len())
+	return nil
+}
+
+func (c *Client) handleRecipeBookRemovePacket(data []byte) error {
+	r := bytes.NewReader(data)
+	n, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		logx.PacketError("recipe_book_remove", data, err)
+		return nil
+	}
+	if n < 0 || int(n) > r.Len() {
+		logx.PacketError("recipe_book_remove", data, fmt.Errorf("配方数量无效: %d", n))
+		return nil
+	}
+	ids := make([]int32, 0, n)
+	for i := int32(0); i < n; i++ {
+		id, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			logx.PacketError("recipe_book_remove", data, err)
+			return nil
+		}
+		ids = append(ids, id)
+	}
+
+	c.Player.Recipes.Remove(ids)
+	logx.Debugf("recipe_book_remove: %d 个配方", len(ids))
+	return nil
+}
+
+func (c *Client) handleRecipeBookSettingsPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	var settings [4]recipe.BookSettings
+	for i := range settings {
+		open, err := packet.ReadBoolFromReader(r)
+		if err != nil {
+			logx.PacketError("recipe_book_settings", data, err)
+			return nil
+		}
+		filtering, err := packet.ReadBoolFromReader(r)
+		if err != nil {
+			logx.PacketError("recipe_book_settings", data, err)
+			return nil
+		}
+		settings[i] = recipe.BookSettings{Open: open, Filtering: filtering}
+	}
+
+	c.Player.Recipes.SetSettings(settings)
+	return nil
+}
+
+// handleUpdateRecipesPacket 处理 update_recipes: 物品属性集合 + 切石机配方。
+// 1.21.2 起合成配方改由 recipe_book_add 下发，这里只保存属性集合。
+func (c *Client) handleUpdateRecipesPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	n, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		logx.PacketError("update_recipes", data, err)
+		return nil
+	}
+	sets := make(map[string][]int32, n)
+	for i := int32(0); i < n; i++ {
+		name, err := packet.ReadStringFromReader(r)
+		if err != nil {
+			logx.PacketError("update_recipes", data, err)
+			return nil
+		}
+		ids, err := readVarIntList(r)
+		if err != nil {
+			logx.PacketError("update_recipes", data, fmt.Errorf("%s: %w", name, err))
+			return nil
+		}
+		sets[name] = ids
+	}
+
+	c.Player.Recipes.SetPropertySets(sets)
+	logx.Debugf("update_recipes: %d 个物品属性集合", len(sets))
+	return nil
+}
+
+// handleUpdateTagsPacket 处理 update_tags (配置与游戏阶段格式相同)
+func (c *Client) handleUpdateTagsPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	n, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		logx.PacketError("update_tags", data, err)
+		return nil
+	}
+	for i := int32(0); i < n; i++ {
+		registryID, err := packet.ReadStringFromReader(r)
+		if err != nil {
+			logx.PacketError("update_tags", data, err)
+			return nil
+		}
+		count, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			logx.PacketError("update_tags", data, err)
+			return nil
+		}
+		tags := make(map[string][]int32, count)
+		for j := int32(0); j < count; j++ {
+			tag, err := packet.ReadStringFromReader(r)
+			if err != nil {
+				logx.PacketError("update_tags", data, err)
+				return nil
+			}
+			ids, err := readVarIntList(r)
+			if err != nil {
+				logx.PacketError("update_tags", data, fmt.Errorf("%s #%s: %w", registryID, tag, err))
+				return nil
+			}
+			tags[tag] = ids
+		}
+		registry.SetTags(registryID, tags)
+	}
+	logx.Debugf("update_tags: %d 个注册表", n)
+	return nil
+}
+
+func readVarIntList(r *bytes.Reader) ([]int32, error) {
+	n, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || int(n) > r.Len() {
+		return nil, fmt.Errorf("列表长度无效: %d", n)
+	}
+	ids := make([]int32, n)
+	for i := range ids {
+		if ids[i], err = packet.ReadVarIntFromReader(r); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// SendPlaceRecipe 请求服务端把配方材料摆入合成格，useMaxItems 对应 Shift 点击配方
+func (c *Client) SendPlaceRecipe(containerID, recipeID int32, useMaxItems bool) error {
+	if c.state != protocol.StatePlay {
+		return fmt.Errorf("当前状态不是 Play，无法发送放置配方数据包")
+	}
+	if c.conn == nil {
+		return fmt.Errorf("连接未初始化")
+	}
+
+	payload := packet.EncodeVarInt(containerID)
+	payload = append(payload, packet.EncodeVarInt(recipeID)...)
+	payload = append(payload, packet.EncodeBool(useMaxItems)...)
+	return c.conn.WritePacket(protocol.PlayServerPlaceRecipe, payload)
+}
@@ -0,0 +1,278 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/scoreboard"
+)
+
+// set_objective / set_player_team 的操作类型
+const (
+	objectiveAdd    = 0
+	objectiveRemove = 1
+	objectiveUpdate = 2
+
+	teamAdd          = 0
+	teamRemove       = 1
+	teamUpdate       = 2
+	teamJoin         = 3
+	teamLeave        = 4
+	teamFriendlyFire = 0x01
+	teamSeeInvisible = 0x02
+)
+
+var nameTagVisibilities = []string{"always", "never", "hideForOtherTeams", "hideForOwnTeam"}
+var collisionRules = []string{"always", "never", "pushOtherTeams", "pushOwnTeam"}
+
+// SidebarLines 返回自己看到的侧边栏内容，没有侧边栏时返回 nil
+func (c *Client) SidebarLines() []scoreboard.SidebarLine {
+	return c.Scoreboard.SidebarLines(c.username)
+}
+
+// ScoreOf 返回 holder 在 objective 上的分数
+func (c *Client) ScoreOf(holder, objective string) (scoreboard.Score, bool) {
+	return c.Scoreboard.ScoreOf(holder, objective)
+}
+
+// TeamOf 返回玩家所在的队伍
+func (c *Client) TeamOf(name string) (scoreboard.Team, bool) {
+	return c.Scoreboard.TeamOf(name)
+}
+
+func (c *Client) handleSetObjectivePacket(data []byte) error {
+	r := bytes.NewReader(data)
+	name, err := packet.ReadStringFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_objective 名称失败: %w", err)
+	}
+	method, err := packet.ReadU8(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_objective 操作失败: %w", err)
+	}
+
+	switch method {
+	case objectiveRemove:
+		c.Scoreboard.RemoveObjective(name)
+		logx.Debugf("移除计分目标 %s", name)
+		return nil
+	case objectiveAdd, objectiveUpdate:
+	default:
+		return fmt.Errorf("未知的 set_objective 操作: %d", method)
+	}
+
+	objective := scoreboard.Objective{Name: name}
+	if objective.DisplayName, err = c.readAnonymousNBTJSON(r); err != nil {
+		return fmt.Errorf("读取 set_objective 显示名失败: %w", err)
+	}
+	renderType, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_objective 显示方式失败: %w", err)
+	}
+	objective.RenderType = scoreboard.RenderType(renderType)
+	if objective.NumberFormat, err = c.readOptionalNumberFormat(r); err != nil {
+		return err
+	}
+
+	c.Scoreboard.SetObjective(objective)
+	logx.Debugf("计分目标 %s: %s", name, objective.DisplayName)
+	return nil
+}
+
+func (c *Client) handleSetDisplayObjectivePacket(data []byte) error {
+	r := bytes.NewReader(data)
+	slot, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_display_objective 位置失败: %w", err)
+	}
+	name, err := packet.ReadStringFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_display_objective 目标名失败: %w", err)
+	}
+	c.Scoreboard.SetDisplay(scoreboard.DisplaySlot(slot), name)
+	return nil
+}
+
+func (c *Client) handleSetScorePacket(data []byte) error {
+	r := bytes.NewReader(data)
+	var score scoreboard.Score
+	var err error
+	if score.Holder, err = packet.ReadStringFromReader(r); err != nil {
+		return fmt.Errorf("读取 set_score 持有者失败: %w", err)
+	}
+	if score.Objective, err = packet.ReadStringFromReader(r); err != nil {
+		return fmt.Errorf("读取 set_score 目标名失败: %w", err)
+	}
+	if score.Value, err = packet.ReadVarIntFromReader(r); err != nil {
+		return fmt.Errorf("读取 set_score 分数失败: %w", err)
+	}
+	hasDisplay, err := packet.ReadBoolFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_score 显示名标记失败: %w", err)
+	}
+	if hasDisplay {
+		if score.DisplayName, err = c.readAnonymousNBTJSON(r); err != nil {
+			return fmt.Errorf("读取 set_score 显示名失败: %w", err)
+		}
+	}
+	if score.NumberFormat, err = c.readOptionalNumberFormat(r); err != nil {
+		return err
+	}
+
+	c.Scoreboard.SetScore(score)
+	return nil
+}
+
+func (c *Client) handleResetScorePacket(data []byte) error {
+	r := bytes.NewReader(data)
+	holder, err := packet.ReadStringFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 reset_score 持有者失败: %w", err)
+	}
+	hasObjective, err := packet.ReadBoolFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 reset_score 目标标记失败: %w", err)
+	}
+	objective := ""
+	if hasObjective {
+		if objective, err = packet.ReadStringFromReader(r); err != nil {
+			return fmt.Errorf("读取 reset_score 目标名失败: %w", err)
+		}
+	}
+	c.Scoreboard.ResetScore(holder, objective)
+	return nil
+}
+
+func (c *Client) handleSetPlayerTeamPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	name, err := packet.ReadStringFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_player_team 队伍名失败: %w", err)
+	}
+	method, err := packet.ReadU8(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_player_team 操作失败: %w", err)
+	}
+
+	switch method {
+	case teamRemove:
+		c.Scoreboard.RemoveTeam(name)
+		return nil
+	case teamAdd, teamUpdate, teamJoin, teamLeave:
+	default:
+		return fmt.Errorf("未知的 set_player_team 操作: %d", method)
+	}
+
+	if method == teamAdd || method == teamUpdate {
+		team, err := c.readTeamParameters(r)
+		if err != nil {
+			return err
+		}
+		team.Name = name
+		c.Scoreboard.SetTeam(team)
+	}
+	if method == teamUpdate {
+		return nil
+	}
+
+	count, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_player_team 成员数失败: %w", err)
+	}
+	if count < 0 || int(count) > r.Len() {
+		return fmt.Errorf("set_player_team 成员数无效: %d", count)
+	}
+	members := make([]string, 0, count)
+	for i := int32(0); i < count; i++ {
+		member, err := packet.ReadStringFromReader(r)
+		if err != nil {
+			return fmt.Errorf("读取 set_player_team 成员失败: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if method == teamLeave {
+		c.Scoreboard.RemoveTeamMembers(name, members)
+	} else {
+		c.Scoreboard.AddTeamMembers(name, members)
+	}
+	return nil
+}
+
+func (c *Client) readTeamParameters(r *bytes.Reader) (scoreboard.Team, error) {
+	var team scoreboard.Team
+	var err error
+	if team.DisplayName, err = c.readAnonymousNBTJSON(r); err != nil {
+		return team, fmt.Errorf("读取队伍显示名失败: %w", err)
+	}
+	options, err := packet.ReadU8(r)
+	if err != nil {
+		return team, fmt.Errorf("读取队伍选项失败: %w", err)
+	}
+	team.FriendlyFire = options&teamFriendlyFire != 0
+	team.SeeInvisible = options&teamSeeInvisible != 0
+
+	visibility, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return team, fmt.Errorf("读取队伍名牌可见性失败: %w", err)
+	}
+	team.NameTagVisibility = enumName(nameTagVisibilities, visibility)
+	collision, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return team, fmt.Errorf("读取队伍碰撞规则失败: %w", err)
+	}
+	team.CollisionRule = enumName(collisionRules, collision)
+	color, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return team, fmt.Errorf("读取队伍颜色失败: %w", err)
+	}
+	team.Color = scoreboard.ColorName(color)
+
+	if team.Prefix, err = c.readAnonymousNBTJSON(r); err != nil {
+		return team, fmt.Errorf("读取队伍前缀失败: %w", err)
+	}
+	if team.Suffix, err = c.readAnonymousNBTJSON(r); err != nil {
+		return team, fmt.Errorf("读取队伍后缀失败: %w", err)
+	}
+	return team, nil
+}
+
+// readOptionalNumberFormat 读取可选的分数显示格式，不存在时返回 nil
+func (c *Client) readOptionalNumberFormat(r *bytes.Reader) (*scoreboard.NumberFormat, error) {
+	present, err := packet.ReadBoolFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取分数格式标记失败: %w", err)
+	}
+	if !present {
+		return nil, nil
+	}
+	kind, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取分数格式类型失败: %w", err)
+	}
+
+	format := &scoreboard.NumberFormat{Kind: scoreboard.NumberFormatKind(kind)}
+	switch format.Kind {
+	case scoreboard.FormatBlank:
+	case scoreboard.FormatStyled:
+		if format.Style, err = c.readAnonymousNBTJSON(r); err != nil {
+			return nil, fmt.Errorf("读取分数样式失败: %w", err)
+		}
+	case scoreboard.FormatFixed:
+		if format.Fixed, err = c.readAnonymousNBTJSON(r); err != nil {
+			return nil, fmt.Errorf("读取分数固定文本失败: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("未知的分数格式类型: %d", kind)
+	}
+	return format, nil
+}
+
+func enumName(names []string, id int32) string {
+	if id < 0 || int(id) >= len(names) {
+		return names[0]
+	}
+	return names[id]
+}
@@ -0,0 +1,89 @@
+package mcclient
+
+import (
+	"testing"
+
+	"gmcc/internal/config"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/scoreboard"
+)
+
+// nbtText 编码 Network NBT 字符串标签形式的文本组件
+func nbtText(s string) []byte {
+	data := []byte{0x08, byte(len(s) >> 8), byte(len(s))}
+	return append(data, s...)
+}
+
+func TestHandleScoreboardPackets(t *testing.T) {
+	cfg := config.Default()
+	cfg.Account.PlayerID = "Bot"
+	c := New(&cfg)
+
+	var objective []byte
+	objective = append(objective, packet.EncodeString("eco")...)
+	objective = append(objective, objectiveAdd)
+	objective = append(objective, nbtText("Balance")...)
+	objective = append(objective, packet.EncodeVarInt(int32(scoreboard.RenderInteger))...)
+	objective = append(objective, packet.EncodeBool(true)...)
+	objective = append(objective, packet.EncodeVarInt(int32(scoreboard.FormatFixed))...)
+	objective = append(objective, nbtText("-")...)
+	if err := c.handleSetObjectivePacket(objective); err != nil {
+		t.Fatalf("handleSetObjectivePacket() error = %v", err)
+	}
+
+	var display []byte
+	display = append(display, packet.EncodeVarInt(int32(scoreboard.SlotSidebar))...)
+	display = append(display, packet.EncodeString("eco")...)
+	if err := c.handleSetDisplayObjectivePacket(display); err != nil {
+		t.Fatalf("handleSetDisplayObjectivePacket() error = %v", err)
+	}
+
+	var score []byte
+	score = append(score, packet.EncodeString("Bot")...)
+	score = append(score, packet.EncodeString("eco")...)
+	score = append(score, packet.EncodeVarInt(250)...)
+	score = append(score, packet.EncodeBool(false)...)
+	score = append(score, packet.EncodeBool(true)...)
+	score = append(score, packet.EncodeVarInt(int32(scoreboard.FormatBlank))...)
+	if err := c.handleSetScorePacket(score); err != nil {
+		t.Fatalf("handleSetScorePacket() error = %v", err)
+	}
+
+	var team []byte
+	team = append(team, packet.EncodeString("staff")...)
+	team = append(team, teamAdd)
+	team = append(team, nbtText("Staff")...)
+	team = append(team, teamFriendlyFire)
+	team = append(team, packet.EncodeVarInt(2)...)
+	team = append(team, packet.EncodeVarInt(1)...)
+	team = append(team, packet.EncodeVarInt(12)...)
+	team = append(team, nbtText("[S] ")...)
+	team = append(team, nbtText("")...)
+	team = append(team, packet.EncodeVarInt(1)...)
+	team = append(team, packet.EncodeString("Bot")...)
+	if err := c.handleSetPlayerTeamPacket(team); err != nil {
+		t.Fatalf("handleSetPlayerTeamPacket() error = %v", err)
+	}
+
+	got, ok := c.TeamOf("Bot")
+	if !ok || got.Color != "red" || !got.FriendlyFire || got.NameTagVisibility != "hideForOtherTeams" || got.CollisionRule != "never" {
+		t.Fatalf("TeamOf(Bot) = %+v, %v", got, ok)
+	}
+	if s, ok := c.ScoreOf("Bot", "eco"); !ok || s.Value != 250 {
+		t.Fatalf("ScoreOf(Bot, eco) = %+v, %v", s, ok)
+	}
+	lines := c.SidebarLines()
+	if len(lines) != 1 || lines[0].Text != "[S] Bot" || lines[0].ValueText != "" {
+		t.Fatalf("SidebarLines() = %+v", lines)
+	}
+
+	var reset []byte
+	reset = append(reset, packet.EncodeString("Bot")...)
+	reset = append(reset, packet.EncodeBool(false)...)
+	if err := c.handleResetScorePacket(reset); err != nil {
+		t.Fatalf("handleResetScorePacket() error = %v", err)
+	}
+	if len(c.SidebarLines()) != 0 {
+		t.Error("reset_score 后侧边栏仍有内容")
+	}
+}
@@ -0,0 +1,311 @@
+package mcclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/chat"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/mcclient/protocol"
+	"gmcc/internal/nbt"
+	"gmcc/internal/world"
+)
+
+// block_entity_type 注册表中告示牌的编号
+const (
+	blockEntitySign        int32 = 7
+	blockEntityHangingSign int32 = 8
+)
+
+// sign_update 每行的最大长度 (UTF-16 码元)
+const maxSignLineLength = 384
+
+// SignEditorEvent 服务端打开告示牌编辑界面
+type SignEditorEvent struct {
+	Pos   world.BlockPos
+	Front bool
+	Sign  world.Sign // 当前内容，Known 为 false 时为空
+	Known bool
+}
+
+// SetSignEditorHandler 设置告示牌编辑回调，回调中可直接调用 UpdateSign 作答
+func (c *Client) SetSignEditorHandler(handler func(SignEditorEvent)) {
+	c.signHandler = handler
+}
+
+// SignEditor 返回尚未提交的告示牌编辑界面
+func (c *Client) SignEditor() (SignEditorEvent, bool) {
+	c.signMu.Lock()
+	defer c.signMu.Unlock()
+	if c.signEditor == nil {
+		return SignEditorEvent{}, false
+	}
+	return *c.signEditor, true
+}
+
+// UpdateSign 提交告示牌内容 (sign_update)，front 为 false 时编辑背面
+func (c *Client) UpdateSign(pos world.BlockPos, front bool, lines [world.SignLines]string) error {
+	if c.state != protocol.StatePlay {
+		return fmt.Errorf("当前状态不是 Play，无法发送告示牌数据包")
+	}
+	if c.conn == nil {
+		return fmt.Errorf("连接未初始化")
+	}
+	payload, err := encodeSignUpdate(pos, front, lines)
+	if err != nil {
+		return err
+	}
+	if err := c.conn.WritePacket(protocol.PlayServerSignUpdate, payload); err != nil {
+		return err
+	}
+
+	c.signMu.Lock()
+	if c.signEditor != nil && c.signEditor.Pos == pos {
+		c.signEditor = nil
+	}
+	c.signMu.Unlock()
+	return nil
+}
+
+// closeSignEditor 切换世界后服务端不再等待告示牌内容
+func (c *Client) closeSignEditor() {
+	c.signMu.Lock()
+	defer c.signMu.Unlock()
+	c.signEditor = nil
+}
+
+func encodeSignUpdate(pos world.BlockPos, front bool, lines [world.SignLines]string) ([]byte, error) {
+	payload := make([]byte, 0, 32)
+	payload = append(payload, packet.EncodeBlockPos(pos.X, pos.Y, pos.Z)...)
+	payload = append(payload, packet.EncodeBool(front)...)
+	for i, line := range lines {
+		if n := len(utf16.Encode([]rune(line))); n > maxSignLineLength {
+			return nil, fmt.Errorf("第 %d 行长度 %d 超过上限 %d", i+1, n, maxSignLineLength)
+		}
+		payload = append(payload, packet.EncodeString(line)...)
+	}
+	return payload, nil
+}
+
+func (c *Client) handleOpenSignEditorPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	pos, err := readBlockPos(r)
+	if err != nil {
+		return fmt.Errorf("读取 open_sign_editor 位置失败: %w", err)
+	}
+	front, err := packet.ReadBoolFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 open_sign_editor 朝向失败: %w", err)
+	}
+
+	sign, known := c.World.Sign(pos)
+	event := SignEditorEvent{Pos: pos, Front: front, Sign: sign, Known: known}
+	c.signMu.Lock()
+	c.signEditor = &event
+	c.signMu.Unlock()
+
+	logx.Infof("服务端打开告示牌编辑: %s (正面=%v)", pos, front)
+	if c.signHandler != nil {
+		c.signHandler(event)
+	}
+	return nil
+}
+
+func (c *Client) handleBlockEntityDataPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	pos, err := readBlockPos(r)
+	if err != nil {
+		return fmt.Errorf("读取 block_entity_data 位置失败: %w", err)
+	}
+	typeID, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 block_entity_data 类型失败: %w", err)
+	}
+	if typeID != blockEntitySign && typeID != blockEntityHangingSign {
+		c.World.RemoveSign(pos)
+		return nil
+	}
+	return c.readSign(r, pos, typeID)
+}
+
+// handleLevelChunkPacket 只读取区块中的方块实体，区块数据本身不解析
+func (c *Client) handleLevelChunkPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	chunkX, err := packet.ReadInt32(r)
+	if err != nil {
+		return fmt.Errorf("读取区块 X 失败: %w", err)
+	}
+	chunkZ, err := packet.ReadInt32(r)
+	if err != nil {
+		return fmt.Errorf("读取区块 Z 失败: %w", err)
+	}
+	if err := skipHeightmaps(r); err != nil {
+		return err
+	}
+	if _, err := packet.ReadByteArray(r, r); err != nil {
+		return fmt.Errorf("读取区块数据失败: %w", err)
+	}
+
+	count, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取方块实体数量失败: %w", err)
+	}
+	if count < 0 || int(count) > r.Len() {
+		return fmt.Errorf("方块实体数量无效: %d", count)
+	}
+
+	// 重新发送的区块会替换旧内容
+	c.World.ForgetChunk(chunkX, chunkZ)
+	for i := int32(0); i < count; i++ {
+		header, err := packet.ReadBytes(r, 3)
+		if err != nil {
+			return fmt.Errorf("读取方块实体[%d]位置失败: %w", i, err)
+		}
+		pos := world.BlockPos{
+			X: chunkX<<4 | int32(header[0]>>4),
+			Y: int32(int16(uint16(header[1])<<8 | uint16(header[2]))),
+			Z: chunkZ<<4 | int32(header[0]&0x0F),
+		}
+		typeID, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			return fmt.Errorf("读取方块实体[%d]类型失败: %w", i, err)
+		}
+		if typeID == blockEntitySign || typeID == blockEntityHangingSign {
+			if err := c.readSign(r, pos, typeID); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := skipNBT(r); err != nil {
+			return fmt.Errorf("跳过方块实体[%d]数据失败: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) handleForgetLevelChunkPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	chunkZ, err := packet.ReadInt32(r)
+	if err != nil {
+		return fmt.Errorf("读取 forget_level_chunk 失败: %w", err)
+	}
+	chunkX, err := packet.ReadInt32(r)
+	if err != nil {
+		return fmt.Errorf("读取 forget_level_chunk 失败: %w", err)
+	}
+	c.World.ForgetChunk(chunkX, chunkZ)
+	return nil
+}
+
+// readSign 读取告示牌 NBT 并写入世界，空 NBT 表示内容不变
+func (c *Client) readSign(r *bytes.Reader, pos world.BlockPos, typeID int32) error {
+	tag, err := readNBT(r)
+	if err != nil {
+		return fmt.Errorf("读取告示牌 %s 数据失败: %w", pos, err)
+	}
+	if tag == nil {
+		return nil
+	}
+
+	sign := world.Sign{
+		Pos:     pos,
+		Hanging: typeID == blockEntityHangingSign,
+		Front:   parseSignText(tag["front_text"]),
+		Back:    parseSignText(tag["back_text"]),
+		Waxed:   nbtBool(tag["is_waxed"]),
+	}
+	c.World.SetSign(sign)
+	return nil
+}
+
+func parseSignText(v any) world.SignText {
+	text := world.SignText{Color: "black"}
+	m, _ := v.(map[string]any)
+	if m == nil {
+		return text
+	}
+	if color, ok := m["color"].(string); ok && color != "" {
+		text.Color = color
+	}
+	text.Glowing = nbtBool(m["has_glowing_text"])
+
+	messages, _ := m["messages"].([]any)
+	for i := 0; i < len(messages) && i < world.SignLines; i++ {
+		raw, err := json.Marshal(messages[i])
+		if err != nil {
+			continue
+		}
+		text.Messages[i] = string(raw)
+		text.Lines[i] = chat.ExtractPlainTextFromChatJSON(text.Messages[i])
+	}
+	return text
+}
+
+func nbtBool(v any) bool {
+	b, _ := v.(int8)
+	return b != 0
+}
+
+// readNBT 读取网络 NBT 复合标签，TAG_End 返回 nil
+func readNBT(r *bytes.Reader) (map[string]any, error) {
+	dec := nbt.NewDecoder(r)
+	dec.NetworkFormat(true)
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		if errors.Is(err, nbt.ErrEND) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("方块实体数据不是复合标签: %T", v)
+	}
+	return m, nil
+}
+
+func skipNBT(r *bytes.Reader) error {
+	_, err := readNBT(r)
+	return err
+}
+
+// skipHeightmaps 跳过高度图: 数量 + (类型, long 数组)
+func skipHeightmaps(r *bytes.Reader) error {
+	count, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取高度图数量失败: %w", err)
+	}
+	if count < 0 || int(count) > r.Len() {
+		return fmt.Errorf("高度图数量无效: %d", count)
+	}
+	for i := int32(0); i < count; i++ {
+		if _, err := packet.ReadVarIntFromReader(r); err != nil {
+			return fmt.Errorf("读取高度图类型失败: %w", err)
+		}
+		longs, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			return fmt.Errorf("读取高度图长度失败: %w", err)
+		}
+		if longs < 0 || int(longs)*8 > r.Len() {
+			return fmt.Errorf("高度图长度无效: %d", longs)
+		}
+		if _, err := r.Seek(int64(longs)*8, io.SeekCurrent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readBlockPos(r *bytes.Reader) (world.BlockPos, error) {
+	packed, err := packet.ReadInt64(r)
+	if err != nil {
+		return world.BlockPos{}, err
+	}
+	x, y, z := packet.DecodeBlockPos(packed)
+	return world.BlockPos{X: x, Y: y, Z: z}, nil
+}
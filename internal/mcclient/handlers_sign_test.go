@@ -0,0 +1,139 @@
+package mcclient
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gmcc/internal/config"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/nbt"
+	"gmcc/internal/world"
+)
+
+// signNBT 编码告示牌方块实体的网络 NBT
+func signNBT(t *testing.T, front [4]any, color string, glowing, waxed bool) []byte {
+	t.Helper()
+	tag := map[string]any{
+		"front_text": map[string]any{
+			"messages":         front[:],
+			"color":            color,
+			"has_glowing_text": glowing,
+		},
+		"back_text": map[string]any{
+			"messages": []any{"", "", "", ""},
+		},
+		"is_waxed": waxed,
+	}
+	buf := bytes.NewBuffer([]byte{nbt.TagCompound})
+	if err := nbt.NewEncoder(buf).NetworkFormat(true).Encode(tag, ""); err != nil {
+		t.Fatalf("编码 NBT 失败: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestHandleSignPackets(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+
+	// 区块 (2, -1) 中的告示牌 (37, 64, -3) 和一个箱子
+	var chunk []byte
+	chunk = append(chunk, packet.EncodeInt32(2)...)
+	chunk = append(chunk, packet.EncodeInt32(-1)...)
+	chunk = append(chunk, packet.EncodeVarInt(1)...) // 高度图
+	chunk = append(chunk, packet.EncodeVarInt(1)...)
+	chunk = append(chunk, packet.EncodeVarInt(1)...)
+	chunk = append(chunk, packet.EncodeInt64(0)...)
+	chunk = append(chunk, packet.EncodeByteArray([]byte{1, 2, 3})...)
+	chunk = append(chunk, packet.EncodeVarInt(2)...)
+	chunk = append(chunk, 5<<4|13, 0, 64)
+	chunk = append(chunk, packet.EncodeVarInt(blockEntitySign)...)
+	chunk = append(chunk, signNBT(t, [4]any{
+		map[string]any{"text": "Type"},
+		map[string]any{"text": "captcha", "color": "red"},
+		map[string]any{"text": ""},
+		map[string]any{"text": ""},
+	}, "red", true, false)...)
+	chunk = append(chunk, 0, 0, 70)
+	chunk = append(chunk, packet.EncodeVarInt(1)...) // chest
+	chunk = append(chunk, nbt.TagCompound, nbt.TagEnd)
+	chunk = append(chunk, 0, 0) // 光照数据不读取
+
+	if err := c.handleLevelChunkPacket(chunk); err != nil {
+		t.Fatalf("handleLevelChunkPacket() error = %v", err)
+	}
+	pos := world.BlockPos{X: 37, Y: 64, Z: -3}
+	sign, ok := c.World.Sign(pos)
+	if !ok {
+		t.Fatalf("Signs() = %+v", c.World.Signs())
+	}
+	if sign.Front.Lines != [4]string{"Type", "captcha", "", ""} || sign.Front.Color != "red" || !sign.Front.Glowing || sign.Waxed || sign.Back.Color != "black" {
+		t.Errorf("Sign = %+v", sign)
+	}
+	if len(c.World.Signs()) != 1 {
+		t.Errorf("Signs() = %+v", c.World.Signs())
+	}
+
+	// 服务端打开编辑界面
+	var events []SignEditorEvent
+	c.SetSignEditorHandler(func(e SignEditorEvent) { events = append(events, e) })
+	open := append(packet.EncodeBlockPos(37, 64, -3), packet.EncodeBool(true)...)
+	if err := c.handleOpenSignEditorPacket(open); err != nil {
+		t.Fatalf("handleOpenSignEditorPacket() error = %v", err)
+	}
+	if len(events) != 1 || events[0].Pos != pos || !events[0].Front || !events[0].Known {
+		t.Errorf("events = %+v", events)
+	}
+	if e, ok := c.SignEditor(); !ok || e.Pos != pos {
+		t.Errorf("SignEditor() = %+v, %v", e, ok)
+	}
+
+	// 方块实体被替换
+	replace := append(packet.EncodeBlockPos(37, 64, -3), packet.EncodeVarInt(1)...)
+	replace = append(replace, nbt.TagEnd)
+	if err := c.handleBlockEntityDataPacket(replace); err != nil {
+		t.Fatalf("handleBlockEntityDataPacket() error = %v", err)
+	}
+	if _, ok := c.World.Sign(pos); ok {
+		t.Error("替换后的告示牌应被移除")
+	}
+
+	// 重新加载后卸载区块
+	if err := c.handleLevelChunkPacket(chunk); err != nil {
+		t.Fatalf("handleLevelChunkPacket() error = %v", err)
+	}
+	forget := append(packet.EncodeInt32(-1), packet.EncodeInt32(2)...)
+	if err := c.handleForgetLevelChunkPacket(forget); err != nil {
+		t.Fatalf("handleForgetLevelChunkPacket() error = %v", err)
+	}
+	if len(c.World.Signs()) != 0 {
+		t.Errorf("卸载区块后 Signs() = %+v", c.World.Signs())
+	}
+}
+
+func TestEncodeSignUpdate(t *testing.T) {
+	payload, err := encodeSignUpdate(world.BlockPos{X: -5, Y: -60, Z: 300}, false, [4]string{"a", "", "验证码", ""})
+	if err != nil {
+		t.Fatalf("encodeSignUpdate() error = %v", err)
+	}
+	r := bytes.NewReader(payload)
+	packed, _ := packet.ReadInt64(r)
+	if x, y, z := packet.DecodeBlockPos(packed); x != -5 || y != -60 || z != 300 {
+		t.Errorf("pos = %d,%d,%d", x, y, z)
+	}
+	if packet.MustReadBool(r, "front") {
+		t.Error("front = true, want false")
+	}
+	var lines [4]string
+	for i := range lines {
+		lines[i] = packet.MustReadString(r, "line")
+	}
+	if lines != [4]string{"a", "", "验证码", ""} || r.Len() != 0 {
+		t.Errorf("lines = %q, 剩余 %d 字节", lines, r.Len())
+	}
+
+	long := strings.Repeat("x", maxSignLineLength+1)
+	if _, err := encodeSignUpdate(world.BlockPos{}, true, [4]string{long}); err == nil {
+		t.Error("超长的行应返回错误")
+	}
+}
@@ -0,0 +1,147 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/world"
+)
+
+// Latency 返回服务器通过 keep_alive 测得的往返延迟 (Tab 列表中自己的延迟)
+func (c *Client) Latency() (time.Duration, bool) {
+	entry, ok := c.TabList.Get(c.uuid)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(entry.Latency) * time.Millisecond, true
+}
+
+// TPS 返回根据 set_time 估算的服务器 TPS
+func (c *Client) TPS() (world.TPS, bool) {
+	return c.World.TPS(time.Now())
+}
+
+func (c *Client) handleSetTimePacket(data []byte) error {
+	r := bytes.NewReader(data)
+	var t world.Time
+	var err error
+	if t.WorldAge, err = packet.ReadInt64(r); err != nil {
+		return fmt.Errorf("读取 set_time world_age 失败: %w", err)
+	}
+	if t.DayTime, err = packet.ReadInt64(r); err != nil {
+		return fmt.Errorf("读取 set_time day_time 失败: %w", err)
+	}
+	if t.DaylightCycle, err = packet.ReadBoolFromReader(r); err != nil {
+		return fmt.Errorf("读取 set_time tick_day_time 失败: %w", err)
+	}
+	c.World.SetTime(t, time.Now())
+	return nil
+}
+
+func (c *Client) handleInitializeBorderPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	var border world.Border
+	var err error
+	if border.CenterX, err = packet.ReadFloat64FromReader(r); err != nil {
+		return fmt.Errorf("读取 initialize_border x 失败: %w", err)
+	}
+	if border.CenterZ, err = packet.ReadFloat64FromReader(r); err != nil {
+		return fmt.Errorf("读取 initialize_border z 失败: %w", err)
+	}
+	lerp, err := readBorderLerp(r)
+	if err != nil {
+		return err
+	}
+	if border.AbsoluteMaxSize, err = packet.ReadVarIntFromReader(r); err != nil {
+		return fmt.Errorf("读取 initialize_border 最大尺寸失败: %w", err)
+	}
+	if border.WarningBlocks, err = packet.ReadVarIntFromReader(r); err != nil {
+		return fmt.Errorf("读取 initialize_border 警告距离失败: %w", err)
+	}
+	if border.WarningTime, err = packet.ReadVarIntFromReader(r); err != nil {
+		return fmt.Errorf("读取 initialize_border 警告时间失败: %w", err)
+	}
+
+	c.World.UpdateBorder(func(b *world.Border) {
+		*b = border
+		lerp(b)
+	})
+	return nil
+}
+
+func (c *Client) handleBorderCenterPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	x, err := packet.ReadFloat64FromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_border_center x 失败: %w", err)
+	}
+	z, err := packet.ReadFloat64FromReader(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_border_center z 失败: %w", err)
+	}
+	c.World.UpdateBorder(func(b *world.Border) { b.CenterX, b.CenterZ = x, z })
+	return nil
+}
+
+func (c *Client) handleBorderLerpSizePacket(data []byte) error {
+	lerp, err := readBorderLerp(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	c.World.UpdateBorder(lerp)
+	return nil
+}
+
+func (c *Client) handleBorderSizePacket(data []byte) error {
+	size, err := packet.ReadFloat64FromReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("读取 set_border_size 失败: %w", err)
+	}
+	c.World.UpdateBorder(func(b *world.Border) {
+		b.Size = size
+		b.LerpDuration = 0
+	})
+	return nil
+}
+
+func (c *Client) handleBorderWarningDelayPacket(data []byte) error {
+	delay, err := packet.ReadVarIntFromReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("读取 set_border_warning_delay 失败: %w", err)
+	}
+	c.World.UpdateBorder(func(b *world.Border) { b.WarningTime = delay })
+	return nil
+}
+
+func (c *Client) handleBorderWarningDistancePacket(data []byte) error {
+	distance, err := packet.ReadVarIntFromReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("读取 set_border_warning_distance 失败: %w", err)
+	}
+	c.World.UpdateBorder(func(b *world.Border) { b.WarningBlocks = distance })
+	return nil
+}
+
+// readBorderLerp 读取 old_size / new_size / lerp_time(毫秒)，返回应用到边界的函数
+func readBorderLerp(r *bytes.Reader) (func(*world.Border), error) {
+	from, err := packet.ReadFloat64FromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取边界原尺寸失败: %w", err)
+	}
+	to, err := packet.ReadFloat64FromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取边界新尺寸失败: %w", err)
+	}
+	millis, err := packet.ReadVarLong(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取边界缩放时间失败: %w", err)
+	}
+	start := time.Now()
+	return func(b *world.Border) {
+		b.LerpFrom, b.Size = from, to
+		b.LerpStart = start
+		b.LerpDuration = time.Duration(millis) * time.Millisecond
+	}, nil
+}
@@ -0,0 +1,70 @@
+package mcclient
+
+import (
+	"testing"
+	"time"
+
+	"gmcc/internal/config"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/player"
+)
+
+func TestHandleWorldPackets(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+
+	var setTime []byte
+	setTime = append(setTime, packet.EncodeInt64(48000)...)
+	setTime = append(setTime, packet.EncodeInt64(13500)...)
+	setTime = append(setTime, packet.EncodeBool(false)...)
+	if err := c.handleSetTimePacket(setTime); err != nil {
+		t.Fatalf("handleSetTimePacket() error = %v", err)
+	}
+	if tm := c.World.Time(); tm.WorldAge != 48000 || tm.DayTime != 13500 || tm.DaylightCycle {
+		t.Errorf("Time = %+v", tm)
+	}
+
+	gameEvent := func(event byte, value float32) []byte {
+		return append([]byte{event}, packet.EncodeFloat32(value)...)
+	}
+	for _, data := range [][]byte{gameEvent(gameEventStartRaining, 0), gameEvent(gameEventRainLevel, 1), gameEvent(gameEventThunderLevel, 1)} {
+		if err := c.handleGameEventPacket(data); err != nil {
+			t.Fatalf("handleGameEventPacket() error = %v", err)
+		}
+	}
+	if w := c.World.Weather(); !w.Raining || !w.IsThundering() {
+		t.Errorf("Weather = %+v", w)
+	}
+
+	var border []byte
+	border = append(border, packet.EncodeFloat64(100)...)
+	border = append(border, packet.EncodeFloat64(-50)...)
+	border = append(border, packet.EncodeFloat64(1000)...)
+	border = append(border, packet.EncodeFloat64(500)...)
+	border = append(border, packet.EncodeVarLong(60000)...)
+	border = append(border, packet.EncodeVarInt(29999984)...)
+	border = append(border, packet.EncodeVarInt(5)...)
+	border = append(border, packet.EncodeVarInt(15)...)
+	if err := c.handleInitializeBorderPacket(border); err != nil {
+		t.Fatalf("handleInitializeBorderPacket() error = %v", err)
+	}
+	b := c.World.Border()
+	if b.CenterX != 100 || b.CenterZ != -50 || b.LerpFrom != 1000 || b.Size != 500 ||
+		b.LerpDuration != time.Minute || b.WarningBlocks != 5 || b.WarningTime != 15 {
+		t.Errorf("Border = %+v", b)
+	}
+	if err := c.handleBorderSizePacket(packet.EncodeFloat64(64)); err != nil {
+		t.Fatalf("handleBorderSizePacket() error = %v", err)
+	}
+	if size := c.World.Border().CurrentSize(time.Now()); size != 64 {
+		t.Errorf("CurrentSize = %v, want 64", size)
+	}
+
+	if _, ok := c.Latency(); ok {
+		t.Error("Tab 列表中没有自己时不应有延迟")
+	}
+	c.TabList.Update(player.TabAddPlayer|player.TabUpdateLatency, player.TabEntry{UUID: c.uuid, Latency: 42})
+	if latency, ok := c.Latency(); !ok || latency != 42*time.Millisecond {
+		t.Errorf("Latency() = %v, %v", latency, ok)
+	}
+}
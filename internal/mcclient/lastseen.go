@@ -0,0 +1,144 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/mcclient/protocol"
+)
+
+const (
+	// lastSeenCapacity 与原版一样只跟踪最近 20 条签名消息，确认位图为 20 位 (3 字节)
+	lastSeenCapacity = 20
+	// lastSeenAckThreshold 未确认消息超过该数量时单独发送 chat_ack，否则服务端会断开连接
+	lastSeenAckThreshold = 64
+	messageSignatureSize = 256
+)
+
+// lastSeenTracker 对应原版 LastSeenMessagesTracker:
+// 环形缓冲区保存最近看到的签名，offset 为尚未告知服务端的消息数
+type lastSeenTracker struct {
+	mu      sync.Mutex
+	entries [lastSeenCapacity][]byte
+	tail    int
+	offset  int32
+	last    []byte
+}
+
+// lastSeenUpdate 随签名聊天和签名命令发送的 last seen 更新
+type lastSeenUpdate struct {
+	offset       int32
+	acknowledged [(lastSeenCapacity + 7) / 8]byte
+	checksum     byte
+	signatures   [][]byte // 按从旧到新的顺序，参与消息签名
+}
+
+// add 记录一条签名消息，重复的签名被忽略；未显示的消息只计入 offset 不参与确认。
+// 返回当前未确认的消息数
+func (t *lastSeenTracker) add(signature []byte, shown bool) int32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if bytes.Equal(signature, t.last) {
+		return t.offset
+	}
+	t.last = signature
+	t.entries[t.tail] = nil
+	if shown {
+		t.entries[t.tail] = signature
+	}
+	t.tail = (t.tail + 1) % lastSeenCapacity
+	t.offset++
+	return t.offset
+}
+
+// takeOffset 返回并清零未确认的消息数
+func (t *lastSeenTracker) takeOffset() int32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	offset := t.offset
+	t.offset = 0
+	return offset
+}
+
+// update 生成 last seen 更新并清零 offset，生成后必须发送给服务端
+func (t *lastSeenTracker) update() lastSeenUpdate {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	u := lastSeenUpdate{offset: t.offset}
+	t.offset = 0
+	for i := range lastSeenCapacity {
+		sig := t.entries[(t.tail+i)%lastSeenCapacity]
+		if sig == nil {
+			continue
+		}
+		u.acknowledged[i/8] |= 1 << (i % 8)
+		u.signatures = append(u.signatures, sig)
+	}
+	u.checksum = lastSeenChecksum(u.signatures)
+	return u
+}
+
+func (t *lastSeenTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = [lastSeenCapacity][]byte{}
+	t.tail, t.offset, t.last = 0, 0, nil
+}
+
+// encode 编码为 offset(VarInt) + acknowledged(3 字节位图) + checksum(u8)
+func (u *lastSeenUpdate) encode() []byte {
+	out := packet.EncodeVarInt(u.offset)
+	out = append(out, u.acknowledged[:]...)
+	return append(out, u.checksum)
+}
+
+// lastSeenChecksum 与原版 LastSeenMessages.computeChecksum 一致:
+// 对每个签名的 Arrays.hashCode 做 31 倍累加，取低 8 位，0 保留给"不校验"因此替换为 1
+func lastSeenChecksum(signatures [][]byte) byte {
+	h := int32(1)
+	for _, sig := range signatures {
+		sh := int32(1)
+		for _, b := range sig {
+			sh = 31*sh + int32(int8(b))
+		}
+		h = 31*h + sh
+	}
+	if b := byte(h); b != 0 {
+		return b
+	}
+	return 1
+}
+
+// markMessageSeen 记录收到的签名消息，未确认消息过多时发送 chat_ack
+func (c *Client) markMessageSeen(signature []byte, shown bool) {
+	if len(signature) != messageSignatureSize {
+		return
+	}
+	if c.lastSeen.add(signature, shown) <= lastSeenAckThreshold {
+		return
+	}
+	if err := c.SendChatAck(); err != nil {
+		logx.Debugf("发送 chat_ack 失败: %v", err)
+	}
+}
+
+// SendChatAck 告知服务端已看到的签名消息数量
+func (c *Client) SendChatAck() error {
+	if c.state != protocol.StatePlay {
+		return fmt.Errorf("当前状态不是 Play，无法发送聊天确认数据包")
+	}
+	if c.conn == nil {
+		return fmt.Errorf("连接未初始化")
+	}
+	c.chatSignMu.Lock()
+	defer c.chatSignMu.Unlock()
+	offset := c.lastSeen.takeOffset()
+	if offset <= 0 {
+		return nil
+	}
+	return c.conn.WritePacket(protocol.PlayServerMsgAck, packet.EncodeVarInt(offset))
+}
@@ -0,0 +1,88 @@
+package mcclient
+
+import (
+	"bytes"
+	"testing"
+
+	"gmcc/internal/mcclient/packet"
+)
+
+func testSignature(b byte) []byte {
+	return bytes.Repeat([]byte{b}, messageSignatureSize)
+}
+
+func TestLastSeenTracker(t *testing.T) {
+	var tr lastSeenTracker
+
+	empty := tr.update()
+	if want := append(packet.EncodeVarInt(0), 0, 0, 0, 1); !bytes.Equal(empty.encode(), want) {
+		t.Errorf("空更新 = %v, want %v", empty.encode(), want)
+	}
+
+	if n := tr.add(testSignature(1), true); n != 1 {
+		t.Errorf("add() = %d, want 1", n)
+	}
+	// 连续重复的签名只记录一次
+	if n := tr.add(testSignature(1), true); n != 1 {
+		t.Errorf("重复 add() = %d, want 1", n)
+	}
+	tr.add(testSignature(0xff), true)
+
+	u := tr.update()
+	if u.offset != 2 || len(u.signatures) != 2 || u.signatures[0][0] != 1 || u.signatures[1][0] != 0xff {
+		t.Fatalf("update() = offset %d, %d 个签名", u.offset, len(u.signatures))
+	}
+	// 两条签名位于环形缓冲区末尾两格
+	if u.acknowledged != [3]byte{0, 0, 0x0c} {
+		t.Errorf("acknowledged = %08b", u.acknowledged)
+	}
+	if u.checksum != 225 {
+		t.Errorf("checksum = %d, want 225", u.checksum)
+	}
+
+	// 再次生成时 offset 已清零，但签名仍被跟踪
+	if again := tr.update(); again.offset != 0 || len(again.signatures) != 2 {
+		t.Errorf("再次 update() = offset %d, %d 个签名", again.offset, len(again.signatures))
+	}
+
+	for i := range lastSeenCapacity + 5 {
+		tr.add(testSignature(byte(10+i)), true)
+	}
+	u = tr.update()
+	if u.offset != lastSeenCapacity+5 || len(u.signatures) != lastSeenCapacity {
+		t.Fatalf("环绕后 offset %d, %d 个签名", u.offset, len(u.signatures))
+	}
+	if u.signatures[0][0] != 15 || u.signatures[lastSeenCapacity-1][0] != 34 {
+		t.Errorf("环绕后签名顺序错误: 首 %d 尾 %d", u.signatures[0][0], u.signatures[lastSeenCapacity-1][0])
+	}
+	if u.acknowledged != [3]byte{0xff, 0xff, 0x0f} {
+		t.Errorf("acknowledged = %08b", u.acknowledged)
+	}
+
+	// 未显示的消息只计入 offset，不在确认位图中
+	tr.add(testSignature(0x50), false)
+	u = tr.update()
+	if u.offset != 1 || len(u.signatures) != lastSeenCapacity-1 || u.acknowledged != [3]byte{0xff, 0xff, 0x07} {
+		t.Errorf("未显示消息后 offset %d, %d 个签名, acknowledged = %08b", u.offset, len(u.signatures), u.acknowledged)
+	}
+
+	tr.reset()
+	if u := tr.update(); u.offset != 0 || len(u.signatures) != 0 || u.checksum != 1 {
+		t.Errorf("reset 后 update() = %+v", u)
+	}
+}
+
+func TestMarkMessageSeenThreshold(t *testing.T) {
+	c := &Client{}
+	for i := range lastSeenAckThreshold + 1 {
+		c.markMessageSeen(testSignature(byte(i)), true)
+	}
+	// 未进入 Play 时无法发送 chat_ack，未确认的数量保留到下一条消息
+	if n := c.lastSeen.takeOffset(); n != lastSeenAckThreshold+1 {
+		t.Errorf("offset = %d, want %d", n, lastSeenAckThreshold+1)
+	}
+	c.markMessageSeen(nil, true)
+	if n := c.lastSeen.takeOffset(); n != 0 {
+		t.Errorf("无签名消息不应计数, offset = %d", n)
+	}
+}
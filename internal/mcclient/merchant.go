@@ -0,0 +1,305 @@
+package mcclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"gmcc/internal/entity"
+	"gmcc/internal/item"
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/mcclient/protocol"
+	"gmcc/internal/player"
+)
+
+// merchantOpenTimeout 右键商人后等待 merchant_offers 的时间
+const merchantOpenTimeout = 3 * time.Second
+
+// MerchantEvent 服务端发送了交易列表 (打开交易界面或交易后刷新)
+type MerchantEvent struct {
+	Merchant *player.Merchant
+}
+
+// SetMerchantHandler 设置交易列表回调
+func (c *Client) SetMerchantHandler(handler func(MerchantEvent)) {
+	c.merchantHandler = handler
+}
+
+// Merchant 返回当前打开的交易界面的副本，窗口已关闭时返回 false
+func (c *Client) Merchant() (*player.Merchant, bool) {
+	c.merchantMu.Lock()
+	m := c.merchant
+	c.merchantMu.Unlock()
+	if m == nil {
+		return nil, false
+	}
+	open := c.Player.GetOpenContainer()
+	if open == nil || open.WindowID != m.WindowID || open.WindowType != ContainerTypeMerchant {
+		return nil, false
+	}
+	cp := *m
+	cp.Offers = slices.Clone(m.Offers)
+	return &cp, true
+}
+
+// NearbyMerchants 返回交互距离内的村民和流浪商人，按距离排序
+func (c *Client) NearbyMerchants() []*entity.Entity {
+	if c.entityTracker == nil {
+		return nil
+	}
+	eye := c.eyePosition()
+	reach := c.attackReach()
+	villager, trader := entity.OfType("villager"), entity.OfType("wandering_trader")
+	found := c.entityTracker.WithinRadius(eye, reach+1, func(e *entity.Entity) bool {
+		return !e.IsDead() && (villager(e) || trader(e)) && e.BoundingBox().DistanceSqTo(eye) <= reach*reach
+	})
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].BoundingBox().DistanceSqTo(eye) < found[j].BoundingBox().DistanceSqTo(eye)
+	})
+	return found
+}
+
+// OpenMerchant 转向并右键商人，等待交易列表
+func (c *Client) OpenMerchant(entityID int32) (*player.Merchant, error) {
+	if c.entityTracker == nil {
+		return nil, fmt.Errorf("实体跟踪器未初始化")
+	}
+	e, ok := c.entityTracker.Get(entityID)
+	if !ok {
+		return nil, fmt.Errorf("实体 %d 不存在", entityID)
+	}
+	box := e.BoundingBox()
+	eye := c.eyePosition()
+	reach := c.attackReach()
+	if d := box.DistanceSqTo(eye); d > reach*reach {
+		return nil, fmt.Errorf("商人超出交互距离 (%.1f > %.1f 格)", math.Sqrt(d), reach)
+	}
+
+	yaw, pitch := lookAngles(eye, box.Center())
+	c.Player.SetRotation(yaw, pitch)
+	_, _, _, _, _, onGround := c.Player.GetMovementState()
+	if err := c.SendPlayerRotation(yaw, pitch, onGround); err != nil {
+		return nil, err
+	}
+	if err := c.SendInteract(entityID, protocol.InteractActionInteract, protocol.HandMainHand, false); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(merchantOpenTimeout)
+	for time.Now().Before(deadline) {
+		if m, ok := c.Merchant(); ok {
+			return m, nil
+		}
+		time.Sleep(craftPollInterval)
+	}
+	return nil, fmt.Errorf("等待交易界面超时 (商人可能没有职业或正在与其他玩家交易)")
+}
+
+// SelectTrade 选择交易，服务端会把背包中的材料放入支付槽
+func (c *Client) SelectTrade(index int) error {
+	if c.state != protocol.StatePlay {
+		return fmt.Errorf("当前状态不是 Play，无法发送选择交易数据包")
+	}
+	if c.conn == nil {
+		return fmt.Errorf("连接未初始化")
+	}
+	m, ok := c.Merchant()
+	if !ok {
+		return fmt.Errorf("没有打开的交易界面")
+	}
+	if index < 0 || index >= len(m.Offers) {
+		return fmt.Errorf("交易序号超出范围: %d (共 %d 项)", index, len(m.Offers))
+	}
+	return c.conn.WritePacket(protocol.PlayServerSelectTrade, packet.EncodeVarInt(int32(index)))
+}
+
+// Trade 执行第 index 项交易 times 次 (times<=0 表示直到缺货或材料不足)，
+// 每次从结果槽取出物品放入背包，返回实际交易次数
+func (c *Client) Trade(index, times int) (int, error) {
+	if !c.cfg.Packets.HandleContainer {
+		return 0, fmt.Errorf("未启用容器数据包处理，无法交易")
+	}
+
+	c.clickMu.Lock()
+	defer c.clickMu.Unlock()
+
+	if err := c.checkClickReady(); err != nil {
+		return 0, err
+	}
+	m, ok := c.Merchant()
+	if !ok {
+		return 0, fmt.Errorf("没有打开的交易界面")
+	}
+	if index < 0 || index >= len(m.Offers) {
+		return 0, fmt.Errorf("交易序号超出范围: %d (共 %d 项)", index, len(m.Offers))
+	}
+	offer := m.Offers[index]
+	if offer.OutOfStock() {
+		return 0, fmt.Errorf("该交易已缺货")
+	}
+	if !c.Player.GetCarried().IsEmpty() {
+		return 0, fmt.Errorf("光标上已有物品，无法交易")
+	}
+
+	limit := int(offer.Remaining())
+	if times > 0 {
+		limit = min(limit, times)
+	}
+	traded := 0
+	for traded < limit {
+		ready := func(s *player.ContainerState) bool {
+			result := s.Slot(player.SlotMerchantResult)
+			return !result.IsEmpty() && result.ID == offer.Result.ID
+		}
+		if !ready(c.Player.GetActiveContainer()) {
+			if err := c.SelectTrade(index); err != nil {
+				return traded, err
+			}
+			// 支付槽材料不足时服务端不会给出结果
+			if !c.waitContainer(ready) {
+				break
+			}
+		}
+		if err := c.clickLocked(player.SlotMerchantResult, 0, player.ClickPickup); err != nil {
+			return traded, err
+		}
+		traded++
+		c.useMerchantOffer(m.WindowID, index)
+		if err := c.storeCarried(); err != nil {
+			return traded, err
+		}
+	}
+
+	if traded == 0 {
+		return 0, fmt.Errorf("交易失败: 材料不足或服务端未给出结果")
+	}
+	logx.Infof("交易 %s 完成 %d 次", offer.Result.DisplayName(), traded)
+	return traded, nil
+}
+
+// storeCarried 把光标上的物品放回玩家背包，优先合并到同类物品
+func (c *Client) storeCarried() error {
+	for range 36 {
+		carried := c.Player.GetCarried()
+		if carried.IsEmpty() {
+			return nil
+		}
+		state := c.Player.GetActiveContainer()
+		target := -1
+		for i := state.PlayerSlotOffset(); i < len(state.Slots); i++ {
+			if s := state.Slot(i); !s.IsEmpty() && s.SameItem(carried) && s.Count < s.MaxStackSize() {
+				target = i
+				break
+			}
+		}
+		if target < 0 {
+			target = c.findEmptyPlayerSlot()
+		}
+		if target < 0 {
+			return fmt.Errorf("背包已满")
+		}
+		if err := c.clickLocked(int16(target), 0, player.ClickPickup); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("无法放回光标上的物品")
+}
+
+// useMerchantOffer 与原版客户端一样在本地增加交易次数，服务端只在补货时重新发送列表
+func (c *Client) useMerchantOffer(windowID int32, index int) {
+	c.merchantMu.Lock()
+	defer c.merchantMu.Unlock()
+	if c.merchant != nil && c.merchant.WindowID == windowID && index < len(c.merchant.Offers) {
+		c.merchant.Offers[index].Uses++
+	}
+}
+
+func (c *Client) handleMerchantOffersPacket(data []byte) error {
+	m, err := decodeMerchantOffers(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("解析 merchant_offers 失败: %w", err)
+	}
+
+	c.merchantMu.Lock()
+	c.merchant = m
+	c.merchantMu.Unlock()
+
+	logx.Infof("merchant_offers: window=%d, 交易 %d 项, 等级 %d", m.WindowID, len(m.Offers), m.Level)
+	for i := range m.Offers {
+		logx.Debugf("  [%d] %s", i+1, &m.Offers[i])
+	}
+	if c.merchantHandler != nil {
+		c.merchantHandler(MerchantEvent{Merchant: m})
+	}
+	return nil
+}
+
+// decodeMerchantOffers 解析 merchant_offers:
+// windowID, 交易列表 (价格A, 结果, 可选价格B, 缺货, 次数, 最大次数, 经验, 特价, 价格系数, 需求), 等级, 经验, 是否村民, 能否补货
+func decodeMerchantOffers(r *bytes.Reader) (*player.Merchant, error) {
+	windowID, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	n, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || int(n) > r.Len() {
+		return nil, fmt.Errorf("交易数量无效: %d", n)
+	}
+
+	m := &player.Merchant{WindowID: windowID, Offers: make([]player.MerchantOffer, 0, n)}
+	for i := int32(0); i < n; i++ {
+		var o player.MerchantOffer
+		if o.CostA, err = item.ReadItemCost(r); err != nil {
+			return nil, fmt.Errorf("交易 %d 价格A: %w", i, err)
+		}
+		if o.Result, err = item.ReadItemStack(r); err != nil {
+			return nil, fmt.Errorf("交易 %d 结果: %w", i, err)
+		}
+		hasB, err := packet.ReadBoolFromReader(r)
+		if err != nil {
+			return nil, err
+		}
+		if hasB {
+			if o.CostB, err = item.ReadItemCost(r); err != nil {
+				return nil, fmt.Errorf("交易 %d 价格B: %w", i, err)
+			}
+		}
+		var tail struct {
+			Disabled        bool
+			Uses            int32
+			MaxUses         int32
+			XP              int32
+			SpecialPrice    int32
+			PriceMultiplier float32
+			Demand          int32
+		}
+		if err := binary.Read(r, binary.BigEndian, &tail); err != nil {
+			return nil, fmt.Errorf("交易 %d: %w", i, err)
+		}
+		o.Disabled, o.Uses, o.MaxUses, o.XP = tail.Disabled, tail.Uses, tail.MaxUses, tail.XP
+		o.SpecialPrice, o.PriceMultiplier, o.Demand = tail.SpecialPrice, tail.PriceMultiplier, tail.Demand
+		m.Offers = append(m.Offers, o)
+	}
+
+	if m.Level, err = packet.ReadVarIntFromReader(r); err != nil {
+		return nil, err
+	}
+	if m.XP, err = packet.ReadVarIntFromReader(r); err != nil {
+		return nil, err
+	}
+	if m.Regular, err = packet.ReadBoolFromReader(r); err != nil {
+		return nil, err
+	}
+	if m.CanRestock, err = packet.ReadBoolFromReader(r); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,94 @@
+package mcclient
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"gmcc/internal/config"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/player"
+)
+
+// encodeOffer 编码一项交易，costB 为 0 表示没有第二种物品
+func encodeOffer(costA, countA, result, countResult, costB, countB int32, uses, maxUses int32) []byte {
+	var data []byte
+	data = append(data, packet.EncodeVarInt(costA)...)
+	data = append(data, packet.EncodeVarInt(countA)...)
+	data = append(data, packet.EncodeVarInt(0)...) // 无组件要求
+	data = append(data, packet.EncodeVarInt(countResult)...)
+	data = append(data, packet.EncodeVarInt(result)...)
+	data = append(data, 0, 0) // 无组件
+	data = append(data, packet.EncodeBool(costB != 0)...)
+	if costB != 0 {
+		data = append(data, packet.EncodeVarInt(costB)...)
+		data = append(data, packet.EncodeVarInt(countB)...)
+		data = append(data, packet.EncodeVarInt(0)...)
+	}
+	data = append(data, packet.EncodeBool(false)...)
+	for _, v := range []int32{uses, maxUses, 2, -3} { // 次数, 最大次数, 经验, 特价
+		data = append(data, packet.EncodeInt32(v)...)
+	}
+	data = binary.BigEndian.AppendUint32(data, math.Float32bits(0.05))
+	data = append(data, packet.EncodeInt32(4)...)
+	return data
+}
+
+func TestHandleMerchantOffersPacket(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	var events []MerchantEvent
+	c.SetMerchantHandler(func(e MerchantEvent) { events = append(events, e) })
+
+	data := packet.EncodeVarInt(5)
+	data = append(data, packet.EncodeVarInt(2)...)
+	data = append(data, encodeOffer(100, 20, 200, 1, 0, 0, 3, 16)...)
+	data = append(data, encodeOffer(200, 5, 300, 1, 400, 1, 0, 12)...)
+	data = append(data, packet.EncodeVarInt(2)...)  // 等级
+	data = append(data, packet.EncodeVarInt(15)...) // 经验
+	data = append(data, packet.EncodeBool(true)...)
+	data = append(data, packet.EncodeBool(true)...)
+
+	if err := c.handleMerchantOffersPacket(data); err != nil {
+		t.Fatalf("handleMerchantOffersPacket() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("events = %+v", events)
+	}
+	m := events[0].Merchant
+	if m.WindowID != 5 || m.Level != 2 || m.XP != 15 || !m.Regular || !m.CanRestock || len(m.Offers) != 2 {
+		t.Fatalf("Merchant = %+v", m)
+	}
+	o := m.Offers[0]
+	if o.CostA.ID != 100 || o.CostA.Count != 20 || o.Result.ID != 200 || o.CostB != nil ||
+		o.Uses != 3 || o.MaxUses != 16 || o.XP != 2 || o.SpecialPrice != -3 || o.PriceMultiplier != 0.05 || o.Demand != 4 {
+		t.Errorf("Offers[0] = %+v", o)
+	}
+	if o := m.Offers[1]; o.CostB == nil || o.CostB.ID != 400 || o.CostB.Count != 1 {
+		t.Errorf("Offers[1] = %+v", o)
+	}
+
+	// 只有交易窗口打开时才返回
+	if _, ok := c.Merchant(); ok {
+		t.Error("交易窗口未打开时 Merchant() 应返回 false")
+	}
+	c.Player.SetOpenContainer(&player.ContainerState{WindowID: 5, WindowType: ContainerTypeMerchant, Open: true})
+	got, ok := c.Merchant()
+	if !ok || len(got.Offers) != 2 {
+		t.Fatalf("Merchant() = %+v, %v", got, ok)
+	}
+	c.useMerchantOffer(5, 0)
+	if got.Offers[0].Uses != 3 {
+		t.Error("Merchant() 应返回副本")
+	}
+	if got, _ := c.Merchant(); got.Offers[0].Uses != 4 {
+		t.Errorf("交易后 Uses = %d, want 4", got.Offers[0].Uses)
+	}
+
+	if err := c.SelectTrade(0); err == nil {
+		t.Error("未连接时 SelectTrade() 应返回错误")
+	}
+	if err := c.handleMerchantOffersPacket(data[:len(data)-3]); err == nil {
+		t.Error("截断的数据应返回错误")
+	}
+}
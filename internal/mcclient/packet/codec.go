@@ -214,6 +214,36 @@ func EncodeVarInt(v int32) []byte {
 	}
 }
 
+func ReadVarLong(r io.ByteReader) (int64, error) {
+	var result int64
+	var shift uint
+	for i := 0; i < 10; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		result |= int64(b&0x7F) << shift
+		if b&0x80 == 0 {
+			return result, nil
+		}
+		shift += 7
+	}
+	return 0, fmt.Errorf("varlong too large")
+}
+
+func EncodeVarLong(v int64) []byte {
+	x := uint64(v)
+	out := make([]byte, 0, 10)
+	for {
+		if x&^uint64(0x7F) == 0 {
+			out = append(out, byte(x))
+			return out
+		}
+		out = append(out, byte((x&0x7F)|0x80))
+		x >>= 7
+	}
+}
+
 func ReadBool(r io.Reader) (bool, error) {
 	var b [1]byte
 	if _, err := io.ReadFull(r, b[:]); err != nil {
@@ -282,6 +312,16 @@ func ReadInt64(r io.Reader) (int64, error) {
 	return v, nil
 }
 
+// DecodeBlockPos 解压方块坐标 (x 26 位、z 26 位、y 12 位)
+func DecodeBlockPos(v int64) (x, y, z int32) {
+	return int32(v >> 38), int32(v << 52 >> 52), int32(v << 26 >> 38)
+}
+
+// EncodeBlockPos 压缩方块坐标，DecodeBlockPos 的逆操作
+func EncodeBlockPos(x, y, z int32) []byte {
+	return EncodeInt64(int64(x&0x3FFFFFF)<<38 | int64(z&0x3FFFFFF)<<12 | int64(y&0xFFF))
+}
+
 func EncodeInt64(v int64) []byte {
 	var b [8]byte
 	binary.BigEndian.PutUint64(b[:], uint64(v))
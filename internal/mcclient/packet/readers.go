@@ -13,12 +13,6 @@ import (
 	"gmcc/internal/nbt"
 )
 
-// SlotData 兼容类型定义（避免循环依赖）
-type SlotData struct {
-	ID    int32
-	Count int32
-}
-
 // MustReadBytes 读取字节，错误不终止但记录日志
 // 用于解析非关键数据时忽略错误
 func MustReadBytes(r io.Reader, n int, name string) []byte {
@@ -159,34 +153,8 @@ func ReadBytes(r io.Reader, n int) ([]byte, error) {
 	return b, nil
 }
 
-// ReadSlotData 读取物品槽数据 (兼容旧接口)
-// 注意: 新代码应直接使用 internal/item.ReadSlotData 获取完整组件信息
-func ReadSlotData(r *bytes.Reader) (*SlotData, error) {
-	// 1. item_count (VarInt)
-	count, err := ReadVarIntFromReader(r)
-	if err != nil {
-		return nil, err
-	}
-	if count == 0 {
-		return nil, nil // 空物品
-	}
-
-	// 2. item_id (VarInt)
-	itemID, err := ReadVarIntFromReader(r)
-	if err != nil {
-		return nil, err
-	}
-
-	// 3. 跳过组件 (使用新的组件系统)
-	if err := skipSlotComponents(r); err != nil {
-		logx.Warnf("Slot解析失败: itemID=%d, count=%d, err=%v", itemID, count, err)
-		return nil, err
-	}
-
-	return &SlotData{ID: itemID, Count: count}, nil
-}
-
-func skipSlotData(r *bytes.Reader) error {
+// SkipSlot 跳过一个完整的物品槽 (Slot) 数据
+func SkipSlot(r *bytes.Reader) error {
 	count, err := ReadVarIntFromReader(r)
 	if err != nil {
 		return err
@@ -220,7 +188,7 @@ func skipSlotComponents(r *bytes.Reader) error {
 		if err != nil {
 			return fmt.Errorf("read component type at index %d: %w", i, err)
 		}
-		if err := skipComponentByType(r, componentType); err != nil {
+		if err := SkipComponent(r, componentType); err != nil {
 			return fmt.Errorf("skip component type %d at index %d: %w", componentType, i, err)
 		}
 	}
@@ -234,8 +202,8 @@ func skipSlotComponents(r *bytes.Reader) error {
 	return nil
 }
 
-// skipComponentByType 根据组件类型跳过数据 (参考 1.21.11 数据组件规范)
-func skipComponentByType(r *bytes.Reader, componentType int32) error {
+// SkipComponent 根据组件类型跳过数据 (参考 1.21.11 数据组件规范)
+func SkipComponent(r *bytes.Reader, componentType int32) error {
 	switch componentType {
 	case 0, 6, 9, 45, 55, 57, 64, 76, 77:
 		return SkipNBT(r)
@@ -269,10 +237,12 @@ func skipComponentByType(r *bytes.Reader, componentType int32) error {
 		return err
 	case 23:
 		return skipFoodComponent(r)
-	case 24, 36:
+	case 24:
 		return skipConsumeEffectsComponent(r)
+	case 36:
+		return skipDeathProtection(r)
 	case 25:
-		return skipSlotData(r)
+		return SkipSlot(r)
 	case 26:
 		return skipUseCooldown(r)
 	case 28:
@@ -324,7 +294,7 @@ func skipComponentByType(r *bytes.Reader, componentType int32) error {
 			return err
 		}
 		return SkipNBT(r)
-	case 59:
+	case 8, 59:
 		return skipHolderOrStringApprox(r)
 	case 60:
 		return skipHolderOrStringApprox(r)
@@ -361,6 +331,10 @@ func skipComponentByType(r *bytes.Reader, componentType int32) error {
 			}
 		}
 		return nil
+	case 72:
+		return skipVarIntList(r)
+	case 74:
+		return skipStringPairs(r)
 	case 75:
 		return skipBeesComponent(r)
 	case 78:
@@ -486,6 +460,19 @@ func skipConsumeEffectsComponent(r *bytes.Reader) error {
 	return nil
 }
 
+func skipDeathProtection(r *bytes.Reader) error {
+	count, err := ReadVarIntFromReader(r)
+	if err != nil {
+		return err
+	}
+	for i := int32(0); i < count; i++ {
+		if err := skipItemConsumeEffect(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func skipUseCooldown(r *bytes.Reader) error {
 	if _, err := ReadFloat32FromReader(r); err != nil {
 		return err
@@ -777,6 +764,9 @@ func skipBeesComponent(r *bytes.Reader) error {
 		return err
 	}
 	for i := int32(0); i < count; i++ {
+		if _, err := ReadVarIntFromReader(r); err != nil {
+			return err
+		}
 		if err := SkipNBT(r); err != nil {
 			return err
 		}
@@ -838,7 +828,7 @@ func skipItemBlockPredicates(r *bytes.Reader) error {
 			if err != nil {
 				return err
 			}
-			if err := skipComponentByType(r, componentType); err != nil {
+			if err := SkipComponent(r, componentType); err != nil {
 				return err
 			}
 		}
@@ -949,8 +939,15 @@ func skipItemConsumeEffect(r *bytes.Reader) error {
 }
 
 func skipSoundHolderApprox(r *bytes.Reader) error {
-	_, err := ReadVarIntFromReader(r)
-	return err
+	id, err := ReadVarIntFromReader(r)
+	if err != nil || id != 0 {
+		return err
+	}
+	// 0 表示内联声音事件: 标识符 + 可选的固定范围
+	if _, err := ReadStringFromReader(r); err != nil {
+		return err
+	}
+	return skipOptionalFloat32(r)
 }
 
 func skipOptionalSoundHolderApprox(r *bytes.Reader) error {
@@ -1230,7 +1227,7 @@ func skipSlotList(r *bytes.Reader) error {
 		return err
 	}
 	for i := int32(0); i < count; i++ {
-		if err := skipSlotData(r); err != nil {
+		if err := SkipSlot(r); err != nil {
 			return err
 		}
 	}
@@ -1285,6 +1282,35 @@ func skipStringList(r *bytes.Reader) error {
 	return nil
 }
 
+func skipVarIntList(r *bytes.Reader) error {
+	count, err := ReadVarIntFromReader(r)
+	if err != nil {
+		return err
+	}
+	for i := int32(0); i < count; i++ {
+		if _, err := ReadVarIntFromReader(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func skipStringPairs(r *bytes.Reader) error {
+	count, err := ReadVarIntFromReader(r)
+	if err != nil {
+		return err
+	}
+	for i := int32(0); i < count; i++ {
+		if _, err := ReadStringFromReader(r); err != nil {
+			return err
+		}
+		if _, err := ReadStringFromReader(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func skipInt32List(r *bytes.Reader) error {
 	count, err := ReadVarIntFromReader(r)
 	if err != nil {
@@ -1331,3 +1357,16 @@ func ReadAnonymousNBTJSON(r io.Reader) (string, error) {
 	}
 	return string(raw), nil
 }
+
+// EncodeAnonymousNBT 编码 Network NBT (根标签没有名称)，nil 编码为 TAG_End
+func EncodeAnonymousNBT(v any) ([]byte, error) {
+	if v == nil {
+		return []byte{nbt.TagEnd}, nil
+	}
+	data, err := nbt.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	// 去掉根标签的空名称 (u16 长度 0)
+	return append(data[:1:1], data[3:]...), nil
+}
@@ -36,8 +36,11 @@ const (
 	CfgClientRegistry      int32 = 0x07
 	CfgClientPackPop       int32 = 0x08
 	CfgClientPackPush      int32 = 0x09
+	CfgClientUpdateTags    int32 = 0x0D
 	CfgClientSelectPacks   int32 = 0x0E
 	CfgClientCodeOfConduct int32 = 0x13
+	CfgClientClearDialog   int32 = 0x11
+	CfgClientShowDialog    int32 = 0x12
 
 	CfgServerClientInfo  int32 = 0x00
 	CfgServerCookieResp  int32 = 0x01
@@ -48,6 +51,7 @@ const (
 	CfgServerResource    int32 = 0x06
 	CfgServerSelectPacks int32 = 0x07
 	CfgServerAcceptCode  int32 = 0x09
+	CfgServerCustomClick int32 = 0x08 // custom_click_action
 )
 
 // Play state packet IDs (protocol 774)
@@ -57,12 +61,18 @@ const (
 	PlayClientCookieReq        int32 = 0x15
 	PlayClientDisconnect       int32 = 0x20
 	PlayClientProfilelessChat  int32 = 0x21
-	PlayClientMoveEntityPos    int32 = 0x09 // move_entity_pos
+	PlayClientMoveEntityPos    int32 = 0x33 // move_entity_pos
+	PlayClientMoveEntityPosRot int32 = 0x34 // move_entity_pos_rot
+	PlayClientMoveEntityRot    int32 = 0x36 // move_entity_rot
+	PlayClientRotateHead       int32 = 0x51 // rotate_head
+	PlayClientSetEntityMotion  int32 = 0x63 // set_entity_motion
+	PlayClientEntityPosSync    int32 = 0x23 // entity_position_sync
+	PlayClientSetPassengers    int32 = 0x69 // set_passengers
 	PlayClientKeepAlive        int32 = 0x2B
 	PlayClientLogin            int32 = 0x30
 	PlayClientPlayerChat       int32 = 0x3F
 	PlayClientPing             int32 = 0x3B
-	PlayClientTeleportEntity   int32 = 0x48 // teleport_entity
+	PlayClientTeleportEntity   int32 = 0x7B // teleport_entity
 	PlayClientPosition         int32 = 0x46
 	PlayClientPackPop          int32 = 0x4E
 	PlayClientPackPush         int32 = 0x4F
@@ -70,6 +80,36 @@ const (
 	PlayClientActionBar        int32 = 0x55
 	PlayClientSystemChat       int32 = 0x77
 	PlayClientSetHealth        int32 = 0x66
+	PlayClientPlayerCombatKill int32 = 0x42 // player_combat_kill - 玩家死亡
+	PlayClientRespawn          int32 = 0x50 // respawn - 重生或切换维度
+	PlayClientStartConfig      int32 = 0x74 // start_configuration - 代理切换后端时重新配置
+	PlayClientTabList          int32 = 0x78 // tab_list - Tab 页眉页脚
+	PlayClientSetObjective     int32 = 0x68 // set_objective - 计分目标
+	PlayClientSetScore         int32 = 0x6C // set_score
+	PlayClientResetScore       int32 = 0x4D // reset_score
+	PlayClientSetDisplayObj    int32 = 0x60 // set_display_objective - 计分目标显示位置
+	PlayClientSetPlayerTeam    int32 = 0x6B // set_player_team - 队伍
+	PlayClientBossEvent        int32 = 0x09 // boss_event - Boss 栏
+	PlayClientSetTitleText     int32 = 0x70 // set_title_text - 标题
+	PlayClientSetSubtitleText  int32 = 0x6E // set_subtitle_text - 副标题
+	PlayClientSetTitlesAnim    int32 = 0x71 // set_titles_animation - 标题淡入/停留/淡出时长
+	PlayClientClearTitles      int32 = 0x0E // clear_titles
+	PlayClientSetTime          int32 = 0x6F // set_time - 世界时间
+	PlayClientInitBorder       int32 = 0x2A // initialize_border
+	PlayClientBorderCenter     int32 = 0x56 // set_border_center
+	PlayClientBorderLerpSize   int32 = 0x57 // set_border_lerp_size
+	PlayClientBorderSize       int32 = 0x58 // set_border_size
+	PlayClientBorderWarnDelay  int32 = 0x59 // set_border_warning_delay
+	PlayClientBorderWarnDist   int32 = 0x5A // set_border_warning_distance
+	PlayClientMapItemData      int32 = 0x31 // map_item_data - 地图内容
+	PlayClientOpenBook         int32 = 0x38 // open_book - 打开手中的成书
+	PlayClientOpenSignEditor   int32 = 0x3A // open_sign_editor - 打开告示牌编辑界面
+	PlayClientBlockEntityData  int32 = 0x06 // block_entity_data
+	PlayClientLevelChunk       int32 = 0x2C // level_chunk_with_light - 只读取方块实体
+	PlayClientForgetChunk      int32 = 0x25 // forget_level_chunk
+	PlayClientClearDialog      int32 = 0x89 // clear_dialog
+	PlayClientShowDialog       int32 = 0x8A // show_dialog
+	PlayClientMerchantOffers   int32 = 0x32 // merchant_offers - 村民交易列表
 	PlayClientSetExperience    int32 = 0x65
 	PlayClientPlayerInfoUpdate int32 = 0x44
 	PlayClientPlayerInfoRemove int32 = 0x43
@@ -83,6 +123,13 @@ const (
 	PlayClientEntityData       int32 = 0x61
 	PlayClientGameEvent        int32 = 0x26
 	PlayClientClientCommand    int32 = 0x0B
+	PlayClientSetCursorItem    int32 = 0x5E // set_cursor_item
+	PlayClientSetPlayerInv     int32 = 0x6A // set_player_inventory
+	PlayClientRecipeBookAdd    int32 = 0x48 // recipe_book_add
+	PlayClientRecipeBookRemove int32 = 0x49 // recipe_book_remove
+	PlayClientRecipeBookSet    int32 = 0x4A // recipe_book_settings
+	PlayClientUpdateRecipes    int32 = 0x83 // update_recipes
+	PlayClientUpdateTags       int32 = 0x84 // update_tags
 
 	PlayServerMsgAck           int32 = 0x05
 	PlayServerChatCommand      int32 = 0x06
@@ -92,9 +139,11 @@ const (
 	PlayServerClientTickEnd    int32 = 0x0C
 	PlayServerAcceptTeleport   int32 = 0x00
 	PlayServerClientInfo       int32 = 0x0D
-	PlayServerContainerClose   int32 = 0x0D
-	PlayServerContainerClick   int32 = 0x0E
+	PlayServerConfigAck        int32 = 0x0F // configuration_acknowledged
+	PlayServerContainerClick   int32 = 0x11 // container_click
+	PlayServerContainerClose   int32 = 0x12 // container_close
 	PlayServerCookieResp       int32 = 0x14
+	PlayServerPlaceRecipe      int32 = 0x26 // place_recipe
 	PlayServerKeepAlive        int32 = 0x1B
 	PlayServerMoveStatus       int32 = 0x20
 	PlayServerMovePlayerPos    int32 = 0x1D // move_player_pos
@@ -104,6 +153,18 @@ const (
 	PlayServerResource         int32 = 0x30
 	PlayServerInteract         int32 = 0x19 // 0x19 (25) - interact - 右键点击实体
 	PlayServerSetCarriedItem   int32 = 0x34 // 0x34 (52) - set_carried_item - 切换快捷栏
+	PlayServerSwing            int32 = 0x3C // swing - 挥动手臂
+	PlayServerPlayerAction     int32 = 0x28 // player_action - 挖掘/松开使用键等
+	PlayServerUseItem          int32 = 0x40 // use_item - 右键使用手中物品
+	PlayServerEditBook         int32 = 0x17 // edit_book - 编辑/署名书与笔
+	PlayServerSignUpdate       int32 = 0x3B // sign_update - 提交告示牌内容
+	PlayServerCustomClick      int32 = 0x41 // custom_click_action - 对话框按钮
+	PlayServerSelectTrade      int32 = 0x32 // select_trade - 选择交易
+)
+
+// Player action types (player_action)
+const (
+	PlayerActionReleaseUseItem int32 = 5 // 松开使用键 (停止进食/拉弓)
 )
 
 // Interact action types
@@ -165,8 +226,11 @@ var CfgClientPacketNames = map[int32]string{
 	CfgClientRegistry:      "registry_data",
 	CfgClientPackPop:       "resource_pack_pop",
 	CfgClientPackPush:      "resource_pack_push",
+	CfgClientUpdateTags:    "update_tags",
 	CfgClientSelectPacks:   "select_known_packs",
 	CfgClientCodeOfConduct: "custom_report_details",
+	CfgClientClearDialog:   "clear_dialog",
+	CfgClientShowDialog:    "show_dialog",
 }
 
 var PlayClientPacketNames = map[int32]string{
@@ -176,6 +240,12 @@ var PlayClientPacketNames = map[int32]string{
 	PlayClientDisconnect:       "disconnect",
 	PlayClientProfilelessChat:  "profileless_chat",
 	PlayClientMoveEntityPos:    "move_entity_pos",
+	PlayClientMoveEntityPosRot: "move_entity_pos_rot",
+	PlayClientMoveEntityRot:    "move_entity_rot",
+	PlayClientRotateHead:       "rotate_head",
+	PlayClientSetEntityMotion:  "set_entity_motion",
+	PlayClientEntityPosSync:    "entity_position_sync",
+	PlayClientSetPassengers:    "set_passengers",
 	PlayClientKeepAlive:        "keep_alive",
 	PlayClientLogin:            "login",
 	PlayClientPlayerChat:       "player_chat",
@@ -188,6 +258,36 @@ var PlayClientPacketNames = map[int32]string{
 	PlayClientActionBar:        "action_bar",
 	PlayClientSystemChat:       "system_chat",
 	PlayClientSetHealth:        "update_health",
+	PlayClientPlayerCombatKill: "player_combat_kill",
+	PlayClientRespawn:          "respawn",
+	PlayClientStartConfig:      "start_configuration",
+	PlayClientTabList:          "tab_list",
+	PlayClientSetObjective:     "set_objective",
+	PlayClientSetScore:         "set_score",
+	PlayClientResetScore:       "reset_score",
+	PlayClientSetDisplayObj:    "set_display_objective",
+	PlayClientSetPlayerTeam:    "set_player_team",
+	PlayClientBossEvent:        "boss_event",
+	PlayClientSetTitleText:     "set_title_text",
+	PlayClientSetSubtitleText:  "set_subtitle_text",
+	PlayClientSetTitlesAnim:    "set_titles_animation",
+	PlayClientClearTitles:      "clear_titles",
+	PlayClientSetTime:          "set_time",
+	PlayClientInitBorder:       "initialize_border",
+	PlayClientBorderCenter:     "set_border_center",
+	PlayClientBorderLerpSize:   "set_border_lerp_size",
+	PlayClientBorderSize:       "set_border_size",
+	PlayClientBorderWarnDelay:  "set_border_warning_delay",
+	PlayClientBorderWarnDist:   "set_border_warning_distance",
+	PlayClientMapItemData:      "map_item_data",
+	PlayClientOpenBook:         "open_book",
+	PlayClientOpenSignEditor:   "open_sign_editor",
+	PlayClientBlockEntityData:  "block_entity_data",
+	PlayClientLevelChunk:       "level_chunk_with_light",
+	PlayClientForgetChunk:      "forget_level_chunk",
+	PlayClientClearDialog:      "clear_dialog",
+	PlayClientShowDialog:       "show_dialog",
+	PlayClientMerchantOffers:   "merchant_offers",
 	PlayClientSetExperience:    "experience",
 	PlayClientPlayerInfoUpdate: "player_info_update",
 	PlayClientPlayerInfoRemove: "player_info_remove",
@@ -201,6 +301,13 @@ var PlayClientPacketNames = map[int32]string{
 	PlayClientEntityData:       "entity_data",
 	PlayClientGameEvent:        "game_event",
 	PlayClientClientCommand:    "client_command",
+	PlayClientSetCursorItem:    "set_cursor_item",
+	PlayClientSetPlayerInv:     "set_player_inventory",
+	PlayClientRecipeBookAdd:    "recipe_book_add",
+	PlayClientRecipeBookRemove: "recipe_book_remove",
+	PlayClientRecipeBookSet:    "recipe_book_settings",
+	PlayClientUpdateRecipes:    "update_recipes",
+	PlayClientUpdateTags:       "update_tags",
 }
 
 func (s State) String() string {
@@ -0,0 +1,65 @@
+package mcclient
+
+import (
+	"net"
+	"testing"
+
+	"gmcc/internal/config"
+	"gmcc/internal/entity"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/mcclient/protocol"
+	"gmcc/internal/registry"
+)
+
+func TestHandleStartConfiguration(t *testing.T) {
+	clientEnd, serverEnd := net.Pipe()
+	defer clientEnd.Close()
+	defer serverEnd.Close()
+
+	cfg := config.Default()
+	c := New(&cfg)
+	c.conn = packet.NewPacketConn(clientEnd)
+	c.state = protocol.StatePlay
+	c.inPlay = true
+	c.initializeTrackers()
+	defer c.entityTracker.Stop()
+	c.startTicker()
+	c.entityTracker.SpawnEntity(1, "minecraft:cow", [16]byte{1}, entity.Position{}, entity.Vector3{})
+	registry.SetTags("minecraft:item", map[string][]int32{"minecraft:planks": {1}})
+	defer registry.ClearTags()
+
+	received := make(chan packet.Packet, 1)
+	go func() {
+		pkt, err := packet.NewPacketConn(serverEnd).ReadPacket()
+		if err == nil {
+			received <- pkt
+		}
+		close(received)
+	}()
+
+	if err := c.handlePlayPacket(packet.Packet{ID: protocol.PlayClientStartConfig}); err != nil {
+		t.Fatalf("start_configuration error = %v", err)
+	}
+	if pkt, ok := <-received; !ok || pkt.ID != protocol.PlayServerConfigAck {
+		t.Errorf("未发送 configuration_acknowledged: %+v", pkt)
+	}
+	if c.state != protocol.StateConfiguration || c.inPlay {
+		t.Errorf("state=%s inPlay=%v, want Configuration false", c.state, c.inPlay)
+	}
+	if c.ticker != nil || c.tickerDone != nil || c.tickerExited != nil {
+		t.Error("游戏刻循环未停止")
+	}
+	if c.entityTracker.Count() != 0 || registry.TagEntries("minecraft:item", "minecraft:planks") != nil {
+		t.Error("旧服务器的实体或标签未清空")
+	}
+
+	// 重新进入 Play 后可以再次启动游戏刻循环，停止时等待循环退出
+	c.startTicker()
+	exited := c.tickerExited
+	c.stopTicker()
+	select {
+	case <-exited:
+	default:
+		t.Error("stopTicker 返回时游戏刻循环仍在运行")
+	}
+}
@@ -0,0 +1,136 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/player"
+)
+
+// respawn 的 data_to_keep 标记
+const (
+	respawnKeepAttributes = 0x01
+	respawnKeepEntityData = 0x02
+)
+
+// spawnInfo login 与 respawn 共用的 CommonPlayerSpawnInfo
+type spawnInfo struct {
+	DimensionType  int32
+	Dimension      string
+	HashedSeed     int64
+	GameMode       player.GameMode
+	PrevGameMode   int8 // -1 表示没有
+	IsDebug        bool
+	IsFlat         bool
+	LastDeath      *player.GlobalPos
+	PortalCooldown int32
+	SeaLevel       int32
+}
+
+// DimensionChangeEvent 切换维度 (或经代理切换服务器) 事件
+type DimensionChangeEvent struct {
+	From     string
+	To       string
+	GameMode player.GameMode
+	Time     time.Time
+}
+
+// SetDimensionChangeHandler 设置维度切换回调
+func (c *Client) SetDimensionChangeHandler(handler func(DimensionChangeEvent)) {
+	c.dimensionHandler = handler
+}
+
+func readSpawnInfo(r *bytes.Reader) (spawnInfo, error) {
+	var info spawnInfo
+	var err error
+
+	if info.DimensionType, err = packet.ReadVarIntFromReader(r); err != nil {
+		return info, fmt.Errorf("读取 dimension_type 失败: %w", err)
+	}
+	if info.Dimension, err = packet.ReadStringFromReader(r); err != nil {
+		return info, fmt.Errorf("读取 dimension_name 失败: %w", err)
+	}
+	if info.HashedSeed, err = packet.ReadInt64(r); err != nil {
+		return info, fmt.Errorf("读取 hashed_seed 失败: %w", err)
+	}
+	gameMode, err := packet.ReadU8(r)
+	if err != nil {
+		return info, fmt.Errorf("读取 game_mode 失败: %w", err)
+	}
+	info.GameMode = player.GameMode(gameMode)
+	prevGameMode, err := packet.ReadU8(r)
+	if err != nil {
+		return info, fmt.Errorf("读取 previous_game_mode 失败: %w", err)
+	}
+	info.PrevGameMode = int8(prevGameMode)
+	if info.IsDebug, err = packet.ReadBoolFromReader(r); err != nil {
+		return info, fmt.Errorf("读取 is_debug 失败: %w", err)
+	}
+	if info.IsFlat, err = packet.ReadBoolFromReader(r); err != nil {
+		return info, fmt.Errorf("读取 is_flat 失败: %w", err)
+	}
+	if info.LastDeath, err = readDeathLocation(r); err != nil {
+		return info, err
+	}
+	if info.PortalCooldown, err = packet.ReadVarIntFromReader(r); err != nil {
+		return info, fmt.Errorf("读取 portal_cooldown 失败: %w", err)
+	}
+	if info.SeaLevel, err = packet.ReadVarIntFromReader(r); err != nil {
+		return info, fmt.Errorf("读取 sea_level 失败: %w", err)
+	}
+	return info, nil
+}
+
+func (c *Client) applySpawnInfo(info spawnInfo) {
+	c.Player.SetDimension(info.Dimension)
+	c.Player.SetGameMode(info.GameMode)
+	c.Player.SetLastDeath(info.LastDeath)
+}
+
+func (c *Client) handleRespawnPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	info, err := readSpawnInfo(r)
+	if err != nil {
+		return fmt.Errorf("解析 respawn 失败: %w", err)
+	}
+	keep, err := packet.ReadU8(r)
+	if err != nil {
+		return fmt.Errorf("读取 respawn data_to_keep 失败: %w", err)
+	}
+
+	from := c.Player.GetDimension()
+	c.applySpawnInfo(info)
+	// 原版客户端会重建世界和玩家实体，旧世界的实体、容器都不再有效
+	c.resetWorldState()
+
+	logx.Infof("重生: 维度 %s -> %s, 游戏模式=%s, 保留属性=%v, 保留实体数据=%v",
+		from, info.Dimension, info.GameMode.String(), keep&respawnKeepAttributes != 0, keep&respawnKeepEntityData != 0)
+
+	if from != info.Dimension && c.dimensionHandler != nil {
+		c.dimensionHandler(DimensionChangeEvent{
+			From:     from,
+			To:       info.Dimension,
+			GameMode: info.GameMode,
+			Time:     time.Now(),
+		})
+	}
+	return nil
+}
+
+// resetWorldState 清空与当前世界绑定的状态
+func (c *Client) resetWorldState() {
+	if c.entityTracker != nil {
+		c.entityTracker.Clear()
+	}
+	if c.NearbyPlayers != nil {
+		c.NearbyPlayers.Clear()
+	}
+	c.Player.SetOpenContainer(nil)
+	c.Player.SetCarried(nil)
+	c.World.ResetWeather()
+	c.World.ClearSigns()
+	c.closeSignEditor()
+}
@@ -0,0 +1,79 @@
+package mcclient
+
+import (
+	"bytes"
+	"testing"
+
+	"gmcc/internal/config"
+	"gmcc/internal/entity"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/player"
+)
+
+func encodeSpawnInfo(dimension string, gameMode byte, death bool) []byte {
+	var buf []byte
+	buf = append(buf, packet.EncodeVarInt(1)...)
+	buf = append(buf, packet.EncodeString(dimension)...)
+	buf = append(buf, packet.EncodeInt64(12345)...)
+	buf = append(buf, gameMode, 0xFF) // 上一个游戏模式为空
+	buf = append(buf, packet.EncodeBool(false)...)
+	buf = append(buf, packet.EncodeBool(true)...) // 超平坦
+	buf = append(buf, packet.EncodeBool(death)...)
+	if death {
+		buf = append(buf, packet.EncodeString("minecraft:overworld")...)
+		buf = append(buf, packet.EncodeInt64(int64(10)<<38|int64(20)<<12|64)...)
+	}
+	buf = append(buf, packet.EncodeVarInt(0)...)
+	buf = append(buf, packet.EncodeVarInt(63)...)
+	return buf
+}
+
+func TestReadSpawnInfo(t *testing.T) {
+	info, err := readSpawnInfo(bytes.NewReader(encodeSpawnInfo("minecraft:the_end", 2, true)))
+	if err != nil {
+		t.Fatalf("readSpawnInfo() error = %v", err)
+	}
+	if info.Dimension != "minecraft:the_end" || info.GameMode != player.GameModeAdventure ||
+		info.PrevGameMode != -1 || !info.IsFlat || info.SeaLevel != 63 {
+		t.Errorf("readSpawnInfo() = %+v", info)
+	}
+	if info.LastDeath == nil || *info.LastDeath != (player.GlobalPos{Dimension: "minecraft:overworld", X: 10, Y: 64, Z: 20}) {
+		t.Errorf("LastDeath = %+v", info.LastDeath)
+	}
+}
+
+func TestHandleRespawnPacket(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	c.initializeTrackers()
+	defer c.entityTracker.Stop()
+	c.Player.SetDimension("minecraft:overworld")
+	c.entityTracker.SpawnEntity(5, "minecraft:zombie", [16]byte{5}, entity.Position{}, entity.Vector3{})
+	c.Player.SetOpenContainer(&player.ContainerState{WindowID: 3, Open: true})
+
+	var events []DimensionChangeEvent
+	c.SetDimensionChangeHandler(func(e DimensionChangeEvent) { events = append(events, e) })
+
+	data := append(encodeSpawnInfo("minecraft:the_nether", 0, false), respawnKeepAttributes)
+	if err := c.handleRespawnPacket(data); err != nil {
+		t.Fatalf("handleRespawnPacket() error = %v", err)
+	}
+
+	if got := c.Player.GetDimension(); got != "minecraft:the_nether" {
+		t.Errorf("Dimension = %s", got)
+	}
+	if c.entityTracker.Count() != 0 || c.Player.GetActiveContainer().WindowID != 0 {
+		t.Errorf("旧世界状态未清空: entities=%d window=%d", c.entityTracker.Count(), c.Player.GetActiveContainer().WindowID)
+	}
+	if len(events) != 1 || events[0].From != "minecraft:overworld" || events[0].To != "minecraft:the_nether" {
+		t.Errorf("维度切换事件 = %+v", events)
+	}
+
+	// 同一维度内重生不触发维度切换
+	if err := c.handleRespawnPacket(append(encodeSpawnInfo("minecraft:the_nether", 0, true), 0)); err != nil {
+		t.Fatalf("handleRespawnPacket() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("同维度重生触发了 %d 次事件", len(events))
+	}
+}
@@ -0,0 +1,211 @@
+package mcclient
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"gmcc/internal/logx"
+	"gmcc/internal/mcclient/chat"
+	"gmcc/internal/mcclient/packet"
+)
+
+// 原版默认的标题时长 (刻)
+const (
+	defaultTitleFadeIn  = 10
+	defaultTitleStay    = 70
+	defaultTitleFadeOut = 20
+	ticksPerSecond      = 20
+)
+
+// TitleMessage 标题事件，Type 为 title / subtitle / clear / reset
+type TitleMessage struct {
+	Type       string
+	PlainText  string
+	RawJSON    string
+	FadeIn     time.Duration
+	Stay       time.Duration
+	FadeOut    time.Duration
+	ReceivedAt time.Time
+}
+
+// Title 当前显示的标题
+type Title struct {
+	Title        string // JSON 文本组件
+	Subtitle     string // JSON 文本组件，可能为空
+	TitleText    string
+	SubtitleText string
+	FadeIn       time.Duration
+	Stay         time.Duration
+	FadeOut      time.Duration
+	ShownAt      time.Time
+}
+
+// Until 返回标题完全消失的时间
+func (t Title) Until() time.Time {
+	return t.ShownAt.Add(t.FadeIn + t.Stay + t.FadeOut)
+}
+
+// titleState 记录标题、副标题和时长。副标题在下一次标题显示时一起出现。
+type titleState struct {
+	mu       sync.Mutex
+	title    string
+	subtitle string
+	fadeIn   int32
+	stay     int32
+	fadeOut  int32
+	shownAt  time.Time
+}
+
+func (s *titleState) resetTimes() {
+	s.fadeIn, s.stay, s.fadeOut = defaultTitleFadeIn, defaultTitleStay, defaultTitleFadeOut
+}
+
+// SetTitleHandler 设置标题回调
+func (c *Client) SetTitleHandler(handler func(TitleMessage)) {
+	c.titleHandler = handler
+}
+
+// CurrentTitle 返回仍在显示的标题
+func (c *Client) CurrentTitle() (Title, bool) {
+	c.title.mu.Lock()
+	defer c.title.mu.Unlock()
+	if c.title.title == "" || c.title.shownAt.IsZero() {
+		return Title{}, false
+	}
+	t := Title{
+		Title:    c.title.title,
+		Subtitle: c.title.subtitle,
+		FadeIn:   ticksToDuration(c.title.fadeIn),
+		Stay:     ticksToDuration(c.title.stay),
+		FadeOut:  ticksToDuration(c.title.fadeOut),
+		ShownAt:  c.title.shownAt,
+	}
+	if time.Now().After(t.Until()) {
+		return Title{}, false
+	}
+	t.TitleText = chat.ExtractPlainTextFromChatJSON(t.Title)
+	if t.Subtitle != "" {
+		t.SubtitleText = chat.ExtractPlainTextFromChatJSON(t.Subtitle)
+	}
+	return t, true
+}
+
+func (c *Client) handleSetTitleTextPacket(data []byte) error {
+	rawJSON, err := c.readAnonymousNBTJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("解析 set_title_text 内容失败: %w", err)
+	}
+	c.title.mu.Lock()
+	c.title.title = rawJSON
+	c.title.shownAt = time.Now()
+	c.title.mu.Unlock()
+
+	c.emitTitle("title", rawJSON)
+	return nil
+}
+
+func (c *Client) handleSetSubtitleTextPacket(data []byte) error {
+	rawJSON, err := c.readAnonymousNBTJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("解析 set_subtitle_text 内容失败: %w", err)
+	}
+	c.title.mu.Lock()
+	c.title.subtitle = rawJSON
+	c.title.mu.Unlock()
+
+	c.emitTitle("subtitle", rawJSON)
+	return nil
+}
+
+func (c *Client) handleSetTitlesAnimationPacket(data []byte) error {
+	r := bytes.NewReader(data)
+	fadeIn, err := packet.ReadInt32(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_titles_animation fade_in 失败: %w", err)
+	}
+	stay, err := packet.ReadInt32(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_titles_animation stay 失败: %w", err)
+	}
+	fadeOut, err := packet.ReadInt32(r)
+	if err != nil {
+		return fmt.Errorf("读取 set_titles_animation fade_out 失败: %w", err)
+	}
+
+	// 与原版 Gui.setTimes 一致: 负数表示保留原值，正在显示的标题重新计时
+	c.title.mu.Lock()
+	if fadeIn >= 0 {
+		c.title.fadeIn = fadeIn
+	}
+	if stay >= 0 {
+		c.title.stay = stay
+	}
+	if fadeOut >= 0 {
+		c.title.fadeOut = fadeOut
+	}
+	if !c.title.shownAt.IsZero() {
+		c.title.shownAt = time.Now()
+	}
+	c.title.mu.Unlock()
+	return nil
+}
+
+func (c *Client) handleClearTitlesPacket(data []byte) error {
+	reset, err := packet.ReadBool(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("读取 clear_titles reset 标记失败: %w", err)
+	}
+
+	c.title.mu.Lock()
+	// 原版清除时总是同时清掉副标题，reset 只额外恢复默认时长
+	c.title.title = ""
+	c.title.subtitle = ""
+	c.title.shownAt = time.Time{}
+	if reset {
+		c.title.resetTimes()
+	}
+	c.title.mu.Unlock()
+
+	if reset {
+		c.emitTitle("reset", "")
+	} else {
+		c.emitTitle("clear", "")
+	}
+	return nil
+}
+
+func (c *Client) emitTitle(kind, rawJSON string) {
+	c.title.mu.Lock()
+	msg := TitleMessage{
+		Type:       kind,
+		RawJSON:    rawJSON,
+		FadeIn:     ticksToDuration(c.title.fadeIn),
+		Stay:       ticksToDuration(c.title.stay),
+		FadeOut:    ticksToDuration(c.title.fadeOut),
+		ReceivedAt: time.Now(),
+	}
+	c.title.mu.Unlock()
+	if rawJSON != "" {
+		msg.PlainText = chat.ExtractPlainTextFromChatJSON(rawJSON)
+	}
+
+	logx.Debugf("[标题] %s: %s", kind, msg.PlainText)
+	if c.titleHandler != nil {
+		c.titleHandler(msg)
+	}
+}
+
+// resetTitles 断开或切换服务器时清空标题
+func (c *Client) resetTitles() {
+	c.title.mu.Lock()
+	defer c.title.mu.Unlock()
+	c.title.title, c.title.subtitle = "", ""
+	c.title.shownAt = time.Time{}
+	c.title.resetTimes()
+}
+
+func ticksToDuration(ticks int32) time.Duration {
+	return time.Duration(ticks) * time.Second / ticksPerSecond
+}
@@ -0,0 +1,135 @@
+package mcclient
+
+import (
+	"testing"
+	"time"
+
+	"gmcc/internal/config"
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/player"
+)
+
+func TestTitles(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	var events []TitleMessage
+	c.SetTitleHandler(func(msg TitleMessage) { events = append(events, msg) })
+
+	var times []byte
+	times = append(times, packet.EncodeInt32(5)...)
+	times = append(times, packet.EncodeInt32(40)...)
+	times = append(times, packet.EncodeInt32(5)...)
+	if err := c.handleSetTitlesAnimationPacket(times); err != nil {
+		t.Fatalf("handleSetTitlesAnimationPacket() error = %v", err)
+	}
+	if err := c.handleSetSubtitleTextPacket(nbtText("排队中")); err != nil {
+		t.Fatalf("handleSetSubtitleTextPacket() error = %v", err)
+	}
+	if _, ok := c.CurrentTitle(); ok {
+		t.Error("只有副标题时不应显示")
+	}
+	if err := c.handleSetTitleTextPacket(nbtText("欢迎")); err != nil {
+		t.Fatalf("handleSetTitleTextPacket() error = %v", err)
+	}
+
+	title, ok := c.CurrentTitle()
+	if !ok || title.TitleText != "欢迎" || title.SubtitleText != "排队中" || title.Stay != 2*time.Second {
+		t.Fatalf("CurrentTitle() = %+v, %v", title, ok)
+	}
+	if len(events) != 2 || events[1].Type != "title" || events[1].PlainText != "欢迎" || events[1].FadeIn != 250*time.Millisecond {
+		t.Fatalf("events = %+v", events)
+	}
+
+	// 不重置时也要清掉副标题，但保留自定义时长
+	if err := c.handleClearTitlesPacket(packet.EncodeBool(false)); err != nil {
+		t.Fatalf("handleClearTitlesPacket(false) error = %v", err)
+	}
+	if c.title.subtitle != "" || c.title.stay != 40 {
+		t.Errorf("clear 后 subtitle = %q, stay = %d", c.title.subtitle, c.title.stay)
+	}
+
+	if err := c.handleClearTitlesPacket(packet.EncodeBool(true)); err != nil {
+		t.Fatalf("handleClearTitlesPacket() error = %v", err)
+	}
+	if _, ok := c.CurrentTitle(); ok {
+		t.Error("clear_titles 后仍在显示")
+	}
+	if last := events[len(events)-1]; last.Type != "reset" || last.Stay != defaultTitleStay*time.Second/ticksPerSecond {
+		t.Errorf("reset 事件 = %+v", last)
+	}
+}
+
+func TestSetTitlesAnimationKeepsNegative(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	animation := func(fadeIn, stay, fadeOut int32) {
+		t.Helper()
+		data := append(packet.EncodeInt32(fadeIn), packet.EncodeInt32(stay)...)
+		data = append(data, packet.EncodeInt32(fadeOut)...)
+		if err := c.handleSetTitlesAnimationPacket(data); err != nil {
+			t.Fatalf("handleSetTitlesAnimationPacket() error = %v", err)
+		}
+	}
+
+	animation(5, 40, 5)
+	if err := c.handleSetTitleTextPacket(nbtText("欢迎")); err != nil {
+		t.Fatalf("handleSetTitleTextPacket() error = %v", err)
+	}
+	shown := time.Now().Add(-time.Second)
+	c.title.shownAt = shown
+
+	// -1 保留原值，正在显示的标题重新计时
+	animation(-1, 100, -1)
+	title, ok := c.CurrentTitle()
+	if !ok {
+		t.Fatal("修改时长后标题不应消失")
+	}
+	if title.FadeIn != 250*time.Millisecond || title.Stay != 5*time.Second || title.FadeOut != 250*time.Millisecond {
+		t.Errorf("时长 = %v/%v/%v, want 250ms/5s/250ms", title.FadeIn, title.Stay, title.FadeOut)
+	}
+	if !title.ShownAt.After(shown) {
+		t.Errorf("ShownAt = %v, 应重新计时", title.ShownAt)
+	}
+}
+
+func TestHandleBossEventPacket(t *testing.T) {
+	cfg := config.Default()
+	c := New(&cfg)
+	uuid := [16]byte{0x42}
+
+	var add []byte
+	add = append(add, uuid[:]...)
+	add = append(add, packet.EncodeVarInt(bossEventAdd)...)
+	add = append(add, nbtText("Queue")...)
+	add = append(add, packet.EncodeFloat32(0.75)...)
+	add = append(add, packet.EncodeVarInt(int32(player.BossBarYellow))...)
+	add = append(add, packet.EncodeVarInt(int32(player.BossBarNotched10))...)
+	add = append(add, player.BossBarDarkenScreen)
+	if err := c.handleBossEventPacket(add); err != nil {
+		t.Fatalf("handleBossEventPacket(add) error = %v", err)
+	}
+
+	var rename []byte
+	rename = append(rename, uuid[:]...)
+	rename = append(rename, packet.EncodeVarInt(bossEventUpdateName)...)
+	rename = append(rename, nbtText("Queue #3")...)
+	if err := c.handleBossEventPacket(rename); err != nil {
+		t.Fatalf("handleBossEventPacket(rename) error = %v", err)
+	}
+
+	bar, ok := c.BossBars.Get(uuid)
+	if !ok || bar.Title != `"Queue #3"` || bar.Progress != 0.75 || bar.Color != player.BossBarYellow ||
+		bar.Overlay != player.BossBarNotched10 || bar.Flags != player.BossBarDarkenScreen {
+		t.Fatalf("Boss 栏 = %+v, %v", bar, ok)
+	}
+
+	var remove []byte
+	remove = append(remove, uuid[:]...)
+	remove = append(remove, packet.EncodeVarInt(bossEventRemove)...)
+	if err := c.handleBossEventPacket(remove); err != nil {
+		t.Fatalf("handleBossEventPacket(remove) error = %v", err)
+	}
+	if c.BossBars.Len() != 0 {
+		t.Error("移除后仍有 Boss 栏")
+	}
+}
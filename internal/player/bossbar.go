@@ -0,0 +1,172 @@
+package player
+
+import (
+	"sort"
+	"sync"
+)
+
+// BossBarColor Boss 栏颜色
+type BossBarColor int32
+
+const (
+	BossBarPink BossBarColor = iota
+	BossBarBlue
+	BossBarRed
+	BossBarGreen
+	BossBarYellow
+	BossBarPurple
+	BossBarWhite
+)
+
+var bossBarColorNames = []string{"pink", "blue", "red", "green", "yellow", "purple", "white"}
+
+func (c BossBarColor) String() string {
+	if c < 0 || int(c) >= len(bossBarColorNames) {
+		return "unknown"
+	}
+	return bossBarColorNames[c]
+}
+
+// BossBarOverlay Boss 栏分段样式
+type BossBarOverlay int32
+
+const (
+	BossBarProgress BossBarOverlay = iota
+	BossBarNotched6
+	BossBarNotched10
+	BossBarNotched12
+	BossBarNotched20
+)
+
+// Boss 栏标记位
+const (
+	BossBarDarkenScreen = 0x01
+	BossBarPlayMusic    = 0x02
+	BossBarCreateFog    = 0x04
+)
+
+// BossBar 服务器下发的 Boss 栏
+type BossBar struct {
+	UUID     [16]byte
+	Title    string // JSON 文本组件
+	Progress float32
+	Color    BossBarColor
+	Overlay  BossBarOverlay
+	Flags    uint8
+	order    int
+}
+
+// BossBarCallbacks Boss 栏事件回调
+type BossBarCallbacks struct {
+	OnAdd    func(b BossBar)
+	OnUpdate func(b BossBar)
+	OnRemove func(b BossBar)
+}
+
+// BossBars 维护 boss_event 下发的 Boss 栏
+type BossBars struct {
+	mu        sync.RWMutex
+	bars      map[[16]byte]*BossBar
+	nextOrder int
+	callbacks BossBarCallbacks
+}
+
+func NewBossBars() *BossBars {
+	return &BossBars{bars: make(map[[16]byte]*BossBar)}
+}
+
+// SetCallbacks 设置事件回调
+func (b *BossBars) SetCallbacks(callbacks BossBarCallbacks) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.callbacks = callbacks
+}
+
+// Add 新增 Boss 栏，已存在时整体替换
+func (b *BossBars) Add(bar BossBar) {
+	b.mu.Lock()
+	if old, ok := b.bars[bar.UUID]; ok {
+		bar.order = old.order
+	} else {
+		bar.order = b.nextOrder
+		b.nextOrder++
+	}
+	b.bars[bar.UUID] = &bar
+	callbacks := b.callbacks
+	b.mu.Unlock()
+
+	if callbacks.OnAdd != nil {
+		go callbacks.OnAdd(bar)
+	}
+}
+
+// Update 修改已有的 Boss 栏，未知 UUID 返回 false
+func (b *BossBars) Update(uuid [16]byte, apply func(*BossBar)) bool {
+	b.mu.Lock()
+	bar, ok := b.bars[uuid]
+	if !ok {
+		b.mu.Unlock()
+		return false
+	}
+	// 替换而不是原地修改，已返回给调用方的快照保持不变
+	copied := *bar
+	apply(&copied)
+	b.bars[uuid] = &copied
+	callbacks := b.callbacks
+	b.mu.Unlock()
+
+	if callbacks.OnUpdate != nil {
+		go callbacks.OnUpdate(copied)
+	}
+	return true
+}
+
+// Remove 移除 Boss 栏
+func (b *BossBars) Remove(uuid [16]byte) {
+	b.mu.Lock()
+	bar, ok := b.bars[uuid]
+	delete(b.bars, uuid)
+	callbacks := b.callbacks
+	b.mu.Unlock()
+
+	if ok && callbacks.OnRemove != nil {
+		go callbacks.OnRemove(*bar)
+	}
+}
+
+// Get 通过 UUID 查找 Boss 栏
+func (b *BossBars) Get(uuid [16]byte) (BossBar, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if bar, ok := b.bars[uuid]; ok {
+		return *bar, true
+	}
+	return BossBar{}, false
+}
+
+// All 返回全部 Boss 栏，按添加顺序排序 (与原版从上到下的顺序一致)
+func (b *BossBars) All() []BossBar {
+	b.mu.RLock()
+	result := make([]BossBar, 0, len(b.bars))
+	for _, bar := range b.bars {
+		result = append(result, *bar)
+	}
+	b.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool { return result[i].order < result[j].order })
+	return result
+}
+
+// Len 返回 Boss 栏数量
+func (b *BossBars) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.bars)
+}
+
+// Clear 清空 Boss 栏 (切换服务器时)
+func (b *BossBars) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bars = make(map[[16]byte]*BossBar)
+}
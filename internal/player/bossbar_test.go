@@ -0,0 +1,53 @@
+package player
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBossBars(t *testing.T) {
+	bars := NewBossBars()
+	removed := make(chan BossBar, 1)
+	bars.SetCallbacks(BossBarCallbacks{
+		OnRemove: func(b BossBar) { removed <- b },
+	})
+
+	first, second := [16]byte{1}, [16]byte{2}
+	bars.Add(BossBar{UUID: second, Title: `"队列"`, Progress: 0.5})
+	bars.Add(BossBar{UUID: first, Title: `"活动"`, Progress: 1})
+	snapshot, _ := bars.Get(second)
+
+	if !bars.Update(second, func(b *BossBar) { b.Progress = 0.25; b.Color = BossBarRed }) {
+		t.Fatal("更新已有 Boss 栏失败")
+	}
+	if bars.Update([16]byte{9}, func(b *BossBar) {}) {
+		t.Error("未知 Boss 栏不应更新成功")
+	}
+	got, ok := bars.Get(second)
+	if !ok || got.Progress != 0.25 || got.Color != BossBarRed || got.Title != `"队列"` {
+		t.Errorf("Get() = %+v", got)
+	}
+	if snapshot.Progress != 0.5 {
+		t.Errorf("旧快照被修改: %+v", snapshot)
+	}
+
+	// 按添加顺序排列，重新添加不改变位置
+	bars.Add(BossBar{UUID: second, Title: `"队列 2"`})
+	all := bars.All()
+	if len(all) != 2 || all[0].UUID != second || all[1].UUID != first {
+		t.Errorf("All() = %+v", all)
+	}
+
+	bars.Remove(first)
+	select {
+	case b := <-removed:
+		if b.UUID != first {
+			t.Errorf("OnRemove = %+v", b)
+		}
+	case <-time.After(time.Second):
+		t.Error("未触发 OnRemove")
+	}
+	if bars.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", bars.Len())
+	}
+}
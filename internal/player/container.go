@@ -0,0 +1,478 @@
+package player
+
+import (
+	"fmt"
+
+	"gmcc/internal/item"
+)
+
+// ClickMode 对应 container_click 包中的点击模式
+type ClickMode int32
+
+const (
+	ClickPickup     ClickMode = 0 // 左/右键拾取或放置
+	ClickQuickMove  ClickMode = 1 // Shift + 点击
+	ClickSwap       ClickMode = 2 // 数字键/副手键交换
+	ClickClone      ClickMode = 3 // 中键复制 (仅创造模式)
+	ClickThrow      ClickMode = 4 // Q 键丢弃
+	ClickQuickCraft ClickMode = 5 // 拖拽分配
+	ClickPickupAll  ClickMode = 6 // 双击收集
+)
+
+// SlotOutside 表示点击窗口外部
+const SlotOutside int16 = -999
+
+const (
+	// playerMenuSlots 玩家背包窗口 (windowID=0) 的槽位数量
+	playerMenuSlots = 46
+	// playerSectionSlots 其他窗口末尾附带的玩家背包槽位数量 (主背包 27 + 快捷栏 9)
+	playerSectionSlots = 36
+	// offhandButton 交换模式下表示副手的按键值
+	offhandButton = 40
+)
+
+// QuickCraft 拖拽类型
+const (
+	QuickCraftLeft   int8 = 0 // 平均分配
+	QuickCraftRight  int8 = 1 // 每格一个
+	QuickCraftMiddle int8 = 2 // 每格满组 (仅创造模式)
+)
+
+type ContainerState struct {
+	WindowID   int32
+	WindowType int32
+	StateID    int32
+	Open       bool
+	Slots      []*item.ItemStack
+	// Offhand 其他窗口不包含副手槽位，副手交换时使用此处的副本
+	Offhand *item.ItemStack
+}
+
+// ClickResult 客户端预测的点击结果
+type ClickResult struct {
+	Changed map[int16]*item.ItemStack
+	Carried *item.ItemStack
+	// 在其他窗口中与副手交换时为 true，Offhand 为交换后的副手物品
+	OffhandChanged bool
+	Offhand        *item.ItemStack
+}
+
+func newPlayerMenu() *ContainerState {
+	return &ContainerState{
+		WindowID: 0,
+		Open:     true,
+		Slots:    make([]*item.ItemStack, playerMenuSlots),
+	}
+}
+
+// Clone 深拷贝容器状态，用于在锁外模拟点击
+func (s *ContainerState) Clone() *ContainerState {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.Slots = make([]*item.ItemStack, len(s.Slots))
+	for i, stack := range s.Slots {
+		c.Slots[i] = stack.Clone()
+	}
+	c.Offhand = s.Offhand.Clone()
+	return &c
+}
+
+func (s *ContainerState) Slot(i int) *item.ItemStack {
+	if s == nil || i < 0 || i >= len(s.Slots) {
+		return nil
+	}
+	return s.Slots[i]
+}
+
+func (s *ContainerState) setSlot(i int, stack *item.ItemStack) {
+	if i < 0 {
+		return
+	}
+	if i >= len(s.Slots) {
+		grown := make([]*item.ItemStack, i+1)
+		copy(grown, s.Slots)
+		s.Slots = grown
+	}
+	s.Slots[i] = stack.Clone()
+}
+
+// PlayerSlotOffset 返回玩家背包部分 (主背包起始) 在窗口中的槽位下标
+func (s *ContainerState) PlayerSlotOffset() int {
+	if s.WindowID == 0 {
+		return 9
+	}
+	if len(s.Slots) < playerSectionSlots {
+		return 0
+	}
+	return len(s.Slots) - playerSectionSlots
+}
+
+// HotbarSlot 返回快捷栏第 n 格 (0-8) 在窗口中的槽位下标
+func (s *ContainerState) HotbarSlot(n int) int {
+	return s.PlayerSlotOffset() + 27 + n
+}
+
+// ContainerSlots 返回非玩家背包部分的槽位数量
+func (s *ContainerState) ContainerSlots() int {
+	if s.WindowID == 0 {
+		return 0
+	}
+	return s.PlayerSlotOffset()
+}
+
+// inventorySlot 将窗口槽位映射为玩家背包窗口 (windowID=0) 中的槽位
+func (s *ContainerState) inventorySlot(i int) (int, bool) {
+	if s.WindowID == 0 {
+		return i, i >= 0 && i < playerMenuSlots
+	}
+	offset := s.PlayerSlotOffset()
+	if i < offset || i >= offset+playerSectionSlots {
+		return 0, false
+	}
+	return 9 + (i - offset), true
+}
+
+// SimulateClick 在状态副本上模拟一次点击，返回变化的槽位和新的光标物品。
+// 规则参考原版 AbstractContainerMenu.doClick，预测错误时服务端会重新同步。
+func SimulateClick(state *ContainerState, carried *item.ItemStack, slot int16, button int8, mode ClickMode, creative bool) (*ClickResult, error) {
+	if state == nil {
+		return nil, fmt.Errorf("没有可操作的容器")
+	}
+	sim := state.Clone()
+	cur := carried.Clone()
+
+	valid := int(slot) >= 0 && int(slot) < len(sim.Slots)
+	if !valid && slot != SlotOutside {
+		return nil, fmt.Errorf("槽位 %d 超出范围 (0-%d)", slot, len(sim.Slots)-1)
+	}
+
+	switch mode {
+	case ClickPickup:
+		if button != 0 && button != 1 {
+			return nil, fmt.Errorf("拾取模式按键无效: %d", button)
+		}
+		if slot == SlotOutside {
+			if !cur.IsEmpty() {
+				if button == 0 {
+					cur = nil
+				} else {
+					cur = cur.WithCount(cur.Count - 1)
+				}
+			}
+			break
+		}
+		cur = pickup(sim, int(slot), cur, button)
+
+	case ClickQuickMove:
+		if !valid {
+			return nil, fmt.Errorf("快速移动需要有效槽位")
+		}
+		quickMove(sim, int(slot))
+
+	case ClickSwap:
+		if !valid {
+			return nil, fmt.Errorf("交换需要有效槽位")
+		}
+		var target int
+		switch {
+		case button >= 0 && button <= 8:
+			target = sim.HotbarSlot(int(button))
+		case button == offhandButton && sim.WindowID == 0:
+			target = playerMenuSlots - 1
+		case button == offhandButton:
+			// 副手不在其他窗口的槽位中，交换结果不计入变化槽位
+			a := sim.Slot(int(slot))
+			sim.setSlot(int(slot), sim.Offhand)
+			sim.Offhand = a.Clone()
+			return diffResult(state, sim, cur), nil
+		default:
+			return nil, fmt.Errorf("交换模式按键无效: %d", button)
+		}
+		a, b := sim.Slot(int(slot)), sim.Slot(target)
+		sim.setSlot(int(slot), b)
+		sim.setSlot(target, a)
+
+	case ClickClone:
+		if !creative {
+			return nil, fmt.Errorf("复制物品需要创造模式")
+		}
+		if valid && cur.IsEmpty() {
+			if stack := sim.Slot(int(slot)); !stack.IsEmpty() {
+				cur = stack.WithCount(stack.MaxStackSize())
+			}
+		}
+
+	case ClickThrow:
+		if valid && cur.IsEmpty() {
+			if stack := sim.Slot(int(slot)); !stack.IsEmpty() {
+				if button == 0 {
+					sim.setSlot(int(slot), stack.WithCount(stack.Count-1))
+				} else {
+					sim.setSlot(int(slot), nil)
+				}
+			}
+		}
+
+	case ClickPickupAll:
+		if !valid {
+			return nil, fmt.Errorf("双击收集需要有效槽位")
+		}
+		cur = pickupAll(sim, cur, button)
+
+	case ClickQuickCraft:
+		return nil, fmt.Errorf("拖拽分配请使用 SimulateQuickCraft")
+
+	default:
+		return nil, fmt.Errorf("未知点击模式: %d", mode)
+	}
+
+	return diffResult(state, sim, cur), nil
+}
+
+// SimulateQuickCraft 模拟一次完整的拖拽分配 (开始 -> 逐格添加 -> 结束)
+func SimulateQuickCraft(state *ContainerState, carried *item.ItemStack, slots []int16, kind int8, creative bool) (*ClickResult, error) {
+	if state == nil {
+		return nil, fmt.Errorf("没有可操作的容器")
+	}
+	if carried.IsEmpty() {
+		return nil, fmt.Errorf("光标上没有物品，无法拖拽分配")
+	}
+	if kind == QuickCraftMiddle && !creative {
+		return nil, fmt.Errorf("中键拖拽需要创造模式")
+	}
+	if kind < QuickCraftLeft || kind > QuickCraftMiddle {
+		return nil, fmt.Errorf("拖拽类型无效: %d", kind)
+	}
+
+	sim := state.Clone()
+	cur := carried.Clone()
+
+	targets := make([]int, 0, len(slots))
+	seen := make(map[int]bool, len(slots))
+	for _, s := range slots {
+		i := int(s)
+		if i < 0 || i >= len(sim.Slots) {
+			return nil, fmt.Errorf("槽位 %d 超出范围 (0-%d)", s, len(sim.Slots)-1)
+		}
+		stack := sim.Slot(i)
+		if seen[i] || (!stack.IsEmpty() && !stack.SameItem(cur)) {
+			continue
+		}
+		seen[i] = true
+		targets = append(targets, i)
+	}
+	if len(targets) == 0 {
+		return diffResult(state, sim, cur), nil
+	}
+	// 原版只在光标数量多于已拖过的槽位数时才加入新槽位
+	if kind != QuickCraftMiddle && int(cur.Count) < len(targets) {
+		targets = targets[:cur.Count]
+	}
+
+	limit := cur.MaxStackSize()
+	remaining := cur.Count
+	for _, i := range targets {
+		var per int32
+		switch kind {
+		case QuickCraftLeft:
+			per = cur.Count / int32(len(targets))
+		case QuickCraftRight:
+			per = 1
+		case QuickCraftMiddle:
+			per = limit
+		}
+		existing := int32(0)
+		if stack := sim.Slot(i); !stack.IsEmpty() {
+			existing = stack.Count
+		}
+		total := min(existing+per, limit)
+		sim.setSlot(i, cur.WithCount(total))
+		if kind != QuickCraftMiddle {
+			remaining -= total - existing
+		}
+	}
+	cur = cur.WithCount(remaining)
+
+	return diffResult(state, sim, cur), nil
+}
+
+func pickup(sim *ContainerState, i int, cur *item.ItemStack, button int8) *item.ItemStack {
+	stack := sim.Slot(i)
+	switch {
+	case stack.IsEmpty():
+		if cur.IsEmpty() {
+			return cur
+		}
+		n := cur.Count
+		if button == 1 {
+			n = 1
+		}
+		n = min(n, cur.MaxStackSize())
+		sim.setSlot(i, cur.WithCount(n))
+		return cur.WithCount(cur.Count - n)
+
+	case cur.IsEmpty():
+		n := stack.Count
+		if button == 1 {
+			n = (stack.Count + 1) / 2
+		}
+		sim.setSlot(i, stack.WithCount(stack.Count-n))
+		return stack.WithCount(n)
+
+	case stack.SameItem(cur):
+		n := cur.Count
+		if button == 1 {
+			n = 1
+		}
+		n = min(n, stack.MaxStackSize()-stack.Count)
+		if n <= 0 {
+			return cur
+		}
+		sim.setSlot(i, stack.WithCount(stack.Count+n))
+		return cur.WithCount(cur.Count - n)
+
+	default:
+		if cur.Count > cur.MaxStackSize() {
+			return cur
+		}
+		sim.setSlot(i, cur)
+		return stack.Clone()
+	}
+}
+
+func quickMove(sim *ContainerState, i int) {
+	stack := sim.Slot(i)
+	if stack.IsEmpty() {
+		return
+	}
+	n := len(sim.Slots)
+
+	var rest *item.ItemStack
+	if sim.WindowID == 0 {
+		// 参考原版 InventoryMenu.quickMoveStack，目标范围不含副手槽位 45
+		const offhand = playerMenuSlots - 1
+		equip := stack.EquipmentSlot()
+		armor := 9 - int(equip) // 头 5、胸 6、腿 7、脚 8
+		switch {
+		case i == 0:
+			rest = moveItemStackTo(sim, stack, 9, offhand, true)
+		case i >= 1 && i <= 8:
+			rest = moveItemStackTo(sim, stack, 9, offhand, false)
+		case equip.IsHumanoidArmor() && sim.Slot(armor).IsEmpty():
+			rest = moveItemStackTo(sim, stack, armor, armor+1, false)
+		case equip == item.EquipOffhand && sim.Slot(offhand).IsEmpty():
+			rest = moveItemStackTo(sim, stack, offhand, offhand+1, false)
+		case i >= 9 && i < 36:
+			rest = moveItemStackTo(sim, stack, 36, offhand, false)
+		case i >= 36 && i < 45:
+			rest = moveItemStackTo(sim, stack, 9, 36, false)
+		default:
+			rest = moveItemStackTo(sim, stack, 9, offhand, false)
+		}
+	} else {
+		offset := sim.PlayerSlotOffset()
+		if i < offset {
+			rest = moveItemStackTo(sim, stack, offset, n, true)
+		} else {
+			rest = moveItemStackTo(sim, stack, 0, offset, false)
+		}
+	}
+	sim.setSlot(i, rest)
+}
+
+// moveItemStackTo 先合并到已有的同类物品，再放入空槽位，返回剩余物品
+func moveItemStackTo(sim *ContainerState, stack *item.ItemStack, start, end int, reverse bool) *item.ItemStack {
+	rest := stack.Clone()
+	limit := rest.MaxStackSize()
+
+	order := func(yield func(int) bool) {
+		if reverse {
+			for j := end - 1; j >= start; j-- {
+				if !yield(j) {
+					return
+				}
+			}
+			return
+		}
+		for j := start; j < end; j++ {
+			if !yield(j) {
+				return
+			}
+		}
+	}
+
+	if limit > 1 {
+		for j := range order {
+			if rest.IsEmpty() {
+				break
+			}
+			target := sim.Slot(j)
+			if !target.SameItem(rest) || target.Count >= limit {
+				continue
+			}
+			n := min(rest.Count, limit-target.Count)
+			sim.setSlot(j, target.WithCount(target.Count+n))
+			rest = rest.WithCount(rest.Count - n)
+		}
+	}
+	for j := range order {
+		if rest.IsEmpty() {
+			break
+		}
+		if !sim.Slot(j).IsEmpty() {
+			continue
+		}
+		n := min(rest.Count, limit)
+		sim.setSlot(j, rest.WithCount(n))
+		rest = rest.WithCount(rest.Count - n)
+	}
+	return rest
+}
+
+func pickupAll(sim *ContainerState, cur *item.ItemStack, button int8) *item.ItemStack {
+	if cur.IsEmpty() {
+		return cur
+	}
+	limit := cur.MaxStackSize()
+	n := len(sim.Slots)
+	for pass := 0; pass < 2; pass++ {
+		for k := 0; k < n && cur.Count < limit; k++ {
+			j := k
+			if button != 0 {
+				j = n - 1 - k
+			}
+			stack := sim.Slot(j)
+			if !stack.SameItem(cur) || (pass == 0 && stack.Count == stack.MaxStackSize()) {
+				continue
+			}
+			take := min(stack.Count, limit-cur.Count)
+			sim.setSlot(j, stack.WithCount(stack.Count-take))
+			cur = cur.WithCount(cur.Count + take)
+		}
+	}
+	return cur
+}
+
+func diffResult(before, after *ContainerState, carried *item.ItemStack) *ClickResult {
+	changed := make(map[int16]*item.ItemStack)
+	for i := range after.Slots {
+		if !sameSlot(before.Slot(i), after.Slot(i)) {
+			changed[int16(i)] = after.Slot(i).Clone()
+		}
+	}
+	result := &ClickResult{Changed: changed, Carried: carried.Clone()}
+	if after.WindowID != 0 && !sameSlot(before.Offhand, after.Offhand) {
+		result.OffhandChanged = true
+		result.Offhand = after.Offhand.Clone()
+	}
+	return result
+}
+
+func sameSlot(a, b *item.ItemStack) bool {
+	if a.IsEmpty() || b.IsEmpty() {
+		return a.IsEmpty() == b.IsEmpty()
+	}
+	return a.SameItem(b) && a.Count == b.Count
+}
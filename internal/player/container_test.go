@@ -0,0 +1,276 @@
+package player
+
+import (
+	"testing"
+
+	"gmcc/internal/item"
+	"gmcc/internal/registry"
+)
+
+func itemID(t *testing.T, name string) int32 {
+	t.Helper()
+	id := registry.GetItemRegistry().NameToID(name)
+	if id < 0 {
+		t.Fatalf("未知物品: %s", name)
+	}
+	return id
+}
+
+func newChestState(slots int) *ContainerState {
+	return &ContainerState{WindowID: 3, StateID: 7, Open: true, Slots: make([]*item.ItemStack, slots+playerSectionSlots)}
+}
+
+func TestSimulateClick_PickupAndPlace(t *testing.T) {
+	stone := itemID(t, "stone")
+	state := newChestState(27)
+	state.Slots[0] = &item.ItemStack{ID: stone, Count: 10}
+
+	res, err := SimulateClick(state, nil, 0, 1, ClickPickup, false)
+	if err != nil {
+		t.Fatalf("右键拾取失败: %v", err)
+	}
+	if res.Carried == nil || res.Carried.Count != 5 {
+		t.Fatalf("期望光标上 5 个, 实际 %+v", res.Carried)
+	}
+	if got := res.Changed[0]; got == nil || got.Count != 5 {
+		t.Fatalf("期望槽位 0 剩余 5 个, 实际 %+v", got)
+	}
+	if state.Slots[0].Count != 10 {
+		t.Fatal("模拟不应修改原状态")
+	}
+
+	res, err = SimulateClick(state, &item.ItemStack{ID: stone, Count: 60}, 0, 0, ClickPickup, false)
+	if err != nil {
+		t.Fatalf("左键放置失败: %v", err)
+	}
+	if res.Changed[0].Count != 64 || res.Carried.Count != 6 {
+		t.Errorf("期望合并为 64 剩余 6, 实际 slot=%d carried=%d", res.Changed[0].Count, res.Carried.Count)
+	}
+}
+
+func TestSimulateClick_SwapDifferentItems(t *testing.T) {
+	stone := itemID(t, "stone")
+	dirt := itemID(t, "dirt")
+	state := newChestState(27)
+	state.Slots[4] = &item.ItemStack{ID: stone, Count: 3}
+
+	res, err := SimulateClick(state, &item.ItemStack{ID: dirt, Count: 2}, 4, 0, ClickPickup, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Changed[4].ID != dirt || res.Carried.ID != stone || res.Carried.Count != 3 {
+		t.Errorf("期望交换物品, 实际 slot=%+v carried=%+v", res.Changed[4], res.Carried)
+	}
+}
+
+func TestSimulateClick_QuickMoveChestToInventory(t *testing.T) {
+	stone := itemID(t, "stone")
+	state := newChestState(27)
+	state.Slots[0] = &item.ItemStack{ID: stone, Count: 20}
+	last := len(state.Slots) - 1
+	state.Slots[30] = &item.ItemStack{ID: stone, Count: 60}
+
+	res, err := SimulateClick(state, nil, 0, 0, ClickQuickMove, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Changed[30].Count != 64 {
+		t.Errorf("期望先合并到已有堆叠, 实际 %+v", res.Changed[30])
+	}
+	if res.Changed[int16(last)] == nil || res.Changed[int16(last)].Count != 16 {
+		t.Errorf("期望剩余放入最后一个空槽位, 实际 %+v", res.Changed[int16(last)])
+	}
+	if stack, ok := res.Changed[0]; !ok || stack != nil {
+		t.Errorf("期望源槽位被清空, 实际 %+v", stack)
+	}
+}
+
+func TestSimulateClick_QuickMoveInventory(t *testing.T) {
+	stone, chestplate := itemID(t, "stone"), itemID(t, "iron_chestplate")
+	tests := []struct {
+		name  string
+		slots map[int]*item.ItemStack
+		from  int16
+		want  map[int16]int32 // 槽位 -> 数量，0 表示清空
+	}{
+		{
+			name:  "副手合并到背包",
+			slots: map[int]*item.ItemStack{45: {ID: stone, Count: 10}, 9: {ID: stone, Count: 60}},
+			from:  45,
+			want:  map[int16]int32{45: 0, 9: 64, 10: 6},
+		},
+		{
+			name:  "合成结果从快捷栏末尾放入，不进副手",
+			slots: map[int]*item.ItemStack{0: {ID: stone, Count: 5}},
+			from:  0,
+			want:  map[int16]int32{0: 0, 44: 5},
+		},
+		{
+			name:  "胸甲穿到空的胸甲槽",
+			slots: map[int]*item.ItemStack{20: {ID: chestplate, Count: 1}},
+			from:  20,
+			want:  map[int16]int32{20: 0, 6: 1},
+		},
+		{
+			name:  "胸甲槽已占用时放入快捷栏",
+			slots: map[int]*item.ItemStack{20: {ID: chestplate, Count: 1}, 6: {ID: chestplate, Count: 1}},
+			from:  20,
+			want:  map[int16]int32{20: 0, 36: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newPlayerMenu()
+			for i, stack := range tt.slots {
+				state.Slots[i] = stack
+			}
+			res, err := SimulateClick(state, nil, tt.from, 0, ClickQuickMove, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Changed) != len(tt.want) {
+				t.Errorf("变化槽位 = %v, want %v", res.Changed, tt.want)
+			}
+			for slot, count := range tt.want {
+				stack, ok := res.Changed[slot]
+				if !ok || (count == 0) != stack.IsEmpty() || (count > 0 && stack.Count != count) {
+					t.Errorf("槽位 %d = %v, want %d", slot, stack, count)
+				}
+			}
+		})
+	}
+}
+
+func TestSimulateClick_SwapHotbar(t *testing.T) {
+	stone := itemID(t, "stone")
+	state := newPlayerMenu()
+	state.Slots[10] = &item.ItemStack{ID: stone, Count: 1}
+
+	res, err := SimulateClick(state, nil, 10, 2, ClickSwap, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Changed[38] == nil || res.Changed[38].ID != stone {
+		t.Errorf("期望物品交换到快捷栏第 3 格 (槽位 38), 实际 %+v", res.Changed)
+	}
+}
+
+func TestSimulateClick_SwapOffhandInChest(t *testing.T) {
+	stone, dirt := itemID(t, "stone"), itemID(t, "dirt")
+	p := NewPlayer()
+	p.UpdatePlayerInventorySlot(40, &item.ItemStack{ID: dirt, Count: 3})
+	chest := newChestState(27)
+	chest.Slots[0] = &item.ItemStack{ID: stone, Count: 5}
+	p.SetOpenContainer(chest)
+
+	state := p.GetActiveContainer()
+	res, err := SimulateClick(state, nil, 0, offhandButton, ClickSwap, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Changed) != 1 || res.Changed[0].ID != dirt || !res.OffhandChanged || res.Offhand.ID != stone {
+		t.Fatalf("副手交换结果 = %+v", res)
+	}
+	p.ApplyClick(state.WindowID, res)
+	if off := p.Inventory.GetOffhand(); off == nil || off.ID != stone || off.Count != 5 {
+		t.Errorf("副手 = %+v, 期望 5 个石头", off)
+	}
+}
+
+func TestSimulateClick_PickupAllSkipsFullStacksFirst(t *testing.T) {
+	stone := itemID(t, "stone")
+	state := newChestState(27)
+	state.Slots[0] = &item.ItemStack{ID: stone, Count: 64}
+	state.Slots[1] = &item.ItemStack{ID: stone, Count: 10}
+
+	res, err := SimulateClick(state, &item.ItemStack{ID: stone, Count: 1}, 1, 0, ClickPickupAll, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Carried.Count != 64 || res.Changed[1] != nil {
+		t.Errorf("期望先收集未满的堆叠, 实际 carried=%d", res.Carried.Count)
+	}
+	if res.Changed[0] == nil || res.Changed[0].Count != 11 {
+		t.Errorf("期望满堆叠被取走 53 个, 实际 %+v", res.Changed[0])
+	}
+}
+
+func TestSimulateClick_CloneRequiresCreative(t *testing.T) {
+	state := newChestState(27)
+	state.Slots[0] = &item.ItemStack{ID: itemID(t, "stone"), Count: 1}
+
+	if _, err := SimulateClick(state, nil, 0, 2, ClickClone, false); err == nil {
+		t.Error("生存模式下复制应返回错误")
+	}
+	res, err := SimulateClick(state, nil, 0, 2, ClickClone, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Carried == nil || res.Carried.Count != 64 || len(res.Changed) != 0 {
+		t.Errorf("期望光标得到满组且槽位不变, 实际 %+v", res)
+	}
+}
+
+func TestSimulateQuickCraft_LeftSplit(t *testing.T) {
+	stone := itemID(t, "stone")
+	state := newChestState(27)
+
+	res, err := SimulateQuickCraft(state, &item.ItemStack{ID: stone, Count: 10}, []int16{0, 1, 2}, QuickCraftLeft, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []int16{0, 1, 2} {
+		if res.Changed[s] == nil || res.Changed[s].Count != 3 {
+			t.Errorf("槽位 %d 期望 3 个, 实际 %+v", s, res.Changed[s])
+		}
+	}
+	if res.Carried == nil || res.Carried.Count != 1 {
+		t.Errorf("期望光标剩余 1 个, 实际 %+v", res.Carried)
+	}
+}
+
+func TestSimulateQuickCraft_LeftFewerItemsThanSlots(t *testing.T) {
+	stone := itemID(t, "stone")
+	state := newChestState(27)
+
+	// 3 个物品拖过 5 格: 只有前 3 格被加入，每格 1 个
+	res, err := SimulateQuickCraft(state, &item.ItemStack{ID: stone, Count: 3}, []int16{0, 1, 2, 3, 4}, QuickCraftLeft, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Changed) != 3 {
+		t.Errorf("期望 3 个槽位变化, 实际 %+v", res.Changed)
+	}
+	for _, s := range []int16{0, 1, 2} {
+		if res.Changed[s] == nil || res.Changed[s].Count != 1 {
+			t.Errorf("槽位 %d 期望 1 个, 实际 %+v", s, res.Changed[s])
+		}
+	}
+	if !res.Carried.IsEmpty() {
+		t.Errorf("期望光标为空, 实际 %+v", res.Carried)
+	}
+}
+
+func TestPlayer_ContainerSlotsSyncInventory(t *testing.T) {
+	stone := itemID(t, "stone")
+	p := NewPlayer()
+	p.SetOpenContainer(&ContainerState{WindowID: 2, Open: true})
+
+	stacks := make([]*item.ItemStack, 27+playerSectionSlots)
+	stacks[27] = &item.ItemStack{ID: stone, Count: 5} // 玩家主背包第一格
+	p.UpdateInventory(2, stacks, nil)
+
+	if got := p.Inventory.GetSlot(9); got == nil || got.Count != 5 {
+		t.Fatalf("期望同步到玩家背包槽位 9, 实际 %+v", got)
+	}
+
+	p.UpdateSlot(-1, -1, &item.ItemStack{ID: stone, Count: 2})
+	if c := p.GetCarried(); c == nil || c.Count != 2 {
+		t.Errorf("期望光标物品被更新, 实际 %+v", c)
+	}
+
+	p.UpdateContainerStateID(2, 42)
+	if s := p.GetActiveContainer(); s.StateID != 42 || s.WindowID != 2 {
+		t.Errorf("期望 stateID=42, 实际 %+v", s)
+	}
+}
@@ -2,91 +2,133 @@ package player
 
 import (
 	"sync"
+
+	"gmcc/internal/item"
 )
 
-type Item struct {
-	ID           string
-	Count        int32
-	Damage       int32
-	DisplayName  string
-	Lore         []string
-	Enchantments map[string]int32
-	NBT          map[string]any
-}
+// 玩家背包窗口 (windowID=0) 的槽位布局
+const (
+	SlotCraftResult = 0  // 合成结果
+	SlotCraftStart  = 1  // 2x2 合成格 1-4
+	SlotArmorStart  = 5  // 盔甲 5-8 (头、胸、腿、脚)
+	SlotMainStart   = 9  // 主背包 9-35
+	SlotHotbarStart = 36 // 快捷栏 36-44
+	SlotOffhand     = 45 // 副手
+)
 
+// Inventory 玩家背包，按玩家背包窗口的槽位编号存储
 type Inventory struct {
 	mu    sync.RWMutex
-	slots map[int8]*Item
+	slots map[int8]*item.ItemStack
 }
 
 func NewInventory() *Inventory {
 	return &Inventory{
-		slots: make(map[int8]*Item),
+		slots: make(map[int8]*item.ItemStack),
 	}
 }
 
-func (i *Inventory) SetSlot(slot int8, item *Item) {
+func (i *Inventory) SetSlot(slot int8, stack *item.ItemStack) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
-	if item == nil || item.Count == 0 {
+	if stack.IsEmpty() {
 		delete(i.slots, slot)
 	} else {
-		i.slots[slot] = item
+		i.slots[slot] = stack.Clone()
 	}
 }
 
-func (i *Inventory) GetSlot(slot int8) *Item {
+func (i *Inventory) GetSlot(slot int8) *item.ItemStack {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
 	return i.slots[slot]
 }
 
-func (i *Inventory) GetAll() map[int8]*Item {
+func (i *Inventory) GetAll() map[int8]*item.ItemStack {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
-	result := make(map[int8]*Item, len(i.slots))
+	result := make(map[int8]*item.ItemStack, len(i.slots))
 	for k, v := range i.slots {
 		result[k] = v
 	}
 	return result
 }
 
-func (i *Inventory) GetHotbar() []*Item {
-	i.mu.RLock()
-	defer i.mu.RUnlock()
-	hotbar := make([]*Item, 9)
-	for j := int8(0); j < 9; j++ {
-		hotbar[j] = i.slots[j]
+// GetHotbarSlot 返回快捷栏第 n 格 (0-8)
+func (i *Inventory) GetHotbarSlot(n int) *item.ItemStack {
+	if n < 0 || n > 8 {
+		return nil
 	}
-	return hotbar
+	return i.GetSlot(int8(SlotHotbarStart + n))
+}
+
+func (i *Inventory) GetHotbar() []*item.ItemStack {
+	return i.rangeSlots(SlotHotbarStart, 9)
+}
+
+func (i *Inventory) GetMainInventory() []*item.ItemStack {
+	return i.rangeSlots(SlotMainStart, 27)
+}
+
+// GetArmor 按头、胸、腿、脚的顺序返回盔甲
+func (i *Inventory) GetArmor() []*item.ItemStack {
+	return i.rangeSlots(SlotArmorStart, 4)
 }
 
-func (i *Inventory) GetMainInventory() []*Item {
+func (i *Inventory) GetOffhand() *item.ItemStack {
+	return i.GetSlot(SlotOffhand)
+}
+
+// FindItem 在主背包与快捷栏中查找第一个匹配 name (如 minecraft:bread) 的物品
+func (i *Inventory) FindItem(name string) (int8, *item.ItemStack) {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
-	main := make([]*Item, 27)
-	for j := int8(0); j < 27; j++ {
-		main[j] = i.slots[j+9]
+	for slot := int8(SlotMainStart); slot < SlotOffhand; slot++ {
+		if stack := i.slots[slot]; !stack.IsEmpty() && stack.Name() == name {
+			return slot, stack
+		}
 	}
-	return main
+	return -1, nil
 }
 
-func (i *Inventory) GetArmor() []*Item {
+// CountItem 统计主背包、快捷栏与副手中 name 物品的总数
+func (i *Inventory) CountItem(name string) int32 {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
-	armor := make([]*Item, 4)
-	for j := int8(0); j < 4; j++ {
-		armor[j] = i.slots[j+36]
+	var total int32
+	for slot := int8(SlotMainStart); slot <= SlotOffhand; slot++ {
+		if stack := i.slots[slot]; !stack.IsEmpty() && stack.Name() == name {
+			total += stack.Count
+		}
 	}
-	return armor
+	return total
 }
 
-func (i *Inventory) GetOffhand() *Item {
-	return i.GetSlot(40)
+// ItemCounts 统计主背包与快捷栏中各物品 ID 的总数 (配方书可使用的物品)
+func (i *Inventory) ItemCounts() map[int32]int32 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	counts := make(map[int32]int32)
+	for slot := int8(SlotMainStart); slot < SlotOffhand; slot++ {
+		if stack := i.slots[slot]; !stack.IsEmpty() {
+			counts[stack.ID] += stack.Count
+		}
+	}
+	return counts
 }
 
 func (i *Inventory) Clear() {
 	i.mu.Lock()
 	defer i.mu.Unlock()
-	i.slots = make(map[int8]*Item)
+	i.slots = make(map[int8]*item.ItemStack)
+}
+
+func (i *Inventory) rangeSlots(start, n int) []*item.ItemStack {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	result := make([]*item.ItemStack, n)
+	for j := 0; j < n; j++ {
+		result[j] = i.slots[int8(start+j)]
+	}
+	return result
 }
@@ -0,0 +1,90 @@
+package player
+
+import (
+	"fmt"
+	"math"
+
+	"gmcc/internal/item"
+)
+
+// 交易窗口的槽位，之后是玩家背包
+const (
+	SlotMerchantPaymentA = 0
+	SlotMerchantPaymentB = 1
+	SlotMerchantResult   = 2
+)
+
+// MerchantOffer 一项交易 (merchant_offers)
+type MerchantOffer struct {
+	CostA           *item.ItemStack // 基础价格，实际数量见 Price
+	CostB           *item.ItemStack // 为 nil 表示只需一种物品
+	Result          *item.ItemStack
+	Disabled        bool // 服务端标记为缺货
+	Uses            int32
+	MaxUses         int32
+	XP              int32
+	SpecialPrice    int32 // 声望、村庄英雄等带来的价格修正，负数为折扣
+	PriceMultiplier float32
+	Demand          int32
+}
+
+// Merchant 当前打开的交易界面
+type Merchant struct {
+	WindowID   int32
+	Offers     []MerchantOffer
+	Level      int32 // 村民职业等级 1-5
+	XP         int32
+	Regular    bool // 村民为 true，流浪商人为 false
+	CanRestock bool
+}
+
+// Price 返回实际需要的第一种物品数量，与原版 MerchantOffer.getModifiedCostCount 一致
+func (o *MerchantOffer) Price() int32 {
+	if o.CostA.IsEmpty() {
+		return 0
+	}
+	base := o.CostA.Count
+	extra := max(0, int32(math.Floor(float64(float32(base*o.Demand)*o.PriceMultiplier))))
+	return min(max(base+extra+o.SpecialPrice, 1), o.CostA.MaxStackSize())
+}
+
+// OutOfStock 是否已用完交易次数
+func (o *MerchantOffer) OutOfStock() bool {
+	return o.Disabled || o.Uses >= o.MaxUses
+}
+
+// Remaining 返回补货前还能交易的次数
+func (o *MerchantOffer) Remaining() int32 {
+	if o.Disabled {
+		return 0
+	}
+	return max(0, o.MaxUses-o.Uses)
+}
+
+// Affordable 按背包物品数量 (ItemCounts) 计算最多能交易几次，不超过剩余次数
+func (o *MerchantOffer) Affordable(counts map[int32]int32) int32 {
+	n := o.Remaining()
+	if price := o.Price(); price > 0 {
+		n = min(n, counts[o.CostA.ID]/price)
+	}
+	if !o.CostB.IsEmpty() {
+		need := o.CostB.Count
+		if o.CostB.ID == o.CostA.ID {
+			need += o.Price()
+		}
+		n = min(n, counts[o.CostB.ID]/need)
+	}
+	return n
+}
+
+func (o *MerchantOffer) String() string {
+	cost := fmt.Sprintf("%d %s", o.Price(), o.CostA.DisplayName())
+	if !o.CostB.IsEmpty() {
+		cost += fmt.Sprintf(" + %d %s", o.CostB.Count, o.CostB.DisplayName())
+	}
+	s := fmt.Sprintf("%s -> %d %s (%d/%d)", cost, o.Result.Count, o.Result.DisplayName(), o.Uses, o.MaxUses)
+	if o.OutOfStock() {
+		s += " 缺货"
+	}
+	return s
+}
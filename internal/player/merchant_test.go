@@ -0,0 +1,57 @@
+package player
+
+import (
+	"strings"
+	"testing"
+
+	"gmcc/internal/item"
+)
+
+func TestMerchantOfferPrice(t *testing.T) {
+	wheat, emerald, pearl := itemID(t, "wheat"), itemID(t, "emerald"), itemID(t, "ender_pearl")
+	tests := []struct {
+		name  string
+		offer MerchantOffer
+		want  int32
+	}{
+		{"基础价格", MerchantOffer{CostA: &item.ItemStack{ID: wheat, Count: 20}, PriceMultiplier: 0.05}, 20},
+		{"需求涨价", MerchantOffer{CostA: &item.ItemStack{ID: wheat, Count: 20}, Demand: 4, PriceMultiplier: 0.05}, 24},
+		{"负需求不降价", MerchantOffer{CostA: &item.ItemStack{ID: wheat, Count: 20}, Demand: -8, PriceMultiplier: 0.05}, 20},
+		{"折扣", MerchantOffer{CostA: &item.ItemStack{ID: wheat, Count: 20}, Demand: 4, PriceMultiplier: 0.05, SpecialPrice: -7}, 17},
+		{"最低为 1", MerchantOffer{CostA: &item.ItemStack{ID: emerald, Count: 1}, SpecialPrice: -5}, 1},
+		{"不超过最大堆叠", MerchantOffer{CostA: &item.ItemStack{ID: pearl, Count: 10}, SpecialPrice: 10}, 16},
+	}
+	for _, tt := range tests {
+		if got := tt.offer.Price(); got != tt.want {
+			t.Errorf("%s: Price() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMerchantOfferAffordable(t *testing.T) {
+	emerald, book, enchanted := itemID(t, "emerald"), itemID(t, "book"), itemID(t, "enchanted_book")
+	offer := MerchantOffer{
+		CostA:   &item.ItemStack{ID: emerald, Count: 10},
+		CostB:   &item.ItemStack{ID: book, Count: 1},
+		Result:  &item.ItemStack{ID: enchanted, Count: 1},
+		Uses:    9,
+		MaxUses: 12,
+	}
+	if n := offer.Affordable(map[int32]int32{emerald: 64, book: 2}); n != 2 {
+		t.Errorf("Affordable() = %d, want 2 (书不足)", n)
+	}
+	if n := offer.Affordable(map[int32]int32{emerald: 64, book: 64}); n != 3 {
+		t.Errorf("Affordable() = %d, want 3 (剩余次数)", n)
+	}
+	if n := offer.Affordable(map[int32]int32{emerald: 25, book: 64}); n != 2 {
+		t.Errorf("Affordable() = %d, want 2 (绿宝石不足)", n)
+	}
+
+	if s := offer.String(); !strings.HasPrefix(s, "10 ") || !strings.HasSuffix(s, "(9/12)") {
+		t.Errorf("String() = %q", s)
+	}
+	offer.Disabled = true
+	if !offer.OutOfStock() || offer.Remaining() != 0 || !strings.HasSuffix(offer.String(), "缺货") {
+		t.Errorf("缺货的交易 = %+v", offer)
+	}
+}
@@ -90,14 +90,17 @@ func (nt *NearbyTracker) handleEntityMove(e *entity.Entity, oldPos entity.Positi
 		return
 	}
 
-	nt.mu.RLock()
-	player, exists := nt.players[e.ID]
-	callbacks := nt.callbacks
-	nt.mu.RUnlock()
-
+	nt.mu.Lock()
+	old, exists := nt.players[e.ID]
 	if !exists {
+		nt.mu.Unlock()
 		return
 	}
+	// 替换而不是原地修改，已返回给调用方的快照保持不变
+	player := &NearbyPlayer{Entity: e, Username: old.Username}
+	nt.players[e.ID] = player
+	callbacks := nt.callbacks
+	nt.mu.Unlock()
 
 	if callbacks.OnPlayerMove != nil {
 		go callbacks.OnPlayerMove(player, oldPos)
@@ -151,16 +154,16 @@ func (nt *NearbyTracker) GetNearbyPlayer(uuid [16]byte) (*NearbyPlayer, bool) {
 	return nil, false
 }
 
-// PlayersWithinDistance 获取指定距离内的玩家
+// PlayersWithinDistance 获取实际距离不超过 distance 的玩家，使用实体跟踪器的空间索引
 func (nt *NearbyTracker) PlayersWithinDistance(center entity.Position, distance float64) []*NearbyPlayer {
+	entities := nt.entityTracker.WithinRadius(center, distance, entity.Players)
+
 	nt.mu.RLock()
 	defer nt.mu.RUnlock()
 
-	distanceSq := distance * distance
-	result := make([]*NearbyPlayer, 0)
-
-	for _, p := range nt.players {
-		if p.DistanceTo(center) <= distanceSq {
+	result := make([]*NearbyPlayer, 0, len(entities))
+	for _, e := range entities {
+		if p, ok := nt.players[e.ID]; ok {
 			result = append(result, p)
 		}
 	}
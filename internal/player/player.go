@@ -1,32 +1,15 @@
 package player
 
 import (
-	"fmt"
 	"sync"
 	"time"
+
+	"gmcc/internal/item"
+	"gmcc/internal/recipe"
 )
 
 type GameMode int
 
-type SlotData struct {
-	ID    int32
-	Count int32
-}
-
-type ContainerState struct {
-	WindowID   int32
-	WindowType int32
-	StateID    int32
-	Open       bool
-}
-
-func (s *SlotData) IDToString() string {
-	if s == nil || s.ID == 0 {
-		return ""
-	}
-	return fmt.Sprintf("minecraft:%d", s.ID)
-}
-
 const (
 	GameModeSurvival GameMode = iota
 	GameModeCreative
@@ -34,6 +17,12 @@ const (
 	GameModeSpectator
 )
 
+// GlobalPos 带维度的方块坐标
+type GlobalPos struct {
+	Dimension string
+	X, Y, Z   int32
+}
+
 type Player struct {
 	mu sync.RWMutex
 
@@ -42,6 +31,8 @@ type Player struct {
 	Name      string
 	GameMode  GameMode
 	Dimension string
+	// 服务器记录的上次死亡位置，login/respawn 下发，nil 表示没有
+	LastDeath *GlobalPos
 
 	X, Y, Z    float64
 	Yaw, Pitch float32
@@ -67,30 +58,35 @@ type Player struct {
 	Inventory     *Inventory
 	HeldSlot      int8
 	OpenContainer *ContainerState
+	InventoryMenu *ContainerState
+	Carried       *item.ItemStack
+	Recipes       *recipe.Book
 
 	JoinTime   time.Time
 	LastUpdate time.Time
 
 	OnHealthChange    func(health, maxHealth float32, food int32)
 	OnPositionChange  func(x, y, z float64)
-	OnInventoryChange func(slot int8, item *Item)
+	OnInventoryChange func(slot int8, stack *item.ItemStack)
 	OnGameModeChange  func(mode GameMode)
 }
 
 func NewPlayer() *Player {
 	return &Player{
-		Inventory:    NewInventory(),
-		Health:       20,
-		MaxHealth:    20,
-		Food:         20,
-		Saturation:   5,
-		Air:          300,
-		EntityHealth: 20,
-		JoinTime:     time.Now(),
-		LastUpdate:   time.Now(),
-		FlyingSpeed:  0.05,
-		FieldOfView:  0.1,
-		OnGround:     true,
+		Inventory:     NewInventory(),
+		InventoryMenu: newPlayerMenu(),
+		Recipes:       recipe.NewBook(),
+		Health:        20,
+		MaxHealth:     20,
+		Food:          20,
+		Saturation:    5,
+		Air:           300,
+		EntityHealth:  20,
+		JoinTime:      time.Now(),
+		LastUpdate:    time.Now(),
+		FlyingSpeed:   0.05,
+		FieldOfView:   0.1,
+		OnGround:      true,
 	}
 }
 
@@ -100,6 +96,12 @@ func (p *Player) SetEntityID(id int32) {
 	p.EntityID = id
 }
 
+func (p *Player) GetEntityID() int32 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.EntityID
+}
+
 func (p *Player) SetUUID(uuid [16]byte) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -124,12 +126,36 @@ func (p *Player) SetGameMode(mode GameMode) {
 	}
 }
 
+func (p *Player) GetGameMode() GameMode {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.GameMode
+}
+
 func (p *Player) SetDimension(dim string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	p.Dimension = dim
 }
 
+func (p *Player) GetDimension() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Dimension
+}
+
+func (p *Player) SetLastDeath(pos *GlobalPos) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.LastDeath = pos
+}
+
+func (p *Player) GetLastDeath() *GlobalPos {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.LastDeath
+}
+
 func (p *Player) UpdatePosition(x, y, z float64, yaw, pitch float32, relative int8) {
 	p.mu.Lock()
 	if relative&0x01 != 0 {
@@ -164,6 +190,13 @@ func (p *Player) GetPosition() (float64, float64, float64) {
 	return p.X, p.Y, p.Z
 }
 
+// SetRotation 设置视角，随下一个游戏刻的位置包发送给服务端
+func (p *Player) SetRotation(yaw, pitch float32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Yaw, p.Pitch = yaw, pitch
+}
+
 func (p *Player) GetRotation() (float32, float32) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -281,31 +314,99 @@ func (p *Player) GetOpenContainer() *ContainerState {
 	return p.OpenContainer
 }
 
-func (p *Player) UpdateContainerStateID(stateID int32) {
+func (p *Player) UpdateContainerStateID(windowID, stateID int32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if state := p.containerLocked(windowID); state != nil {
+		state.StateID = stateID
+	}
+}
+
+// GetActiveContainer 返回当前可操作窗口的副本 (已打开的容器优先，否则为玩家背包)
+func (p *Player) GetActiveContainer() *ContainerState {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.OpenContainer != nil && p.OpenContainer.Open {
+		state := p.OpenContainer.Clone()
+		state.Offhand = p.InventoryMenu.Slot(playerMenuSlots - 1).Clone()
+		return state
+	}
+	return p.InventoryMenu.Clone()
+}
+
+func (p *Player) GetCarried() *item.ItemStack {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Carried.Clone()
+}
+
+func (p *Player) SetCarried(stack *item.ItemStack) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Carried = stack.Clone()
+}
+
+// ApplyClick 将客户端预测的点击结果写入窗口状态
+func (p *Player) ApplyClick(windowID int32, result *ClickResult) {
+	if result == nil {
+		return
+	}
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	if p.OpenContainer != nil {
-		p.OpenContainer.StateID = stateID
+	state := p.containerLocked(windowID)
+	if state == nil {
+		return
 	}
+	for slot, stack := range result.Changed {
+		p.setContainerSlotLocked(state, int(slot), stack)
+	}
+	if result.OffhandChanged {
+		p.setContainerSlotLocked(p.InventoryMenu, playerMenuSlots-1, result.Offhand)
+	}
+	p.Carried = result.Carried.Clone()
 }
 
-func (p *Player) GetHeldItem() *Item {
+func (p *Player) containerLocked(windowID int32) *ContainerState {
+	if windowID == 0 {
+		return p.InventoryMenu
+	}
+	if p.OpenContainer != nil && p.OpenContainer.WindowID == windowID {
+		return p.OpenContainer
+	}
+	return nil
+}
+
+// setContainerSlotLocked 更新窗口槽位，并同步到玩家背包
+func (p *Player) setContainerSlotLocked(state *ContainerState, slot int, stack *item.ItemStack) {
+	state.setSlot(slot, stack)
+	invSlot, ok := state.inventorySlot(slot)
+	if !ok {
+		return
+	}
+	if state != p.InventoryMenu {
+		p.InventoryMenu.setSlot(invSlot, stack)
+	}
+	p.Inventory.SetSlot(int8(invSlot), stack)
+}
+
+// GetHeldItem 返回主手物品 (快捷栏当前选中格)
+func (p *Player) GetHeldItem() *item.ItemStack {
 	p.mu.RLock()
 	slot := p.HeldSlot
 	p.mu.RUnlock()
-	return p.Inventory.GetSlot(slot)
+	return p.Inventory.GetHotbarSlot(int(slot))
 }
 
-func (p *Player) UpdateInventorySlot(windowID int8, stateID int32, slot int8, item *Item) {
+func (p *Player) UpdateInventorySlot(windowID int8, stateID int32, slot int8, stack *item.ItemStack) {
 	if windowID != 0 {
 		return
 	}
-	p.Inventory.SetSlot(slot, item)
+	p.Inventory.SetSlot(slot, stack)
 	p.mu.RLock()
 	cb := p.OnInventoryChange
 	p.mu.RUnlock()
 	if cb != nil {
-		go cb(slot, item)
+		go cb(slot, stack)
 	}
 }
 
@@ -313,40 +414,60 @@ func (p *Player) ClearInventory() {
 	p.Inventory.Clear()
 }
 
-func (p *Player) UpdateInventory(windowID int32, items []*SlotData, carriedItem *SlotData) {
-	if windowID != 0 {
+func (p *Player) UpdateInventory(windowID int32, stacks []*item.ItemStack, carriedItem *item.ItemStack) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	state := p.containerLocked(windowID)
+	if state == nil {
 		return
 	}
-	p.mu.Lock()
-	p.Inventory.Clear()
-	for i, item := range items {
-		if item != nil && item.Count > 0 {
-			p.Inventory.SetSlot(int8(i), &Item{
-				ID:    item.IDToString(),
-				Count: item.Count,
-			})
-		}
-	}
-	if carriedItem != nil && carriedItem.Count > 0 {
-		p.Inventory.SetSlot(-1, &Item{
-			ID:    carriedItem.IDToString(),
-			Count: carriedItem.Count,
-		})
+	if windowID == 0 {
+		p.Inventory.Clear()
 	}
-	p.mu.Unlock()
+	state.Slots = make([]*item.ItemStack, len(stacks))
+	for i, stack := range stacks {
+		p.setContainerSlotLocked(state, i, stack)
+	}
+	p.Carried = carriedItem.Clone()
 }
 
-func (p *Player) UpdateSlot(windowID int32, slot int32, item *SlotData) {
-	if windowID != 0 {
+func (p *Player) UpdateSlot(windowID int32, slot int32, stack *item.ItemStack) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// windowID = -1 且 slot = -1 表示光标上的物品
+	if windowID == -1 && slot == -1 {
+		p.Carried = stack.Clone()
+		return
+	}
+	state := p.containerLocked(windowID)
+	if state == nil {
+		return
+	}
+	p.setContainerSlotLocked(state, int(slot), stack)
+}
+
+// UpdatePlayerInventorySlot 处理 set_player_inventory，slot 为背包编号
+// (0-8 快捷栏, 9-35 主背包, 36-39 盔甲 脚->头, 40 副手)
+func (p *Player) UpdatePlayerInventorySlot(slot int32, stack *item.ItemStack) {
+	var menuSlot int
+	switch {
+	case slot >= 0 && slot <= 8:
+		menuSlot = 36 + int(slot)
+	case slot >= 9 && slot <= 35:
+		menuSlot = int(slot)
+	case slot >= 36 && slot <= 39:
+		menuSlot = 8 - int(slot-36)
+	case slot == 40:
+		menuSlot = playerMenuSlots - 1
+	default:
 		return
 	}
-	if item != nil && item.Count > 0 {
-		p.Inventory.SetSlot(int8(slot), &Item{
-			ID:    item.IDToString(),
-			Count: item.Count,
-		})
-	} else {
-		p.Inventory.SetSlot(int8(slot), nil)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.setContainerSlotLocked(p.InventoryMenu, menuSlot, stack)
+	if open := p.OpenContainer; open != nil && len(open.Slots) >= playerSectionSlots && menuSlot >= 9 && menuSlot < 45 {
+		open.setSlot(open.PlayerSlotOffset()+menuSlot-9, stack)
 	}
 }
 
@@ -0,0 +1,240 @@
+package player
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TabAction player_info_update 的动作位，表示本次更新包含哪些字段
+type TabAction uint8
+
+const (
+	TabAddPlayer         TabAction = 0x01
+	TabInitializeChat    TabAction = 0x02
+	TabUpdateGameMode    TabAction = 0x04
+	TabUpdateListed      TabAction = 0x08
+	TabUpdateLatency     TabAction = 0x10
+	TabUpdateDisplayName TabAction = 0x20
+	TabUpdateListOrder   TabAction = 0x40
+	TabUpdateShowHat     TabAction = 0x80
+)
+
+// ProfileProperty 玩家档案属性，如皮肤 textures
+type ProfileProperty struct {
+	Name      string
+	Value     string
+	Signature string // 为空表示未签名
+}
+
+// ChatSession 玩家的聊天签名会话
+type ChatSession struct {
+	SessionID    [16]byte
+	ExpiresAt    time.Time
+	PublicKey    []byte // X.509 DER
+	KeySignature []byte // Mojang 对公钥的签名
+}
+
+// TabEntry Tab 列表中的一个玩家
+type TabEntry struct {
+	UUID        [16]byte
+	Name        string
+	Properties  []ProfileProperty
+	ChatSession *ChatSession // nil 表示没有签名会话
+	GameMode    GameMode
+	Listed      bool
+	Latency     int32  // 毫秒
+	DisplayName string // JSON 文本组件，为空时显示 Name
+	ListOrder   int32
+	ShowHat     bool
+}
+
+// TabListCallbacks Tab 列表事件回调
+type TabListCallbacks struct {
+	OnAdd          func(e TabEntry)
+	OnUpdate       func(e TabEntry, changed TabAction)
+	OnRemove       func(e TabEntry)
+	OnHeaderFooter func(header, footer string)
+}
+
+// TabList 维护 player_info_update / player_info_remove / tab_list 下发的玩家列表
+type TabList struct {
+	mu        sync.RWMutex
+	entries   map[[16]byte]*TabEntry
+	header    string
+	footer    string
+	callbacks TabListCallbacks
+}
+
+func NewTabList() *TabList {
+	return &TabList{entries: make(map[[16]byte]*TabEntry)}
+}
+
+// SetCallbacks 设置事件回调
+func (t *TabList) SetCallbacks(callbacks TabListCallbacks) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.callbacks = callbacks
+}
+
+// Update 按 actions 合并一条更新，update 中只有 actions 对应的字段有效。
+// 带 TabAddPlayer 时新建条目，否则更新已有条目；未知玩家返回 false。
+func (t *TabList) Update(actions TabAction, update TabEntry) bool {
+	t.mu.Lock()
+	entry, exists := t.entries[update.UUID]
+	added := actions&TabAddPlayer != 0
+	if added {
+		entry = &TabEntry{UUID: update.UUID, Name: update.Name, Properties: update.Properties}
+	} else if !exists {
+		t.mu.Unlock()
+		return false
+	} else {
+		// 替换而不是原地修改，已返回给调用方的快照保持不变
+		copied := *entry
+		entry = &copied
+	}
+
+	if actions&TabInitializeChat != 0 {
+		entry.ChatSession = update.ChatSession
+	}
+	if actions&TabUpdateGameMode != 0 {
+		entry.GameMode = update.GameMode
+	}
+	if actions&TabUpdateListed != 0 {
+		entry.Listed = update.Listed
+	}
+	if actions&TabUpdateLatency != 0 {
+		entry.Latency = update.Latency
+	}
+	if actions&TabUpdateDisplayName != 0 {
+		entry.DisplayName = update.DisplayName
+	}
+	if actions&TabUpdateListOrder != 0 {
+		entry.ListOrder = update.ListOrder
+	}
+	if actions&TabUpdateShowHat != 0 {
+		entry.ShowHat = update.ShowHat
+	}
+	t.entries[entry.UUID] = entry
+	callbacks := t.callbacks
+	t.mu.Unlock()
+
+	if added && callbacks.OnAdd != nil {
+		go callbacks.OnAdd(*entry)
+	} else if !added && callbacks.OnUpdate != nil {
+		go callbacks.OnUpdate(*entry, actions)
+	}
+	return true
+}
+
+// Remove 移除玩家
+func (t *TabList) Remove(uuids [][16]byte) {
+	t.mu.Lock()
+	removed := make([]TabEntry, 0, len(uuids))
+	for _, uuid := range uuids {
+		if entry, ok := t.entries[uuid]; ok {
+			delete(t.entries, uuid)
+			removed = append(removed, *entry)
+		}
+	}
+	callbacks := t.callbacks
+	t.mu.Unlock()
+
+	if callbacks.OnRemove != nil {
+		for _, entry := range removed {
+			go callbacks.OnRemove(entry)
+		}
+	}
+}
+
+// SetHeaderFooter 设置 Tab 列表页眉页脚 (JSON 文本组件)
+func (t *TabList) SetHeaderFooter(header, footer string) {
+	t.mu.Lock()
+	t.header, t.footer = header, footer
+	callbacks := t.callbacks
+	t.mu.Unlock()
+
+	if callbacks.OnHeaderFooter != nil {
+		go callbacks.OnHeaderFooter(header, footer)
+	}
+}
+
+// HeaderFooter 返回页眉页脚
+func (t *TabList) HeaderFooter() (header, footer string) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.header, t.footer
+}
+
+// Get 通过 UUID 查找玩家
+func (t *TabList) Get(uuid [16]byte) (TabEntry, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if entry, ok := t.entries[uuid]; ok {
+		return *entry, true
+	}
+	return TabEntry{}, false
+}
+
+// GetByName 通过名字查找玩家 (不区分大小写)
+func (t *TabList) GetByName(name string) (TabEntry, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, entry := range t.entries {
+		if strings.EqualFold(entry.Name, name) {
+			return *entry, true
+		}
+	}
+	return TabEntry{}, false
+}
+
+// Entries 返回所有玩家，包括不在 Tab 中显示的
+func (t *TabList) Entries() []TabEntry {
+	return t.collect(false)
+}
+
+// Listed 返回在 Tab 中显示的玩家，按客户端的显示顺序排序
+func (t *TabList) Listed() []TabEntry {
+	return t.collect(true)
+}
+
+func (t *TabList) collect(listedOnly bool) []TabEntry {
+	t.mu.RLock()
+	result := make([]TabEntry, 0, len(t.entries))
+	for _, entry := range t.entries {
+		if !listedOnly || entry.Listed {
+			result = append(result, *entry)
+		}
+	}
+	t.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool { return tabLess(result[i], result[j]) })
+	return result
+}
+
+// tabLess 与原版 PlayerTabOverlay 一致: list_order 大的在前，旁观者在后，再按名字排序
+func tabLess(a, b TabEntry) bool {
+	if a.ListOrder != b.ListOrder {
+		return a.ListOrder > b.ListOrder
+	}
+	if aSpec, bSpec := a.GameMode == GameModeSpectator, b.GameMode == GameModeSpectator; aSpec != bSpec {
+		return bSpec
+	}
+	return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+}
+
+// Len 返回玩家数量
+func (t *TabList) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.entries)
+}
+
+// Clear 清空列表 (切换服务器时)
+func (t *TabList) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = make(map[[16]byte]*TabEntry)
+	t.header, t.footer = "", ""
+}
@@ -0,0 +1,74 @@
+package player
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTabList_Update(t *testing.T) {
+	tl := NewTabList()
+	updates := make(chan TabAction, 4)
+	tl.SetCallbacks(TabListCallbacks{
+		OnUpdate: func(e TabEntry, changed TabAction) { updates <- changed },
+	})
+
+	alice := [16]byte{1}
+	tl.Update(TabAddPlayer|TabUpdateListed|TabUpdateLatency, TabEntry{UUID: alice, Name: "Alice", Listed: true, Latency: 40})
+	snapshot, _ := tl.Get(alice)
+
+	if !tl.Update(TabUpdateLatency|TabUpdateGameMode, TabEntry{UUID: alice, Latency: 120, GameMode: GameModeCreative}) {
+		t.Fatal("更新已有玩家失败")
+	}
+	got, ok := tl.Get(alice)
+	if !ok || got.Name != "Alice" || !got.Listed || got.Latency != 120 || got.GameMode != GameModeCreative {
+		t.Errorf("Get() = %+v", got)
+	}
+	if snapshot.Latency != 40 {
+		t.Errorf("旧快照被修改: %+v", snapshot)
+	}
+
+	select {
+	case changed := <-updates:
+		if changed != TabUpdateLatency|TabUpdateGameMode {
+			t.Errorf("OnUpdate changed = 0x%02x", changed)
+		}
+	case <-time.After(time.Second):
+		t.Error("未触发 OnUpdate")
+	}
+
+	if tl.Update(TabUpdateLatency, TabEntry{UUID: [16]byte{9}}) {
+		t.Error("未知玩家不应更新成功")
+	}
+
+	tl.Remove([][16]byte{alice})
+	if tl.Len() != 0 {
+		t.Errorf("Remove 后 Len() = %d", tl.Len())
+	}
+}
+
+func TestTabList_ListedOrder(t *testing.T) {
+	tl := NewTabList()
+	add := func(id byte, name string, listed bool, order int32, mode GameMode) {
+		tl.Update(TabAddPlayer|TabUpdateListed|TabUpdateListOrder|TabUpdateGameMode,
+			TabEntry{UUID: [16]byte{id}, Name: name, Listed: listed, ListOrder: order, GameMode: mode})
+	}
+	add(1, "zed", true, 0, GameModeSurvival)
+	add(2, "Bob", true, 0, GameModeSpectator)
+	add(3, "amy", true, 0, GameModeSurvival)
+	add(4, "admin", true, 10, GameModeCreative)
+	add(5, "hidden", false, 0, GameModeSurvival)
+
+	want := []string{"admin", "amy", "zed", "Bob"}
+	got := tl.Listed()
+	if len(got) != len(want) {
+		t.Fatalf("Listed() = %d 人, want %d", len(got), len(want))
+	}
+	for i, e := range got {
+		if e.Name != want[i] {
+			t.Errorf("Listed()[%d] = %s, want %s", i, e.Name, want[i])
+		}
+	}
+	if len(tl.Entries()) != 5 {
+		t.Errorf("Entries() = %d 人, want 5", len(tl.Entries()))
+	}
+}
@@ -0,0 +1,240 @@
+package recipe
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/registry"
+)
+
+// recipe_book_add 条目标志
+const (
+	FlagNotification byte = 0x01 // 解锁时弹出提示
+	FlagHighlight    byte = 0x02 // 在配方书中高亮
+)
+
+// BookType 配方书分类 (背包/工作台、熔炉、高炉、烟熏炉)
+type BookType int
+
+const (
+	BookCrafting BookType = iota
+	BookFurnace
+	BookBlastFurnace
+	BookSmoker
+	bookTypeCount
+)
+
+// Ingredient 一个配方格可接受的物品集合 (HolderSet<Item>)
+type Ingredient struct {
+	Tag   string  // 标签名，不含 #，为空时使用 Items
+	Items []int32 // 物品网络 ID
+}
+
+// Resolve 返回可接受的物品 ID，标签通过 update_tags 下发的数据展开
+func (in Ingredient) Resolve() []int32 {
+	if in.Tag != "" {
+		return registry.TagEntries("minecraft:item", in.Tag)
+	}
+	return in.Items
+}
+
+// Entry 配方书中一个已解锁的配方 (RecipeDisplayEntry)
+type Entry struct {
+	ID           int32 // RecipeDisplayId，place_recipe 使用
+	Display      *Display
+	Group        int32 // 0 表示不分组
+	Category     int32 // minecraft:recipe_book_category
+	Requirements []Ingredient
+	Flags        byte
+}
+
+// BookSettings 单个配方书分类的界面设置
+type BookSettings struct {
+	Open      bool
+	Filtering bool
+}
+
+// ReadIngredient 读取 HolderSet<Item>: 0 表示标签名，否则为 n-1 个物品 ID
+func ReadIngredient(r *bytes.Reader) (Ingredient, error) {
+	n, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return Ingredient{}, err
+	}
+	if n == 0 {
+		tag, err := packet.ReadStringFromReader(r)
+		return Ingredient{Tag: tag}, err
+	}
+	if n < 0 || int(n-1) > r.Len() {
+		return Ingredient{}, fmt.Errorf("物品集合长度无效: %d", n-1)
+	}
+	items := make([]int32, n-1)
+	for i := range items {
+		if items[i], err = packet.ReadVarIntFromReader(r); err != nil {
+			return Ingredient{}, err
+		}
+	}
+	return Ingredient{Items: items}, nil
+}
+
+// ReadEntry 读取 recipe_book_add 中的一个条目 (含末尾的标志字节)
+func ReadEntry(r *bytes.Reader) (*Entry, error) {
+	id, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	display, err := ReadDisplay(r)
+	if err != nil {
+		return nil, fmt.Errorf("配方 %d: %w", id, err)
+	}
+	e := &Entry{ID: id, Display: display}
+
+	// OPTIONAL_COMPACT_INT: 0 表示空，否则为 值+1
+	group, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	if group > 0 {
+		e.Group = group - 1
+	}
+	if e.Category, err = packet.ReadVarIntFromReader(r); err != nil {
+		return nil, err
+	}
+
+	hasReq, err := packet.ReadBoolFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	if hasReq {
+		n, err := packet.ReadVarIntFromReader(r)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 || int(n) > r.Len() {
+			return nil, fmt.Errorf("配方 %d: 材料数量无效: %d", id, n)
+		}
+		e.Requirements = make([]Ingredient, n)
+		for i := range e.Requirements {
+			if e.Requirements[i], err = ReadIngredient(r); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if e.Flags, err = packet.ReadU8(r); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Book 客户端配方书，保存服务端解锁的配方
+type Book struct {
+	mu       sync.RWMutex
+	entries  map[int32]*Entry
+	settings [bookTypeCount]BookSettings
+
+	// update_recipes 下发的物品属性集合 (如 minecraft:furnace_input)
+	propertySets map[string][]int32
+}
+
+func NewBook() *Book {
+	return &Book{
+		entries:      make(map[int32]*Entry),
+		propertySets: make(map[string][]int32),
+	}
+}
+
+// Add 添加配方，replace 为 true 时先清空已有配方
+func (b *Book) Add(entries []*Entry, replace bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if replace {
+		b.entries = make(map[int32]*Entry, len(entries))
+	}
+	for _, e := range entries {
+		b.entries[e.ID] = e
+	}
+}
+
+// Remove 移除配方
+func (b *Book) Remove(ids []int32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, id := range ids {
+		delete(b.entries, id)
+	}
+}
+
+// Clear 清空配方与属性集合 (断线或重新配置时调用)
+func (b *Book) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = make(map[int32]*Entry)
+	b.propertySets = make(map[string][]int32)
+	b.settings = [bookTypeCount]BookSettings{}
+}
+
+func (b *Book) Get(id int32) *Entry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.entries[id]
+}
+
+// All 返回全部配方，按 ID 排序
+func (b *Book) All() []*Entry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make([]*Entry, 0, len(b.entries))
+	for _, e := range b.entries {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// FindByResult 返回结果为指定物品的配方，按 ID 排序
+func (b *Book) FindByResult(itemID int32) []*Entry {
+	var out []*Entry
+	for _, e := range b.All() {
+		if id, _ := e.Display.Result.ResultItem(); id == itemID {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (b *Book) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.entries)
+}
+
+func (b *Book) SetSettings(settings [bookTypeCount]BookSettings) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.settings = settings
+}
+
+func (b *Book) Settings(t BookType) BookSettings {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if t < 0 || t >= bookTypeCount {
+		return BookSettings{}
+	}
+	return b.settings[t]
+}
+
+func (b *Book) SetPropertySets(sets map[string][]int32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.propertySets = sets
+}
+
+// PropertySet 返回物品属性集合 (如 minecraft:furnace_input)
+func (b *Book) PropertySet(name string) []int32 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.propertySets[name]
+}
@@ -0,0 +1,97 @@
+package recipe
+
+import "sort"
+
+// Ingredients 返回配方每个非空格子可接受的物品 ID。
+// 优先使用服务端下发的材料需求，缺失时从显示数据推断。
+func (e *Entry) Ingredients() [][]int32 {
+	var out [][]int32
+	if e.Requirements != nil {
+		for _, in := range e.Requirements {
+			out = append(out, in.Resolve())
+		}
+		return out
+	}
+	for _, s := range e.Display.Ingredients {
+		if items := s.candidates(); len(items) > 0 {
+			out = append(out, items)
+		}
+	}
+	return out
+}
+
+// candidates 展开 SlotDisplay 可代表的物品 ID
+func (s SlotDisplay) candidates() []int32 {
+	switch s.Type {
+	case SlotItem:
+		return []int32{s.ItemID}
+	case SlotItemStack:
+		if s.Stack != nil {
+			return []int32{s.Stack.ID}
+		}
+	case SlotTag:
+		return Ingredient{Tag: s.Tag}.Resolve()
+	case SlotWithRemainder:
+		if len(s.Children) > 0 {
+			return s.Children[0].candidates()
+		}
+	case SlotComposite:
+		var out []int32
+		for _, c := range s.Children {
+			out = append(out, c.candidates()...)
+		}
+		return out
+	}
+	return nil
+}
+
+// MaxCrafts 计算 counts (物品 ID -> 数量) 最多能完成多少次合成，不超过 limit。
+// 每次合成每个格子消耗一个物品；分配采用贪心，候选少的格子优先。
+func MaxCrafts(ingredients [][]int32, counts map[int32]int32, limit int) int {
+	if len(ingredients) == 0 || limit <= 0 {
+		return 0
+	}
+	order := make([]int, len(ingredients))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(ingredients[order[a]]) < len(ingredients[order[b]])
+	})
+
+	feasible := func(n int) bool {
+		left := make(map[int32]int32, len(counts))
+		for id, c := range counts {
+			left[id] = c
+		}
+		for _, i := range order {
+			need := int32(n)
+			cands := append([]int32(nil), ingredients[i]...)
+			sort.Slice(cands, func(a, b int) bool { return left[cands[a]] > left[cands[b]] })
+			for _, id := range cands {
+				if need == 0 {
+					break
+				}
+				take := min(need, left[id])
+				left[id] -= take
+				need -= take
+			}
+			if need > 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	// 可行性随 n 单调，二分查找最大值
+	lo, hi := 0, limit
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if feasible(mid) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
@@ -0,0 +1,214 @@
+package recipe
+
+import (
+	"bytes"
+	"fmt"
+
+	"gmcc/internal/item"
+	"gmcc/internal/mcclient/packet"
+)
+
+// SlotDisplayType 对应 minecraft:slot_display_type 注册表
+type SlotDisplayType int32
+
+const (
+	SlotEmpty         SlotDisplayType = 0
+	SlotAnyFuel       SlotDisplayType = 1
+	SlotItem          SlotDisplayType = 2
+	SlotItemStack     SlotDisplayType = 3
+	SlotTag           SlotDisplayType = 4
+	SlotSmithingTrim  SlotDisplayType = 5
+	SlotWithRemainder SlotDisplayType = 6
+	SlotComposite     SlotDisplayType = 7
+)
+
+// SlotDisplay 配方书中一个格子的显示内容
+type SlotDisplay struct {
+	Type     SlotDisplayType
+	ItemID   int32           // SlotItem
+	Stack    *item.ItemStack // SlotItemStack
+	Tag      string          // SlotTag，不含 #
+	Children []SlotDisplay   // SlotComposite 的候选项；SlotWithRemainder 为 [输入, 剩余物]；SlotSmithingTrim 为 [基础, 材料]
+}
+
+// DisplayType 对应 minecraft:recipe_display 注册表
+type DisplayType int32
+
+const (
+	DisplayShapeless   DisplayType = 0
+	DisplayShaped      DisplayType = 1
+	DisplayFurnace     DisplayType = 2
+	DisplayStonecutter DisplayType = 3
+	DisplaySmithing    DisplayType = 4
+)
+
+// Display 配方的显示数据 (RecipeDisplay)
+type Display struct {
+	Type          DisplayType
+	Width, Height int32         // 仅有序合成
+	Ingredients   []SlotDisplay // 有序合成按行排列，空格为 SlotEmpty
+	Fuel          SlotDisplay   // 仅熔炉类
+	Result        SlotDisplay
+	Station       SlotDisplay
+	CookingTime   int32   // 仅熔炉类 (tick)
+	Experience    float32 // 仅熔炉类
+}
+
+// ResultItem 返回结果物品 ID 与数量，无法确定时返回 -1
+func (s SlotDisplay) ResultItem() (int32, int32) {
+	switch s.Type {
+	case SlotItem:
+		return s.ItemID, 1
+	case SlotItemStack:
+		if s.Stack != nil {
+			return s.Stack.ID, s.Stack.Count
+		}
+	case SlotWithRemainder:
+		if len(s.Children) > 0 {
+			return s.Children[0].ResultItem()
+		}
+	}
+	return -1, 0
+}
+
+// IsCrafting 是否为工作台/背包合成配方
+func (d *Display) IsCrafting() bool {
+	return d.Type == DisplayShaped || d.Type == DisplayShapeless
+}
+
+// FitsGrid 判断配方能否放入 size×size 的合成格
+func (d *Display) FitsGrid(size int32) bool {
+	switch d.Type {
+	case DisplayShaped:
+		return d.Width <= size && d.Height <= size
+	case DisplayShapeless:
+		return int32(len(d.Ingredients)) <= size*size
+	}
+	return false
+}
+
+// ReadSlotDisplay 读取一个 SlotDisplay
+func ReadSlotDisplay(r *bytes.Reader) (SlotDisplay, error) {
+	t, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return SlotDisplay{}, err
+	}
+	s := SlotDisplay{Type: SlotDisplayType(t)}
+	switch s.Type {
+	case SlotEmpty, SlotAnyFuel:
+	case SlotItem:
+		s.ItemID, err = packet.ReadVarIntFromReader(r)
+	case SlotItemStack:
+		s.Stack, err = item.ReadItemStack(r)
+	case SlotTag:
+		s.Tag, err = packet.ReadStringFromReader(r)
+	case SlotSmithingTrim:
+		s.Children, err = readSlotDisplays(r, 2)
+		if err == nil {
+			err = skipTrimPattern(r)
+		}
+	case SlotWithRemainder:
+		s.Children, err = readSlotDisplays(r, 2)
+	case SlotComposite:
+		s.Children, err = readSlotDisplayList(r)
+	default:
+		return s, fmt.Errorf("未知 slot_display 类型: %d", t)
+	}
+	return s, err
+}
+
+func readSlotDisplays(r *bytes.Reader, n int) ([]SlotDisplay, error) {
+	out := make([]SlotDisplay, n)
+	for i := range out {
+		s, err := ReadSlotDisplay(r)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = s
+	}
+	return out, nil
+}
+
+func readSlotDisplayList(r *bytes.Reader) ([]SlotDisplay, error) {
+	n, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || int(n) > r.Len() {
+		return nil, fmt.Errorf("slot_display 列表长度无效: %d", n)
+	}
+	return readSlotDisplays(r, int(n))
+}
+
+// skipTrimPattern 跳过 Holder<TrimPattern>: 0 表示内联 (asset_id, description, decal)
+func skipTrimPattern(r *bytes.Reader) error {
+	id, err := packet.ReadVarIntFromReader(r)
+	if err != nil || id != 0 {
+		return err
+	}
+	if _, err := packet.ReadStringFromReader(r); err != nil {
+		return err
+	}
+	if err := packet.SkipNBT(r); err != nil {
+		return err
+	}
+	_, err = packet.ReadBoolFromReader(r)
+	return err
+}
+
+// ReadDisplay 读取一个 RecipeDisplay
+func ReadDisplay(r *bytes.Reader) (*Display, error) {
+	t, err := packet.ReadVarIntFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	d := &Display{Type: DisplayType(t)}
+	switch d.Type {
+	case DisplayShapeless:
+		if d.Ingredients, err = readSlotDisplayList(r); err != nil {
+			return nil, err
+		}
+	case DisplayShaped:
+		if d.Width, err = packet.ReadVarIntFromReader(r); err != nil {
+			return nil, err
+		}
+		if d.Height, err = packet.ReadVarIntFromReader(r); err != nil {
+			return nil, err
+		}
+		if d.Ingredients, err = readSlotDisplayList(r); err != nil {
+			return nil, err
+		}
+	case DisplayFurnace:
+		s, err := readSlotDisplays(r, 2)
+		if err != nil {
+			return nil, err
+		}
+		d.Ingredients, d.Fuel = s[:1], s[1]
+	case DisplayStonecutter:
+		if d.Ingredients, err = readSlotDisplays(r, 1); err != nil {
+			return nil, err
+		}
+	case DisplaySmithing:
+		if d.Ingredients, err = readSlotDisplays(r, 3); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("未知 recipe_display 类型: %d", t)
+	}
+
+	if d.Result, err = ReadSlotDisplay(r); err != nil {
+		return nil, err
+	}
+	if d.Station, err = ReadSlotDisplay(r); err != nil {
+		return nil, err
+	}
+	if d.Type == DisplayFurnace {
+		if d.CookingTime, err = packet.ReadVarIntFromReader(r); err != nil {
+			return nil, err
+		}
+		if d.Experience, err = packet.ReadFloat32FromReader(r); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
@@ -0,0 +1,103 @@
+package recipe
+
+import (
+	"bytes"
+	"testing"
+
+	"gmcc/internal/mcclient/packet"
+	"gmcc/internal/registry"
+)
+
+func varInts(vs ...int32) []byte {
+	var buf []byte
+	for _, v := range vs {
+		buf = append(buf, packet.EncodeVarInt(v)...)
+	}
+	return buf
+}
+
+func TestReadEntry_Shaped(t *testing.T) {
+	// 铁块: 3x3 有序合成，9 个铁锭 (物品 ID 10)，结果物品 ID 20
+	var data []byte
+	data = append(data, varInts(7)...)          // display id
+	data = append(data, varInts(1, 3, 3, 9)...) // shaped 3x3, 9 格
+	for i := 0; i < 9; i++ {
+		data = append(data, varInts(int32(SlotItem), 10)...)
+	}
+	data = append(data, varInts(int32(SlotItem), 20)...) // result
+	data = append(data, varInts(int32(SlotItem), 30)...) // station
+	data = append(data, varInts(0, 2)...)                // 无分组, category
+	data = append(data, packet.EncodeBool(true)...)
+	data = append(data, varInts(9)...)
+	for i := 0; i < 9; i++ {
+		data = append(data, varInts(2, 10)...) // 1 个物品的集合
+	}
+	data = append(data, FlagHighlight)
+
+	r := bytes.NewReader(data)
+	e, err := ReadEntry(r)
+	if err != nil {
+		t.Fatalf("ReadEntry() error = %v", err)
+	}
+	if r.Len() != 0 {
+		t.Errorf("剩余 %d 字节未读取", r.Len())
+	}
+	if e.ID != 7 || e.Display.Type != DisplayShaped || e.Display.Width != 3 || e.Flags != FlagHighlight {
+		t.Errorf("ReadEntry() = %+v", e)
+	}
+	if id, n := e.Display.Result.ResultItem(); id != 20 || n != 1 {
+		t.Errorf("ResultItem() = (%d, %d), want (20, 1)", id, n)
+	}
+	if e.Display.FitsGrid(2) || !e.Display.FitsGrid(3) {
+		t.Error("3x3 配方不应放入 2x2 合成格")
+	}
+	if got := MaxCrafts(e.Ingredients(), map[int32]int32{10: 30}, 64); got != 3 {
+		t.Errorf("MaxCrafts() = %d, want 3", got)
+	}
+}
+
+func TestIngredient_Tag(t *testing.T) {
+	registry.SetTags("minecraft:item", map[string][]int32{"minecraft:planks": {1, 2}})
+	defer registry.ClearTags()
+
+	in, err := ReadIngredient(bytes.NewReader(append(varInts(0), packet.EncodeString("minecraft:planks")...)))
+	if err != nil {
+		t.Fatalf("ReadIngredient() error = %v", err)
+	}
+	if got := in.Resolve(); len(got) != 2 {
+		t.Errorf("Resolve() = %v, want [1 2]", got)
+	}
+}
+
+func TestIngredient_InvalidLength(t *testing.T) {
+	for _, n := range []int32{-1, 100} {
+		if _, err := ReadIngredient(bytes.NewReader(varInts(n, 1))); err == nil {
+			t.Errorf("ReadIngredient(n=%d) 应返回错误", n)
+		}
+	}
+}
+
+func TestMaxCrafts(t *testing.T) {
+	tests := []struct {
+		name        string
+		ingredients [][]int32
+		counts      map[int32]int32
+		want        int
+	}{
+		{"empty", nil, map[int32]int32{1: 5}, 0},
+		{"single", [][]int32{{1}}, map[int32]int32{1: 5}, 5},
+		{"missing", [][]int32{{1}, {2}}, map[int32]int32{1: 5}, 0},
+		// 木板可以混用不同种类
+		{"mixed_candidates", [][]int32{{1, 2}, {1, 2}, {1, 2}, {1, 2}}, map[int32]int32{1: 3, 2: 5}, 2},
+		// 专用材料的格子先分配，避免被通用格子占用
+		{"specific_first", [][]int32{{1, 2}, {1}}, map[int32]int32{1: 2, 2: 2}, 2},
+		{"limit", [][]int32{{1}}, map[int32]int32{1: 1000}, 64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaxCrafts(tt.ingredients, tt.counts, 64); got != tt.want {
+				t.Errorf("MaxCrafts() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
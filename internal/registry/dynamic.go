@@ -0,0 +1,46 @@
+package registry
+
+import "sync"
+
+// 配置阶段 registry_data 下发的动态注册表 (附魔、聊天类型等)，按网络 ID 顺序保存条目名
+var (
+	dynamicMu      sync.RWMutex
+	dynamicEntries = map[string][]string{}
+)
+
+// SetDynamicEntries 记录一个动态注册表的全部条目，registryID 形如 "minecraft:enchantment"
+func SetDynamicEntries(registryID string, names []string) {
+	dynamicMu.Lock()
+	defer dynamicMu.Unlock()
+	dynamicEntries[registryID] = append([]string(nil), names...)
+}
+
+// DynamicEntryName 根据网络 ID 返回动态注册表条目名，未知时返回空字符串
+func DynamicEntryName(registryID string, id int32) string {
+	dynamicMu.RLock()
+	defer dynamicMu.RUnlock()
+	names := dynamicEntries[registryID]
+	if id < 0 || int(id) >= len(names) {
+		return ""
+	}
+	return names[id]
+}
+
+// DynamicEntryID 根据条目名返回网络 ID，未知时返回 -1
+func DynamicEntryID(registryID, name string) int32 {
+	dynamicMu.RLock()
+	defer dynamicMu.RUnlock()
+	for i, n := range dynamicEntries[registryID] {
+		if n == name {
+			return int32(i)
+		}
+	}
+	return -1
+}
+
+// ClearDynamicEntries 清空全部动态注册表 (重新进入配置阶段时调用)
+func ClearDynamicEntries() {
+	dynamicMu.Lock()
+	defer dynamicMu.Unlock()
+	dynamicEntries = map[string][]string{}
+}
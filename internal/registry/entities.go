@@ -0,0 +1,101 @@
+package registry
+
+import (
+	"strings"
+	"sync"
+
+	"gmcc/internal/i18n"
+)
+
+// EntityCategory 实体的刷怪分类 (简化自原版 MobCategory)
+type EntityCategory string
+
+const (
+	CategoryMonster       EntityCategory = "monster"
+	CategoryCreature      EntityCategory = "creature"
+	CategoryAmbient       EntityCategory = "ambient"
+	CategoryWaterCreature EntityCategory = "water_creature"
+	CategoryMisc          EntityCategory = "misc"
+)
+
+// EntityTypeInfo 实体类型，Name 不含 minecraft: 前缀
+type EntityTypeInfo struct {
+	ID          int32
+	Name        string
+	DisplayName string
+	Width       float64 // 碰撞箱宽度 (X/Z)
+	Height      float64 // 碰撞箱高度
+	Category    EntityCategory
+}
+
+// livingMisc misc 分类中属于 LivingEntity 的类型，其余 misc 实体 (载具、投射物、展示实体等) 没有生命值
+var livingMisc = map[string]bool{
+	"player":       true,
+	"armor_stand":  true,
+	"mannequin":    true,
+	"villager":     true,
+	"iron_golem":   true,
+	"snow_golem":   true,
+	"copper_golem": true,
+}
+
+// Living 是否为 LivingEntity (有生命值)
+func (e *EntityTypeInfo) Living() bool {
+	return e.Category != CategoryMisc || livingMisc[e.Name]
+}
+
+// ResourceName 返回带命名空间的名称，如 minecraft:zombie
+func (e *EntityTypeInfo) ResourceName() string {
+	return "minecraft:" + e.Name
+}
+
+// TranslationKey 返回语言文件中的键，如 entity.minecraft.zombie
+func (e *EntityTypeInfo) TranslationKey() string {
+	return "entity.minecraft." + e.Name
+}
+
+// LocalizedName 返回本地化名称，缺少翻译时使用英文名
+func (e *EntityTypeInfo) LocalizedName() string {
+	if name := i18n.EntityName(e.Name); name != e.TranslationKey() {
+		return name
+	}
+	return e.DisplayName
+}
+
+type EntityRegistry struct {
+	mu     sync.RWMutex
+	byID   map[int32]*EntityTypeInfo
+	byName map[string]*EntityTypeInfo
+}
+
+var defaultEntityRegistry *EntityRegistry
+var entityOnce sync.Once
+
+func GetEntityRegistry() *EntityRegistry {
+	entityOnce.Do(func() {
+		defaultEntityRegistry = newEntityRegistry()
+	})
+	return defaultEntityRegistry
+}
+
+func newEntityRegistry() *EntityRegistry {
+	r := &EntityRegistry{
+		byID:   make(map[int32]*EntityTypeInfo),
+		byName: make(map[string]*EntityTypeInfo),
+	}
+	r.loadEntities()
+	return r
+}
+
+func (r *EntityRegistry) GetByID(id int32) *EntityTypeInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.byID[id]
+}
+
+// GetByName 按名称查找，接受 zombie 或 minecraft:zombie
+func (r *EntityRegistry) GetByName(name string) *EntityTypeInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.byName[strings.TrimPrefix(name, "minecraft:")]
+}
@@ -0,0 +1,26 @@
+package registry
+
+type entityTypeEntry struct {
+	ID          int32          `json:"id"`
+	Name        string         `json:"name"`
+	DisplayName string         `json:"displayName"`
+	Width       float64        `json:"width"`
+	Height      float64        `json:"height"`
+	Category    EntityCategory `json:"category"`
+}
+
+func (r *EntityRegistry) loadEntities() {
+	entities := getEntitiesData()
+	for i := range entities {
+		e := &entities[i]
+		r.byID[e.ID] = &EntityTypeInfo{
+			ID:          e.ID,
+			Name:        e.Name,
+			DisplayName: e.DisplayName,
+			Width:       e.Width,
+			Height:      e.Height,
+			Category:    e.Category,
+		}
+		r.byName[e.Name] = r.byID[e.ID]
+	}
+}
@@ -0,0 +1,79 @@
+//go:build ignore
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+type entityEntry struct {
+	ID          int32   `json:"id"`
+	Name        string  `json:"name"`
+	DisplayName string  `json:"displayName"`
+	Width       float64 `json:"width"`
+	Height      float64 `json:"height"`
+	Type        string  `json:"type"`
+}
+
+// categoryOf 将 minecraft-data 的实体类型映射为刷怪分类
+func categoryOf(t string) string {
+	switch t {
+	case "hostile":
+		return "monster"
+	case "animal", "passive":
+		return "creature"
+	case "water_creature":
+		return "water_creature"
+	case "ambient":
+		return "ambient"
+	default:
+		return "misc"
+	}
+}
+
+func main() {
+	homeDir, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting working directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	entitiesPath := homeDir + "/.knowledge/minecraft-data/data/pc/1.21.11/entities.json"
+	if len(os.Args) > 1 {
+		entitiesPath = os.Args[1]
+	}
+
+	data, err := os.ReadFile(entitiesPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading entities.json: %v\n", err)
+		os.Exit(1)
+	}
+
+	var entities []entityEntry
+	if err := json.Unmarshal(data, &entities); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing entities.json: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("// Code generated by go run generate_entities.go. DO NOT EDIT.")
+	fmt.Println("// Source: .knowledge/minecraft-data/data/pc/1.21.11/entities.json")
+	fmt.Println("// Minecraft version: 1.21.11, Protocol: 774")
+	fmt.Println()
+	fmt.Println("package registry")
+	fmt.Println()
+	fmt.Println("func getEntitiesData() []entityTypeEntry {")
+	fmt.Println("\treturn []entityTypeEntry{")
+
+	for _, e := range entities {
+		fmt.Printf("\t\t{ID: %d, Name: \"%s\", DisplayName: \"%s\", Width: %s, Height: %s, Category: \"%s\"},\n",
+			e.ID, e.Name, e.DisplayName,
+			strconv.FormatFloat(e.Width, 'f', -1, 64), strconv.FormatFloat(e.Height, 'f', -1, 64),
+			categoryOf(e.Type))
+	}
+
+	fmt.Println("\t}")
+	fmt.Println("}")
+}
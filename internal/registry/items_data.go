@@ -16,6 +16,7 @@ func (r *ItemRegistry) loadItems() {
 			Name:        item.Name,
 			DisplayName: item.DisplayName,
 			StackSize:   item.StackSize,
+			MaxDamage:   itemDurability[item.Name],
 		}
 		r.byName[item.Name] = r.byID[item.ID]
 	}
@@ -0,0 +1,57 @@
+package registry
+
+// itemDurability 原版物品的默认最大耐久 (max_damage 默认组件)。
+// items.json 的生成数据不含该字段，此表手工维护。
+var itemDurability = map[string]int32{}
+
+func init() {
+	tools := []string{"sword", "shovel", "pickaxe", "axe", "hoe"}
+	for material, durability := range map[string]int32{
+		"wooden":    59,
+		"stone":     131,
+		"copper":    190,
+		"iron":      250,
+		"golden":    32,
+		"diamond":   1561,
+		"netherite": 2031,
+	} {
+		for _, tool := range tools {
+			itemDurability[material+"_"+tool] = durability
+		}
+	}
+
+	// 盔甲: 头盔、胸甲、护腿、靴子
+	armor := []string{"helmet", "chestplate", "leggings", "boots"}
+	for material, values := range map[string][4]int32{
+		"leather":   {55, 80, 75, 65},
+		"copper":    {121, 176, 165, 143},
+		"chainmail": {165, 240, 225, 195},
+		"iron":      {165, 240, 225, 195},
+		"golden":    {77, 112, 105, 91},
+		"diamond":   {363, 528, 495, 429},
+		"netherite": {407, 592, 555, 481},
+	} {
+		for i, piece := range armor {
+			itemDurability[material+"_"+piece] = values[i]
+		}
+	}
+
+	for name, durability := range map[string]int32{
+		"turtle_helmet":            275,
+		"wolf_armor":               64,
+		"elytra":                   432,
+		"shield":                   336,
+		"bow":                      384,
+		"crossbow":                 465,
+		"trident":                  250,
+		"mace":                     500,
+		"fishing_rod":              64,
+		"carrot_on_a_stick":        25,
+		"warped_fungus_on_a_stick": 100,
+		"shears":                   238,
+		"flint_and_steel":          64,
+		"brush":                    64,
+	} {
+		itemDurability[name] = durability
+	}
+}
@@ -11,6 +11,7 @@ type ItemInfo struct {
 	Name        string
 	DisplayName string
 	StackSize   int32
+	MaxDamage   int32 // 默认最大耐久，0 表示不可损坏
 }
 
 type ItemRegistry struct {
@@ -0,0 +1,107 @@
+package registry
+
+// 未随 registry_data 下发的内置注册表，按原版注册顺序排列 (网络 ID 即下标)。
+// 仅收录物品组件编码、实体数据与地图解析需要用到的几个，此表手工维护。
+var staticEntries = map[string][]string{
+	"minecraft:potion": {
+		"water", "mundane", "thick", "awkward",
+		"night_vision", "long_night_vision", "invisibility", "long_invisibility",
+		"leaping", "long_leaping", "strong_leaping",
+		"fire_resistance", "long_fire_resistance",
+		"swiftness", "long_swiftness", "strong_swiftness",
+		"slowness", "long_slowness", "strong_slowness",
+		"turtle_master", "long_turtle_master", "strong_turtle_master",
+		"water_breathing", "long_water_breathing",
+		"healing", "strong_healing", "harming", "strong_harming",
+		"poison", "long_poison", "strong_poison",
+		"regeneration", "long_regeneration", "strong_regeneration",
+		"strength", "long_strength", "strong_strength",
+		"weakness", "long_weakness", "luck",
+		"slow_falling", "long_slow_falling",
+		"wind_charged", "weaving", "oozing", "infested",
+	},
+	"minecraft:mob_effect": {
+		"speed", "slowness", "haste", "mining_fatigue", "strength",
+		"instant_health", "instant_damage", "jump_boost", "nausea", "regeneration",
+		"resistance", "fire_resistance", "water_breathing", "invisibility", "blindness",
+		"night_vision", "hunger", "weakness", "poison", "wither",
+		"health_boost", "absorption", "saturation", "glowing", "levitation",
+		"luck", "unluck", "slow_falling", "conduit_power", "dolphins_grace",
+		"bad_omen", "hero_of_the_village", "darkness", "trial_omen", "raid_omen",
+		"wind_charged", "weaving", "oozing", "infested", "breath_of_the_nautilus",
+	},
+	"minecraft:villager_type": {
+		"desert", "jungle", "plains", "savanna", "snow", "swamp", "taiga",
+	},
+	"minecraft:particle_type": {
+		"angry_villager", "block", "block_marker", "bubble", "cloud", "copper_fire_flame",
+		"crit", "damage_indicator", "dragon_breath", "dripping_lava", "falling_lava", "landing_lava",
+		"dripping_water", "falling_water", "dust", "dust_color_transition", "effect", "elder_guardian",
+		"enchanted_hit", "enchant", "end_rod", "entity_effect", "explosion_emitter", "explosion",
+		"gust", "small_gust", "gust_emitter_large", "gust_emitter_small", "sonic_boom", "falling_dust",
+		"firework", "fishing", "flame", "infested", "cherry_leaves", "pale_oak_leaves",
+		"tinted_leaves", "sculk_soul", "sculk_charge", "sculk_charge_pop", "soul_fire_flame", "soul",
+		"flash", "happy_villager", "composter", "heart", "instant_effect", "item",
+		"vibration", "trail", "item_slime", "item_cobweb", "item_snowball", "large_smoke",
+		"lava", "mycelium", "note", "poof", "portal", "rain",
+		"smoke", "white_smoke", "sneeze", "spit", "squid_ink", "sweep_attack",
+		"totem_of_undying", "underwater", "splash", "witch", "bubble_pop", "current_down",
+		"bubble_column_up", "nautilus", "dolphin", "campfire_cosy_smoke", "campfire_signal_smoke", "dripping_honey",
+		"falling_honey", "landing_honey", "falling_nectar", "falling_spore_blossom", "ash", "crimson_spore",
+		"warped_spore", "spore_blossom_air", "dripping_obsidian_tear", "falling_obsidian_tear", "landing_obsidian_tear", "reverse_portal",
+		"white_ash", "small_flame", "snowflake", "dripping_dripstone_lava", "falling_dripstone_lava", "dripping_dripstone_water",
+		"falling_dripstone_water", "glow_squid_ink", "glow", "wax_on", "wax_off", "electric_spark",
+		"scrape", "shriek", "egg_crack", "dust_plume", "trial_spawner_detected_player", "trial_spawner_detected_player_ominous",
+		"vault_connection", "dust_pillar", "ominous_spawning", "raid_omen", "trial_omen", "block_crumble",
+		"firefly",
+	},
+	"minecraft:map_decoration_type": {
+		"player", "frame", "red_marker", "blue_marker", "target_x", "target_point",
+		"player_off_map", "player_off_limits", "mansion", "monument",
+		"banner_white", "banner_orange", "banner_magenta", "banner_light_blue",
+		"banner_yellow", "banner_lime", "banner_pink", "banner_gray",
+		"banner_light_gray", "banner_cyan", "banner_purple", "banner_blue",
+		"banner_brown", "banner_green", "banner_red", "banner_black",
+		"red_x", "village_desert", "village_plains", "village_savanna",
+		"village_snowy", "village_taiga", "jungle_temple", "swamp_hut", "trial_chambers",
+	},
+	"minecraft:attribute": {
+		"armor", "armor_toughness", "attack_damage", "attack_knockback", "attack_speed",
+		"block_break_speed", "block_interaction_range", "burning_time", "camera_distance",
+		"explosion_knockback_resistance", "entity_interaction_range", "fall_damage_multiplier",
+		"flying_speed", "follow_range", "gravity", "jump_strength", "knockback_resistance",
+		"luck", "max_absorption", "max_health", "mining_efficiency", "movement_efficiency",
+		"movement_speed", "oxygen_bonus", "safe_fall_distance", "scale", "sneaking_speed",
+		"spawn_reinforcements", "step_height", "submerged_mining_speed", "sweeping_damage_ratio",
+		"tempt_range", "water_movement_efficiency", "waypoint_transmit_range", "waypoint_receive_range",
+	},
+}
+
+// StaticEntryName 返回内置注册表条目名 (带 minecraft: 前缀)，未知时返回空字符串
+func StaticEntryName(registryID string, id int32) string {
+	names := staticEntries[registryID]
+	if id < 0 || int(id) >= len(names) {
+		return ""
+	}
+	return "minecraft:" + names[id]
+}
+
+// EntryName 依次从物品/实体类型、动态与内置注册表解析网络 ID，未知时返回空字符串
+func EntryName(registryID string, id int32) string {
+	switch registryID {
+	case "minecraft:item":
+		if info := GetItemRegistry().GetByID(id); info != nil {
+			return "minecraft:" + info.Name
+		}
+		return ""
+	case "minecraft:entity_type":
+		if info := GetEntityRegistry().GetByID(id); info != nil {
+			return info.ResourceName()
+		}
+		return ""
+	}
+	if name := DynamicEntryName(registryID, id); name != "" {
+		return name
+	}
+	return StaticEntryName(registryID, id)
+}
@@ -0,0 +1,30 @@
+package registry
+
+import "sync"
+
+// update_tags 下发的标签，registryID -> 标签名 (不含 #) -> 条目网络 ID
+var (
+	tagsMu sync.RWMutex
+	tags   = map[string]map[string][]int32{}
+)
+
+// SetTags 替换一个注册表的全部标签
+func SetTags(registryID string, entries map[string][]int32) {
+	tagsMu.Lock()
+	defer tagsMu.Unlock()
+	tags[registryID] = entries
+}
+
+// TagEntries 返回标签包含的条目 ID，tag 形如 minecraft:planks，未知时返回 nil
+func TagEntries(registryID, tag string) []int32 {
+	tagsMu.RLock()
+	defer tagsMu.RUnlock()
+	return tags[registryID][tag]
+}
+
+// ClearTags 清空全部标签
+func ClearTags() {
+	tagsMu.Lock()
+	defer tagsMu.Unlock()
+	tags = map[string]map[string][]int32{}
+}
@@ -0,0 +1,540 @@
+// Package scoreboard 维护服务器下发的计分板：目标、分数、显示位置和队伍
+package scoreboard
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gmcc/internal/mcclient/chat"
+)
+
+// DisplaySlot 目标的显示位置
+type DisplaySlot int32
+
+const (
+	SlotList      DisplaySlot = 0
+	SlotSidebar   DisplaySlot = 1
+	SlotBelowName DisplaySlot = 2
+	// 3-18 为按队伍颜色区分的侧边栏，SlotTeamSidebar + 颜色 ID
+	SlotTeamSidebar DisplaySlot = 3
+)
+
+// 侧边栏最多显示的行数
+const maxSidebarLines = 15
+
+// RenderType 目标在 Tab 列表中的显示方式
+type RenderType int32
+
+const (
+	RenderInteger RenderType = 0
+	RenderHearts  RenderType = 1
+)
+
+// NumberFormatKind 分数的显示格式
+type NumberFormatKind int32
+
+const (
+	FormatBlank  NumberFormatKind = 0 // 不显示分数
+	FormatStyled NumberFormatKind = 1 // 按样式显示数字
+	FormatFixed  NumberFormatKind = 2 // 显示固定文本
+)
+
+// NumberFormat 分数显示格式，nil 表示使用默认格式
+type NumberFormat struct {
+	Kind  NumberFormatKind
+	Style string // FormatStyled 的样式 (JSON)
+	Fixed string // FormatFixed 的文本组件 (JSON)
+}
+
+// Objective 计分目标
+type Objective struct {
+	Name         string
+	DisplayName  string // JSON 文本组件
+	RenderType   RenderType
+	NumberFormat *NumberFormat
+}
+
+// Score 计分项
+type Score struct {
+	Holder       string // 玩家名或实体 UUID
+	Objective    string
+	Value        int32
+	DisplayName  string // JSON 文本组件，为空时显示 Holder
+	NumberFormat *NumberFormat
+}
+
+// Team 队伍
+type Team struct {
+	Name              string
+	DisplayName       string // JSON 文本组件
+	Prefix            string // JSON 文本组件
+	Suffix            string // JSON 文本组件
+	Color             string // 颜色名，如 red；reset 表示无颜色
+	FriendlyFire      bool
+	SeeInvisible      bool
+	NameTagVisibility string
+	CollisionRule     string
+	Members           []string
+}
+
+// SidebarLine 侧边栏中的一行
+type SidebarLine struct {
+	Holder    string
+	Name      string // 带队伍前后缀的名字 (JSON 文本组件)
+	Text      string // Name 的纯文本
+	Value     int32
+	ValueText string // 按显示格式处理后的分数，FormatBlank 时为空
+}
+
+// Callbacks 计分板变更回调
+type Callbacks struct {
+	OnObjectiveChange func(o Objective, removed bool)
+	OnScoreChange     func(s Score, removed bool)
+	OnTeamChange      func(t Team, removed bool)
+	OnDisplayChange   func(slot DisplaySlot, objective string)
+}
+
+// Scoreboard 计分板
+type Scoreboard struct {
+	mu         sync.RWMutex
+	objectives map[string]*Objective
+	scores     map[string]map[string]*Score // objective -> holder -> score
+	display    map[DisplaySlot]string
+	teams      map[string]*Team
+	teamOf     map[string]string // 成员 -> 队伍名
+	callbacks  Callbacks
+}
+
+func New() *Scoreboard {
+	return &Scoreboard{
+		objectives: make(map[string]*Objective),
+		scores:     make(map[string]map[string]*Score),
+		display:    make(map[DisplaySlot]string),
+		teams:      make(map[string]*Team),
+		teamOf:     make(map[string]string),
+	}
+}
+
+// SetCallbacks 设置事件回调
+func (s *Scoreboard) SetCallbacks(callbacks Callbacks) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callbacks = callbacks
+}
+
+// SetObjective 新增或修改目标
+func (s *Scoreboard) SetObjective(o Objective) {
+	s.mu.Lock()
+	s.objectives[o.Name] = &o
+	callbacks := s.callbacks
+	s.mu.Unlock()
+
+	if callbacks.OnObjectiveChange != nil {
+		go callbacks.OnObjectiveChange(o, false)
+	}
+}
+
+// RemoveObjective 移除目标及其全部分数和显示位置
+func (s *Scoreboard) RemoveObjective(name string) {
+	s.mu.Lock()
+	o, ok := s.objectives[name]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.objectives, name)
+	delete(s.scores, name)
+	for slot, objective := range s.display {
+		if objective == name {
+			delete(s.display, slot)
+		}
+	}
+	callbacks := s.callbacks
+	s.mu.Unlock()
+
+	if callbacks.OnObjectiveChange != nil {
+		go callbacks.OnObjectiveChange(*o, true)
+	}
+}
+
+// SetDisplay 设置显示位置上的目标，objective 为空表示清除
+func (s *Scoreboard) SetDisplay(slot DisplaySlot, objective string) {
+	s.mu.Lock()
+	if objective == "" {
+		delete(s.display, slot)
+	} else {
+		s.display[slot] = objective
+	}
+	callbacks := s.callbacks
+	s.mu.Unlock()
+
+	if callbacks.OnDisplayChange != nil {
+		go callbacks.OnDisplayChange(slot, objective)
+	}
+}
+
+// SetScore 设置分数，目标不存在时忽略
+func (s *Scoreboard) SetScore(score Score) {
+	s.mu.Lock()
+	if _, ok := s.objectives[score.Objective]; !ok {
+		s.mu.Unlock()
+		return
+	}
+	holders, ok := s.scores[score.Objective]
+	if !ok {
+		holders = make(map[string]*Score)
+		s.scores[score.Objective] = holders
+	}
+	holders[score.Holder] = &score
+	callbacks := s.callbacks
+	s.mu.Unlock()
+
+	if callbacks.OnScoreChange != nil {
+		go callbacks.OnScoreChange(score, false)
+	}
+}
+
+// ResetScore 移除 holder 在 objective 上的分数，objective 为空时移除其全部分数
+func (s *Scoreboard) ResetScore(holder, objective string) {
+	s.mu.Lock()
+	removed := make([]Score, 0, 1)
+	for name, holders := range s.scores {
+		if objective != "" && name != objective {
+			continue
+		}
+		if score, ok := holders[holder]; ok {
+			delete(holders, holder)
+			removed = append(removed, *score)
+		}
+	}
+	callbacks := s.callbacks
+	s.mu.Unlock()
+
+	if callbacks.OnScoreChange != nil {
+		for _, score := range removed {
+			go callbacks.OnScoreChange(score, true)
+		}
+	}
+}
+
+// SetTeam 新增或修改队伍属性，members 不变
+func (s *Scoreboard) SetTeam(t Team) {
+	s.mu.Lock()
+	if old, ok := s.teams[t.Name]; ok {
+		t.Members = old.Members
+	}
+	for _, member := range t.Members {
+		s.teamOf[member] = t.Name
+	}
+	s.teams[t.Name] = &t
+	s.mu.Unlock()
+	s.notifyTeam(t.Name, false)
+}
+
+// RemoveTeam 移除队伍
+func (s *Scoreboard) RemoveTeam(name string) {
+	s.mu.Lock()
+	t, ok := s.teams[name]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.teams, name)
+	for _, member := range t.Members {
+		if s.teamOf[member] == name {
+			delete(s.teamOf, member)
+		}
+	}
+	callbacks := s.callbacks
+	s.mu.Unlock()
+
+	if callbacks.OnTeamChange != nil {
+		go callbacks.OnTeamChange(*t, true)
+	}
+}
+
+// AddTeamMembers 将成员加入队伍，成员会先离开原来的队伍
+func (s *Scoreboard) AddTeamMembers(name string, members []string) {
+	s.mu.Lock()
+	t, ok := s.teams[name]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	for _, member := range members {
+		if current, ok := s.teamOf[member]; ok && current != name {
+			if other, ok := s.teams[current]; ok {
+				other.Members = removeMember(other.Members, member)
+			}
+		}
+		if s.teamOf[member] != name {
+			t.Members = append(t.Members, member)
+			s.teamOf[member] = name
+		}
+	}
+	s.mu.Unlock()
+	s.notifyTeam(name, false)
+}
+
+// RemoveTeamMembers 将成员移出队伍
+func (s *Scoreboard) RemoveTeamMembers(name string, members []string) {
+	s.mu.Lock()
+	t, ok := s.teams[name]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	for _, member := range members {
+		if s.teamOf[member] == name {
+			delete(s.teamOf, member)
+			t.Members = removeMember(t.Members, member)
+		}
+	}
+	s.mu.Unlock()
+	s.notifyTeam(name, false)
+}
+
+func (s *Scoreboard) notifyTeam(name string, removed bool) {
+	s.mu.RLock()
+	callbacks := s.callbacks
+	t, ok := s.teams[name]
+	var snapshot Team
+	if ok {
+		snapshot = copyTeam(t)
+	}
+	s.mu.RUnlock()
+
+	if ok && callbacks.OnTeamChange != nil {
+		go callbacks.OnTeamChange(snapshot, removed)
+	}
+}
+
+func removeMember(members []string, member string) []string {
+	result := members[:0:0]
+	for _, m := range members {
+		if m != member {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+func copyTeam(t *Team) Team {
+	c := *t
+	c.Members = append([]string(nil), t.Members...)
+	return c
+}
+
+// Objective 返回目标
+func (s *Scoreboard) Objective(name string) (Objective, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if o, ok := s.objectives[name]; ok {
+		return *o, true
+	}
+	return Objective{}, false
+}
+
+// Objectives 返回全部目标
+func (s *Scoreboard) Objectives() []Objective {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make([]Objective, 0, len(s.objectives))
+	for _, o := range s.objectives {
+		result = append(result, *o)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// DisplayedObjective 返回显示位置上的目标名
+func (s *Scoreboard) DisplayedObjective(slot DisplaySlot) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	name, ok := s.display[slot]
+	return name, ok
+}
+
+// ScoreOf 返回 holder 在 objective 上的分数
+func (s *Scoreboard) ScoreOf(holder, objective string) (Score, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if score, ok := s.scores[objective][holder]; ok {
+		return *score, true
+	}
+	return Score{}, false
+}
+
+// Scores 返回目标上可见的分数，按侧边栏顺序排序。
+// 以 # 开头的持有者是原版的隐藏分数，不显示
+func (s *Scoreboard) Scores(objective string) []Score {
+	s.mu.RLock()
+	holders := s.scores[objective]
+	result := make([]Score, 0, len(holders))
+	for _, score := range holders {
+		if strings.HasPrefix(score.Holder, "#") {
+			continue
+		}
+		result = append(result, *score)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Value != result[j].Value {
+			return result[i].Value > result[j].Value
+		}
+		return strings.ToLower(result[i].Holder) < strings.ToLower(result[j].Holder)
+	})
+	return result
+}
+
+// Team 返回队伍
+func (s *Scoreboard) Team(name string) (Team, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if t, ok := s.teams[name]; ok {
+		return copyTeam(t), true
+	}
+	return Team{}, false
+}
+
+// TeamOf 返回成员所在的队伍
+func (s *Scoreboard) TeamOf(member string) (Team, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if t, ok := s.teams[s.teamOf[member]]; ok {
+		return copyTeam(t), true
+	}
+	return Team{}, false
+}
+
+// SidebarObjective 返回 viewer 看到的侧边栏目标，队伍颜色侧边栏优先
+func (s *Scoreboard) SidebarObjective(viewer string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if t, ok := s.teams[s.teamOf[viewer]]; ok {
+		if id, ok := colorIDs[t.Color]; ok {
+			if name, ok := s.display[SlotTeamSidebar+DisplaySlot(id)]; ok {
+				return name, true
+			}
+		}
+	}
+	name, ok := s.display[SlotSidebar]
+	return name, ok
+}
+
+// SidebarLines 返回 viewer 看到的侧边栏内容，从上到下最多 15 行
+func (s *Scoreboard) SidebarLines(viewer string) []SidebarLine {
+	name, ok := s.SidebarObjective(viewer)
+	if !ok {
+		return nil
+	}
+	objective, ok := s.Objective(name)
+	if !ok {
+		return nil
+	}
+	scores := s.Scores(name)
+	if len(scores) > maxSidebarLines {
+		scores = scores[:maxSidebarLines]
+	}
+
+	lines := make([]SidebarLine, 0, len(scores))
+	for _, score := range scores {
+		line := SidebarLine{
+			Holder:    score.Holder,
+			Value:     score.Value,
+			ValueText: formatScore(score, objective),
+		}
+		if score.DisplayName != "" {
+			line.Name = score.DisplayName
+		} else {
+			team, _ := s.TeamOf(score.Holder)
+			line.Name = decorateName(team, score.Holder)
+		}
+		line.Text = chat.ExtractPlainTextFromChatJSON(line.Name)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// formatScore 按分数自身或目标的显示格式输出分数文本
+func formatScore(score Score, objective Objective) string {
+	format := score.NumberFormat
+	if format == nil {
+		format = objective.NumberFormat
+	}
+	if format == nil {
+		return strconv.Itoa(int(score.Value))
+	}
+	switch format.Kind {
+	case FormatBlank:
+		return ""
+	case FormatFixed:
+		return chat.ExtractPlainTextFromChatJSON(format.Fixed)
+	default:
+		return strconv.Itoa(int(score.Value))
+	}
+}
+
+// decorateName 拼接队伍前缀、带队伍颜色的名字和后缀
+func decorateName(team Team, holder string) string {
+	name := map[string]any{"text": holder}
+	if team.Color != "" && team.Color != "reset" {
+		name["color"] = team.Color
+	}
+	extra := make([]any, 0, 3)
+	if team.Prefix != "" {
+		extra = append(extra, rawComponent(team.Prefix))
+	}
+	extra = append(extra, name)
+	if team.Suffix != "" {
+		extra = append(extra, rawComponent(team.Suffix))
+	}
+	raw, _ := json.Marshal(map[string]any{"text": "", "extra": extra})
+	return string(raw)
+}
+
+// rawComponent 原样嵌入 JSON 文本组件，不是合法 JSON 时当作纯文本
+func rawComponent(component string) any {
+	if json.Valid([]byte(component)) {
+		return json.RawMessage(component)
+	}
+	return component
+}
+
+// ChatFormatting 颜色 ID，用于队伍颜色和队伍侧边栏
+var colorNames = []string{
+	"black", "dark_blue", "dark_green", "dark_aqua", "dark_red", "dark_purple", "gold", "gray",
+	"dark_gray", "blue", "green", "aqua", "red", "light_purple", "yellow", "white",
+	"obfuscated", "bold", "strikethrough", "underline", "italic", "reset",
+}
+
+var colorIDs = func() map[string]int {
+	ids := make(map[string]int, 16)
+	for i, name := range colorNames[:16] {
+		ids[name] = i
+	}
+	return ids
+}()
+
+// ColorName 返回 ChatFormatting ID 对应的名字，未知时返回 reset
+func ColorName(id int32) string {
+	if id < 0 || int(id) >= len(colorNames) {
+		return "reset"
+	}
+	return colorNames[id]
+}
+
+// Clear 清空计分板 (切换服务器时)
+func (s *Scoreboard) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objectives = make(map[string]*Objective)
+	s.scores = make(map[string]map[string]*Score)
+	s.display = make(map[DisplaySlot]string)
+	s.teams = make(map[string]*Team)
+	s.teamOf = make(map[string]string)
+}
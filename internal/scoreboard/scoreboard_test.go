@@ -0,0 +1,119 @@
+package scoreboard
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSidebarLines(t *testing.T) {
+	s := New()
+	s.SetObjective(Objective{Name: "eco", DisplayName: `"经济"`})
+	s.SetDisplay(SlotSidebar, "eco")
+	s.SetScore(Score{Holder: "bob", Objective: "eco", Value: 5})
+	s.SetScore(Score{Holder: "Alice", Objective: "eco", Value: 5})
+	s.SetScore(Score{Holder: "carol", Objective: "eco", Value: 9, NumberFormat: &NumberFormat{Kind: FormatFixed, Fixed: `"第一"`}})
+	s.SetScore(Score{Holder: "§1", Objective: "eco", Value: 1, DisplayName: `{"text":"余额: 100"}`})
+	s.SetTeam(Team{Name: "vip", Prefix: `{"text":"[VIP] ","color":"gold"}`, Suffix: `" ★"`, Color: "red"})
+	s.AddTeamMembers("vip", []string{"bob"})
+
+	lines := s.SidebarLines("viewer")
+	want := []struct {
+		text, value string
+	}{
+		{"carol", "第一"},
+		{"Alice", "5"},
+		{"[VIP] bob ★", "5"},
+		{"余额: 100", "1"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("SidebarLines() = %+v", lines)
+	}
+	for i, w := range want {
+		if lines[i].Text != w.text || lines[i].ValueText != w.value {
+			t.Errorf("第 %d 行 = %q %q, want %q %q", i, lines[i].Text, lines[i].ValueText, w.text, w.value)
+		}
+	}
+}
+
+func TestSidebarTeamSlotAndLimit(t *testing.T) {
+	s := New()
+	s.SetObjective(Objective{Name: "all"})
+	s.SetObjective(Objective{Name: "red", NumberFormat: &NumberFormat{Kind: FormatBlank}})
+	s.SetDisplay(SlotSidebar, "all")
+	s.SetDisplay(SlotTeamSidebar+12, "red")
+	s.SetTeam(Team{Name: "r", Color: ColorName(12)})
+	s.AddTeamMembers("r", []string{"me"})
+	for i := 0; i < 20; i++ {
+		s.SetScore(Score{Holder: fmt.Sprintf("p%02d", i), Objective: "red", Value: int32(i)})
+		// 隐藏的持有者分数更高，也不能占用侧边栏的行数
+		s.SetScore(Score{Holder: fmt.Sprintf("#h%02d", i), Objective: "red", Value: int32(100 + i)})
+	}
+
+	if name, _ := s.SidebarObjective("me"); name != "red" {
+		t.Fatalf("SidebarObjective(me) = %q, want red", name)
+	}
+	if name, _ := s.SidebarObjective("other"); name != "all" {
+		t.Fatalf("SidebarObjective(other) = %q, want all", name)
+	}
+	lines := s.SidebarLines("me")
+	if len(lines) != maxSidebarLines || lines[0].Holder != "p19" || lines[0].ValueText != "" {
+		t.Errorf("SidebarLines(me) = %d 行, 首行 %+v", len(lines), lines[0])
+	}
+	if scores := s.Scores("red"); len(scores) != 20 || scores[0].Holder != "p19" {
+		t.Errorf("Scores(red) = %d 个, 应排除 # 开头的持有者", len(scores))
+	}
+}
+
+func TestScoresAndTeams(t *testing.T) {
+	s := New()
+	s.SetScore(Score{Holder: "a", Objective: "missing", Value: 1})
+	if _, ok := s.ScoreOf("a", "missing"); ok {
+		t.Error("目标不存在时不应记录分数")
+	}
+
+	s.SetObjective(Objective{Name: "x"})
+	s.SetObjective(Objective{Name: "y"})
+	s.SetScore(Score{Holder: "a", Objective: "x", Value: 1})
+	s.SetScore(Score{Holder: "a", Objective: "y", Value: 2})
+	s.ResetScore("a", "x")
+	if _, ok := s.ScoreOf("a", "x"); ok {
+		t.Error("ResetScore(a, x) 后仍有分数")
+	}
+	if score, ok := s.ScoreOf("a", "y"); !ok || score.Value != 2 {
+		t.Errorf("ScoreOf(a, y) = %+v, %v", score, ok)
+	}
+	s.ResetScore("a", "")
+	if _, ok := s.ScoreOf("a", "y"); ok {
+		t.Error("ResetScore(a) 应移除全部分数")
+	}
+
+	s.SetDisplay(SlotSidebar, "y")
+	s.RemoveObjective("y")
+	if _, ok := s.DisplayedObjective(SlotSidebar); ok {
+		t.Error("移除目标后应清除显示位置")
+	}
+
+	s.SetTeam(Team{Name: "t1", Color: "blue"})
+	s.SetTeam(Team{Name: "t2"})
+	s.AddTeamMembers("t1", []string{"a", "b"})
+	s.AddTeamMembers("t2", []string{"a"})
+	if team, ok := s.TeamOf("a"); !ok || team.Name != "t2" {
+		t.Errorf("TeamOf(a) = %+v, %v", team, ok)
+	}
+	if team, _ := s.Team("t1"); len(team.Members) != 1 || team.Members[0] != "b" {
+		t.Errorf("t1 成员 = %v", team.Members)
+	}
+	// 修改属性不影响成员
+	s.SetTeam(Team{Name: "t1", Color: "green"})
+	if team, _ := s.TeamOf("b"); team.Color != "green" || len(team.Members) != 1 {
+		t.Errorf("TeamOf(b) = %+v", team)
+	}
+	s.RemoveTeamMembers("t1", []string{"b"})
+	if _, ok := s.TeamOf("b"); ok {
+		t.Error("离开队伍后 TeamOf(b) 仍有结果")
+	}
+	s.RemoveTeam("t2")
+	if _, ok := s.TeamOf("a"); ok {
+		t.Error("移除队伍后 TeamOf(a) 仍有结果")
+	}
+}
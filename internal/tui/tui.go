@@ -13,8 +13,10 @@ import (
 	"golang.org/x/term"
 
 	"gmcc/internal/config"
+	"gmcc/internal/dialog"
 	"gmcc/internal/mcclient"
 	"gmcc/internal/mcclient/chat"
+	"gmcc/internal/player"
 )
 
 type TUI struct {
@@ -77,6 +79,34 @@ func (t *TUI) Run(ctx context.Context) error {
 			t.addLog(text)
 		}
 	})
+	t.client.SetTitleHandler(func(msg mcclient.TitleMessage) {
+		t.requestRedraw()
+		if msg.Type == "title" {
+			// 标题消失后重绘一次
+			time.AfterFunc(msg.FadeIn+msg.Stay+msg.FadeOut, t.requestRedraw)
+		}
+	})
+	t.client.BossBars.SetCallbacks(player.BossBarCallbacks{
+		OnAdd:    func(player.BossBar) { t.requestRedraw() },
+		OnUpdate: func(player.BossBar) { t.requestRedraw() },
+		OnRemove: func(player.BossBar) { t.requestRedraw() },
+	})
+	t.client.SetDialogHandler(func(e mcclient.DialogEvent) {
+		if e.Dialog == nil {
+			t.addLog("\x1b[90m[对话框] 已关闭\x1b[0m")
+			return
+		}
+		for _, line := range e.Dialog.Lines() {
+			t.addLog("\x1b[36m" + line + "\x1b[0m")
+		}
+		t.addLog("\x1b[90m输入 :dialog <按钮> [key=value ...] 点击按钮，:dialog close 关闭\x1b[0m")
+	})
+	t.client.SetMerchantHandler(func(e mcclient.MerchantEvent) {
+		t.addLog(fmt.Sprintf("\x1b[36m[交易] %d 项\x1b[0m", len(e.Merchant.Offers)))
+		for i := range e.Merchant.Offers {
+			t.addLog(fmt.Sprintf("\x1b[36m  <%d> %s\x1b[0m", i+1, &e.Merchant.Offers[i]))
+		}
+	})
 
 	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
@@ -257,6 +287,10 @@ func (t *TUI) handleAnsiControlSequence(cmd byte) bool {
 }
 
 func (t *TUI) processInput(line string) {
+	if line == ":dialog" || strings.HasPrefix(line, ":dialog ") {
+		t.processDialogInput(strings.TrimSpace(strings.TrimPrefix(line, ":dialog")))
+		return
+	}
 	if strings.HasPrefix(line, "/") {
 		cmd := strings.TrimPrefix(line, "/")
 		if t.client.IsReady() {
@@ -296,6 +330,31 @@ func (t *TUI) processInput(line string) {
 	}
 }
 
+// processDialogInput 处理 :dialog 命令：无参数时显示当前对话框，close 关闭，否则点击按钮
+func (t *TUI) processDialogInput(args string) {
+	d, ok := t.client.CurrentDialog()
+	if !ok {
+		t.addLog("\x1b[33m[提示] 当前没有对话框\x1b[0m")
+		return
+	}
+	var err error
+	switch args {
+	case "":
+		for _, line := range d.Lines() {
+			t.addLog("\x1b[36m" + line + "\x1b[0m")
+		}
+		return
+	case "close":
+		err = t.client.CloseDialog()
+	default:
+		button, inputs := dialog.ParseArgs(args)
+		err = t.client.ClickDialog(button, inputs)
+	}
+	if err != nil {
+		t.addLog(fmt.Sprintf("\x1b[31m[错误] %v\x1b[0m", err))
+	}
+}
+
 func (t *TUI) addLog(text string) {
 	t.mu.Lock()
 	t.logs = append(t.logs, text)
@@ -330,7 +389,16 @@ func (t *TUI) render() {
 	sb.WriteString("\x1b[2J")
 	sb.WriteString("\x1b[H")
 
-	msgHeight := h - 2
+	status := t.statusLines(w)
+	if len(status) > h/2 {
+		status = status[:h/2]
+	}
+	for _, line := range status {
+		sb.WriteString(truncateANSI(line, w))
+		sb.WriteString("\r\n")
+	}
+
+	msgHeight := h - 2 - len(status)
 	if msgHeight < 1 {
 		msgHeight = 1
 	}
@@ -371,6 +439,67 @@ func (t *TUI) render() {
 	fmt.Print(sb.String())
 }
 
+// statusLines 返回显示在消息区上方的 Boss 栏和当前标题
+func (t *TUI) statusLines(w int) []string {
+	if t.client == nil {
+		return nil
+	}
+	var lines []string
+	for _, bar := range t.client.BossBars.All() {
+		lines = append(lines, formatBossBar(bar, w))
+	}
+	if title, ok := t.client.CurrentTitle(); ok {
+		lines = append(lines, centerANSI("\x1b[1m"+componentANSI(title.Title, title.TitleText)+"\x1b[0m", w))
+		if title.Subtitle != "" {
+			lines = append(lines, centerANSI(componentANSI(title.Subtitle, title.SubtitleText), w))
+		}
+	}
+	if len(lines) > 0 {
+		lines = append(lines, "\x1b[90m"+strings.Repeat("─", w)+"\x1b[0m")
+	}
+	return lines
+}
+
+var bossBarColors = map[player.BossBarColor]string{
+	player.BossBarPink:   "\x1b[95m",
+	player.BossBarBlue:   "\x1b[34m",
+	player.BossBarRed:    "\x1b[31m",
+	player.BossBarGreen:  "\x1b[32m",
+	player.BossBarYellow: "\x1b[33m",
+	player.BossBarPurple: "\x1b[35m",
+	player.BossBarWhite:  "\x1b[37m",
+}
+
+func formatBossBar(bar player.BossBar, w int) string {
+	progress := min(max(bar.Progress, 0), 1)
+	width := min(20, max(w/4, 5))
+	filled := int(progress*float32(width) + 0.5)
+	return fmt.Sprintf("%s %s[%s%s]\x1b[0m %3d%%",
+		componentANSI(bar.Title, ""),
+		bossBarColors[bar.Color],
+		strings.Repeat("█", filled),
+		strings.Repeat("░", width-filled),
+		int(progress*100+0.5))
+}
+
+func componentANSI(rawJSON, fallback string) string {
+	comp, err := chat.ParseTextComponent(rawJSON)
+	if err != nil {
+		if fallback == "" {
+			return chat.ExtractPlainTextFromChatJSON(rawJSON)
+		}
+		return fallback
+	}
+	return comp.ToANSI()
+}
+
+func centerANSI(s string, w int) string {
+	if pad := (w - visibleLen(s)) / 2; pad > 0 {
+		return strings.Repeat(" ", pad) + s
+	}
+	return s
+}
+
 func visibleLen(s string) int {
 	len := 0
 	inEscape := false
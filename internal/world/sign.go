@@ -0,0 +1,113 @@
+package world
+
+import (
+	"fmt"
+	"sort"
+)
+
+// 告示牌行数
+const SignLines = 4
+
+// BlockPos 方块坐标
+type BlockPos struct {
+	X, Y, Z int32
+}
+
+// Chunk 返回所在区块坐标
+func (p BlockPos) Chunk() (x, z int32) {
+	return p.X >> 4, p.Z >> 4
+}
+
+func (p BlockPos) String() string {
+	return fmt.Sprintf("%d,%d,%d", p.X, p.Y, p.Z)
+}
+
+// SignText 告示牌一面的文字
+type SignText struct {
+	Messages [SignLines]string // JSON 文本组件
+	Lines    [SignLines]string // 纯文本
+	Color    string            // 染色，如 black、red
+	Glowing  bool              // 荧光墨囊
+}
+
+// Sign 告示牌方块实体
+type Sign struct {
+	Pos     BlockPos
+	Hanging bool // 悬挂式告示牌
+	Front   SignText
+	Back    SignText
+	Waxed   bool // 涂蜡后无法编辑
+}
+
+// Text 返回指定一面的文字
+func (s Sign) Text(front bool) SignText {
+	if front {
+		return s.Front
+	}
+	return s.Back
+}
+
+// SetSign 记录告示牌，覆盖同一位置的旧数据
+func (s *State) SetSign(sign Sign) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.signs == nil {
+		s.signs = make(map[BlockPos]Sign)
+	}
+	s.signs[sign.Pos] = sign
+}
+
+// RemoveSign 移除告示牌 (方块实体被替换为其他类型)
+func (s *State) RemoveSign(pos BlockPos) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.signs, pos)
+}
+
+// Sign 返回指定位置的告示牌
+func (s *State) Sign(pos BlockPos) (Sign, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sign, ok := s.signs[pos]
+	return sign, ok
+}
+
+// Signs 返回所有已加载的告示牌，按坐标排序
+func (s *State) Signs() []Sign {
+	s.mu.RLock()
+	signs := make([]Sign, 0, len(s.signs))
+	for _, sign := range s.signs {
+		signs = append(signs, sign)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(signs, func(i, j int) bool {
+		a, b := signs[i].Pos, signs[j].Pos
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		if a.Z != b.Z {
+			return a.Z < b.Z
+		}
+		return a.Y < b.Y
+	})
+	return signs
+}
+
+// ForgetChunk 区块卸载时移除其中的告示牌
+func (s *State) ForgetChunk(chunkX, chunkZ int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for pos := range s.signs {
+		if x, z := pos.Chunk(); x == chunkX && z == chunkZ {
+			delete(s.signs, pos)
+		}
+	}
+}
+
+// ClearSigns 切换世界时清空告示牌
+func (s *State) ClearSigns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signs = nil
+}
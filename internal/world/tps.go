@@ -0,0 +1,81 @@
+package world
+
+import "time"
+
+// 服务器每秒发送一次 set_time，保留最长窗口内的采样
+const (
+	tpsWindowShort  = time.Minute
+	tpsWindowMedium = 5 * time.Minute
+	tpsWindowLong   = 15 * time.Minute
+	// 两次采样至少间隔这么久才计算，避免刚连接时的抖动
+	tpsMinSpan = 3 * time.Second
+)
+
+// TPS 三个滑动窗口内的平均 TPS，数据不足一个窗口时按已有数据计算
+type TPS struct {
+	OneMinute      float64
+	FiveMinutes    float64
+	FifteenMinutes float64
+}
+
+type tpsSample struct {
+	at       time.Time
+	worldAge int64
+}
+
+type tpsMeter struct {
+	samples []tpsSample
+}
+
+func (m *tpsMeter) add(now time.Time, worldAge int64) {
+	// 世界刻数倒退说明换了服务器，旧采样作废
+	if n := len(m.samples); n > 0 && worldAge < m.samples[n-1].worldAge {
+		m.samples = m.samples[:0]
+	}
+	m.samples = append(m.samples, tpsSample{at: now, worldAge: worldAge})
+
+	cutoff := now.Add(-tpsWindowLong)
+	drop := 0
+	for drop < len(m.samples)-1 && m.samples[drop+1].at.Before(cutoff) {
+		drop++
+	}
+	if drop > 0 {
+		m.samples = append(m.samples[:0], m.samples[drop:]...)
+	}
+}
+
+func (m *tpsMeter) estimate(now time.Time) (TPS, bool) {
+	if len(m.samples) < 2 {
+		return TPS{}, false
+	}
+	if span := m.samples[len(m.samples)-1].at.Sub(m.samples[0].at); span < tpsMinSpan {
+		return TPS{}, false
+	}
+	return TPS{
+		OneMinute:      m.rate(now, tpsWindowShort),
+		FiveMinutes:    m.rate(now, tpsWindowMedium),
+		FifteenMinutes: m.rate(now, tpsWindowLong),
+	}, true
+}
+
+// rate 用窗口内最早和最新的采样计算平均 TPS
+func (m *tpsMeter) rate(now time.Time, window time.Duration) float64 {
+	last := m.samples[len(m.samples)-1]
+	cutoff := now.Add(-window)
+	first := m.samples[0]
+	for _, sample := range m.samples {
+		if !sample.at.Before(cutoff) {
+			first = sample
+			break
+		}
+	}
+	if first.at.Equal(last.at) {
+		// 窗口内只有一个采样，退回到全部数据
+		first = m.samples[0]
+	}
+	seconds := last.at.Sub(first.at).Seconds()
+	if seconds <= 0 {
+		return 0
+	}
+	return float64(last.worldAge-first.worldAge) / seconds
+}
@@ -0,0 +1,187 @@
+// Package world 维护世界时间、天气、世界边界与告示牌，并根据 set_time 估算服务器 TPS
+package world
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// 一个游戏日的刻数
+const TicksPerDay = 24000
+
+// Time 世界时间
+type Time struct {
+	WorldAge      int64 // 世界总刻数，不受 doDaylightCycle 影响
+	DayTime       int64 // 一天中的时间累计值，/time set 会修改
+	DaylightCycle bool  // 昼夜循环是否在走
+}
+
+// TimeOfDay 返回当天的时间 (0-23999)，0 为日出
+func (t Time) TimeOfDay() int64 {
+	return ((t.DayTime % TicksPerDay) + TicksPerDay) % TicksPerDay
+}
+
+// Day 返回第几天，从 0 开始
+func (t Time) Day() int64 {
+	return t.DayTime / TicksPerDay
+}
+
+// IsNight 是否为夜晚 (怪物在露天生成的时段)
+func (t Time) IsNight() bool {
+	tod := t.TimeOfDay()
+	return tod >= 13000 && tod < 23000
+}
+
+// Weather 天气，水平由服务器逐渐调整
+type Weather struct {
+	Raining      bool
+	RainLevel    float32 // 0-1
+	ThunderLevel float32 // 0-1
+}
+
+// IsRaining 与原版一致，降雨水平超过 0.2 视为下雨
+func (w Weather) IsRaining() bool {
+	return w.RainLevel > 0.2
+}
+
+// IsThundering 与原版一致，雷暴水平超过 0.9 视为雷暴
+func (w Weather) IsThundering() bool {
+	return w.IsRaining() && w.ThunderLevel > 0.9
+}
+
+// Border 世界边界
+type Border struct {
+	CenterX, CenterZ float64
+	Size             float64 // 当前目标直径，正在缩放时为缩放终点
+	LerpFrom         float64
+	LerpStart        time.Time
+	LerpDuration     time.Duration
+	AbsoluteMaxSize  int32
+	WarningBlocks    int32
+	WarningTime      int32 // 秒
+}
+
+// CurrentSize 返回 now 时刻的直径，正在缩放时按线性插值计算
+func (b Border) CurrentSize(now time.Time) float64 {
+	if b.LerpDuration <= 0 || b.LerpStart.IsZero() {
+		return b.Size
+	}
+	elapsed := now.Sub(b.LerpStart)
+	if elapsed >= b.LerpDuration {
+		return b.Size
+	}
+	progress := float64(elapsed) / float64(b.LerpDuration)
+	return b.LerpFrom + (b.Size-b.LerpFrom)*progress
+}
+
+// Contains 判断坐标是否在 now 时刻的边界内
+func (b Border) Contains(x, z float64, now time.Time) bool {
+	half := b.CurrentSize(now) / 2
+	return math.Abs(x-b.CenterX) <= half && math.Abs(z-b.CenterZ) <= half
+}
+
+// State 当前世界的时间、天气和边界
+type State struct {
+	mu      sync.RWMutex
+	time    Time
+	weather Weather
+	border  Border
+	tps     tpsMeter
+	signs   map[BlockPos]Sign
+}
+
+func NewState() *State {
+	return &State{}
+}
+
+// SetTime 处理 set_time，同时记录 TPS 采样
+func (s *State) SetTime(t Time, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.time = t
+	s.tps.add(now, t.WorldAge)
+}
+
+// Time 返回世界时间
+func (s *State) Time() Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.time
+}
+
+// SetRaining 处理开始/停止降雨，与原版一样同时重置降雨水平
+func (s *State) SetRaining(raining bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.weather.Raining = raining
+	if raining {
+		s.weather.RainLevel = 0
+	} else {
+		s.weather.RainLevel = 1
+	}
+}
+
+// SetRainLevel 设置降雨水平
+func (s *State) SetRainLevel(level float32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.weather.RainLevel = clamp01(level)
+}
+
+// SetThunderLevel 设置雷暴水平
+func (s *State) SetThunderLevel(level float32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.weather.ThunderLevel = clamp01(level)
+}
+
+// Weather 返回天气
+func (s *State) Weather() Weather {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.weather
+}
+
+// UpdateBorder 修改世界边界
+func (s *State) UpdateBorder(apply func(*Border)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	apply(&s.border)
+}
+
+// Border 返回世界边界
+func (s *State) Border() Border {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.border
+}
+
+// TPS 返回 TPS 估算，采样不足时返回 false
+func (s *State) TPS(now time.Time) (TPS, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tps.estimate(now)
+}
+
+// ResetWeather 重生时原版会新建世界，服务器只在下雨时重新发送天气
+func (s *State) ResetWeather() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.weather = Weather{}
+}
+
+// Reset 重新登录或切换服务器时清空
+func (s *State) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.time = Time{}
+	s.weather = Weather{}
+	s.border = Border{}
+	s.tps = tpsMeter{}
+	s.signs = nil
+}
+
+func clamp01(v float32) float32 {
+	return min(max(v, 0), 1)
+}
@@ -0,0 +1,96 @@
+package world
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTPS(t *testing.T) {
+	var m tpsMeter
+	start := time.Unix(1000, 0)
+
+	m.add(start, 100)
+	if _, ok := m.estimate(start); ok {
+		t.Fatal("只有一个采样时不应给出 TPS")
+	}
+
+	// 前 5 分钟满速，之后 1 分钟只有 10 TPS
+	age := int64(100)
+	now := start
+	for i := 0; i < 300; i++ {
+		now = now.Add(time.Second)
+		age += 20
+		m.add(now, age)
+	}
+	for i := 0; i < 60; i++ {
+		now = now.Add(time.Second)
+		age += 10
+		m.add(now, age)
+	}
+
+	tps, ok := m.estimate(now)
+	if !ok {
+		t.Fatal("estimate() 没有数据")
+	}
+	if math.Abs(tps.OneMinute-10) > 0.01 {
+		t.Errorf("OneMinute = %.2f, want 10", tps.OneMinute)
+	}
+	if math.Abs(tps.FiveMinutes-18) > 0.01 {
+		t.Errorf("FiveMinutes = %.2f, want 18", tps.FiveMinutes)
+	}
+	// 数据不足 15 分钟时按全部数据计算
+	if want := float64(300*20+60*10) / 360; math.Abs(tps.FifteenMinutes-want) > 0.01 {
+		t.Errorf("FifteenMinutes = %.2f, want %.2f", tps.FifteenMinutes, want)
+	}
+
+	// 世界刻数倒退 (换服) 清空采样
+	m.add(now.Add(time.Second), 5)
+	if _, ok := m.estimate(now.Add(time.Second)); ok {
+		t.Error("换服后不应沿用旧采样")
+	}
+}
+
+func TestTPSDropsOldSamples(t *testing.T) {
+	var m tpsMeter
+	start := time.Unix(0, 0)
+	for i := 0; i <= 20*60; i++ {
+		m.add(start.Add(time.Duration(i)*time.Second), int64(i*20))
+	}
+	if got := m.samples[len(m.samples)-1].at.Sub(m.samples[0].at); got > tpsWindowLong+time.Second {
+		t.Errorf("保留了 %v 的采样", got)
+	}
+}
+
+func TestBorderCurrentSize(t *testing.T) {
+	start := time.Unix(0, 0)
+	b := Border{CenterX: 10, Size: 100, LerpFrom: 200, LerpStart: start, LerpDuration: 10 * time.Second}
+
+	if got := b.CurrentSize(start.Add(5 * time.Second)); got != 150 {
+		t.Errorf("缩放中 CurrentSize = %v, want 150", got)
+	}
+	if got := b.CurrentSize(start.Add(time.Minute)); got != 100 {
+		t.Errorf("缩放后 CurrentSize = %v, want 100", got)
+	}
+	if !b.Contains(59, 0, start.Add(time.Minute)) || b.Contains(61, 0, start.Add(time.Minute)) {
+		t.Error("Contains() 边界判断错误")
+	}
+}
+
+func TestTimeAndWeather(t *testing.T) {
+	tm := Time{DayTime: 3*TicksPerDay + 18000}
+	if tm.Day() != 3 || tm.TimeOfDay() != 18000 || !tm.IsNight() {
+		t.Errorf("Time = %+v, day=%d tod=%d", tm, tm.Day(), tm.TimeOfDay())
+	}
+
+	s := NewState()
+	s.SetRaining(true)
+	if s.Weather().IsRaining() {
+		t.Error("刚开始下雨时降雨水平为 0")
+	}
+	s.SetRainLevel(1)
+	s.SetThunderLevel(1.5)
+	if w := s.Weather(); !w.IsRaining() || !w.IsThundering() || w.ThunderLevel != 1 {
+		t.Errorf("Weather = %+v", w)
+	}
+}